@@ -16,11 +16,45 @@ func New() error {
 		fmt.Println("  strategic:  generate domain strategic diagram")
 		fmt.Println("     tactic:  generate domain tactic diagram")
 		fmt.Println("     normal:  generate normal arch diagram")
-		fmt.Println("       open:  open arch diagram")
+		fmt.Println("       open:  open arch diagram on the dddplayer website, uploading it in the url")
+		fmt.Println("      check:  check dependencies against architecture rules and aggregate boundaries")
+		fmt.Println("      cycle:  report package or object dependency cycles")
+		fmt.Println("    metrics:  report coupling and stability metrics per package and aggregate")
+		fmt.Println("    suggest:  suggest aggregate boundaries from object intimacy")
+		fmt.Println("     events:  report domain events and commands with their publishers and subscribers")
+		fmt.Println("      ports:  map domain and application interfaces to their adapters")
+		fmt.Println("  callgraph:  compare the calls found by two call graph algorithms")
+		fmt.Println("    callers:  trace the callers of a function back to its entry points")
+		fmt.Println("     routes:  list the http routes with their handler functions")
+		fmt.Println("      watch:  update the normal arch diagram whenever the go files change")
+		fmt.Println("       diff:  compare the domain model of two git revisions")
+		fmt.Println("      serve:  serve saved arch diagrams with a local viewer")
+		fmt.Println("     schema:  print the json schema of the exported model")
 		fmt.Println("    version:  show dddplayer command version")
 
 		fmt.Println("\nExample:")
 		fmt.Println("  dp normal -m ~/github/dddplayer/dp -p github.com/dddplayer/dp/internal/domain")
+		fmt.Println("  dp normal -m ~/github/dddplayer/dp -p github.com/dddplayer/dp/internal/domain -mf -start 'github.com/dddplayer/dp/internal/interfaces/cmd/*.Run'")
+		fmt.Println("  dp normal -m ~/github/dddplayer/dp -p github.com/dddplayer/dp/internal/domain -seq -format mermaid")
+		fmt.Println("  dp normal -m ~/github/dddplayer/dp -p github.com/dddplayer/dp/internal/domain -mf -mf-mode reach -max-depth 0")
+		fmt.Println("  dp normal -m ~/github/example/svc -p github.com/example/svc/internal/domain -routes -start 'GET /orders*'")
+		fmt.Println("  dp tactic -m ~/github/dddplayer/dp -p github.com/dddplayer/dp/internal/domain -format mermaid")
+		fmt.Println("  dp strategic -m ~/github/example/svc -p github.com/example/svc/internal/core -layout .dp-layout.json")
+		fmt.Println("  dp check -m ~/github/dddplayer/dp -r .dp-rules.json")
+		fmt.Println("  dp check -m ~/github/dddplayer/dp -aggregate")
+		fmt.Println("  dp cycle -m ~/github/dddplayer/dp -level object -diagram")
+		fmt.Println("  dp metrics -m ~/github/dddplayer/dp -format csv")
+		fmt.Println("  dp suggest -m ~/github/dddplayer/dp -diagram -format mermaid")
+		fmt.Println("  dp events -m ~/github/dddplayer/dp -diagram")
+		fmt.Println("  dp ports -m ~/github/dddplayer/dp -diagram -format mermaid")
+		fmt.Println("  dp callgraph -m ~/github/dddplayer/dp -p github.com/dddplayer/dp/internal/domain -base static -compare cha")
+		fmt.Println("  dp callers -m ~/github/dddplayer/dp -t 'github.com/dddplayer/dp/internal/domain/arch/entity/Arch.*'")
+		fmt.Println("  dp routes -m ~/github/example/svc")
+		fmt.Println("  dp watch -m ~/github/dddplayer/dp -p github.com/dddplayer/dp/internal/domain -interval 2s")
+		fmt.Println("  dp diff -m ~/github/dddplayer/dp -diagram main HEAD")
+
+		fmt.Println("\nAnalysis results are cached in .dddplayer-cache next to the dddplayer folder,")
+		fmt.Println("set DP_NO_CACHE=1 to analyse the code without it.")
 	}
 
 	err := topLevel.Parse(os.Args[1:])
@@ -55,6 +89,123 @@ func New() error {
 				return err
 			}
 
+		case "check":
+			checkCmd, err := cmd.NewCheckCmd(topLevel)
+			if err != nil {
+				return err
+			}
+			if err := checkCmd.Run(); err != nil {
+				return err
+			}
+
+		case "cycle":
+			cycleCmd, err := cmd.NewCycleCmd(topLevel)
+			if err != nil {
+				return err
+			}
+			if err := cycleCmd.Run(); err != nil {
+				return err
+			}
+
+		case "metrics":
+			metricsCmd, err := cmd.NewMetricsCmd(topLevel)
+			if err != nil {
+				return err
+			}
+			if err := metricsCmd.Run(); err != nil {
+				return err
+			}
+
+		case "suggest":
+			suggestCmd, err := cmd.NewSuggestCmd(topLevel)
+			if err != nil {
+				return err
+			}
+			if err := suggestCmd.Run(); err != nil {
+				return err
+			}
+
+		case "events":
+			eventsCmd, err := cmd.NewEventsCmd(topLevel)
+			if err != nil {
+				return err
+			}
+			if err := eventsCmd.Run(); err != nil {
+				return err
+			}
+
+		case "ports":
+			portsCmd, err := cmd.NewPortsCmd(topLevel)
+			if err != nil {
+				return err
+			}
+			if err := portsCmd.Run(); err != nil {
+				return err
+			}
+
+		case "callers":
+			callersCmd, err := cmd.NewCallersCmd(topLevel)
+			if err != nil {
+				return err
+			}
+			if err := callersCmd.Run(); err != nil {
+				return err
+			}
+
+		case "routes":
+			routesCmd, err := cmd.NewRoutesCmd(topLevel)
+			if err != nil {
+				return err
+			}
+			if err := routesCmd.Run(); err != nil {
+				return err
+			}
+
+		case "callgraph":
+			callGraphCmd, err := cmd.NewCallGraphCmd(topLevel)
+			if err != nil {
+				return err
+			}
+			if err := callGraphCmd.Run(); err != nil {
+				return err
+			}
+
+		case "watch":
+			watchCmd, err := cmd.NewWatchCmd(topLevel)
+			if err != nil {
+				return err
+			}
+			if err := watchCmd.Run(); err != nil {
+				return err
+			}
+
+		case "diff":
+			diffCmd, err := cmd.NewDiffCmd(topLevel)
+			if err != nil {
+				return err
+			}
+			if err := diffCmd.Run(); err != nil {
+				return err
+			}
+
+		case "schema":
+			schemaCmd, err := cmd.NewSchemaCmd(topLevel)
+			if err != nil {
+				return err
+			}
+			if err := schemaCmd.Run(); err != nil {
+				return err
+			}
+
+		case "serve":
+			serveCmd, err := cmd.NewServeCmd(topLevel)
+			if err != nil {
+				return err
+			}
+			if err := serveCmd.Run(); err != nil {
+				return err
+			}
+
 		case "normal":
 			normalCmd, err := cmd.NewNormalCmd(topLevel)
 			if err != nil {
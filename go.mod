@@ -4,10 +4,8 @@ go 1.21
 
 require (
 	golang.org/x/exp v0.0.0-20230321023759-10a507213a29
+	golang.org/x/mod v0.10.0
 	golang.org/x/tools v0.8.0
 )
 
-require (
-	golang.org/x/mod v0.10.0 // indirect
-	golang.org/x/sys v0.7.0 // indirect
-)
+require golang.org/x/sys v0.7.0 // indirect
@@ -0,0 +1,69 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/code"
+	"github.com/dddplayer/dp/internal/domain/code/entity"
+	"path/filepath"
+)
+
+type CodeCache interface {
+	Load(mainPkgPath, scope string) (*entity.Record, error)
+	Save(mainPkgPath, scope string, r *entity.Record) error
+}
+
+// visitCode reports the code of mainPkgPath within domain to handler and
+// returns the main package path. The code of unchanged modules is replayed
+// from cache instead of loading it again, a nil cache turns caching off.
+func visitCode(cache CodeCache, mainPkgPath, domain string, handler code.Handler,
+	algos ...CallGraph) (string, error) {
+	return visitCodeContext(context.Background(), cache, mainPkgPath, domain, handler, algos...)
+}
+
+func visitCodeContext(ctx context.Context, cache CodeCache, mainPkgPath, domain string, handler code.Handler,
+	algos ...CallGraph) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	var types []code.CallGraphType
+	for _, algo := range algos {
+		types = append(types, code.CallGraphType(algo))
+	}
+
+	var key, scope string
+	if cache != nil {
+		if k, err := entity.Fingerprint(mainPkgPath); err == nil {
+			key = k
+			if abs, err := filepath.Abs(mainPkgPath); err == nil {
+				scope = fmt.Sprintf("%s|%s|%v", abs, domain, types)
+			}
+		}
+	}
+	if scope != "" {
+		if r, err := cache.Load(mainPkgPath, scope); err == nil && r != nil && r.Key == key {
+			r.Replay(handler)
+			return r.MainPkgPath, nil
+		}
+	}
+
+	c, err := entity.NewCodeContext(ctx, mainPkgPath, domain)
+	if err != nil {
+		return "", err
+	}
+
+	if scope == "" {
+		return c.MainPkgPath(), c.Visit(handler, types...)
+	}
+
+	r := entity.NewRecord(key, c.MainPkgPath())
+	if err := c.Visit(r, types...); err != nil {
+		return "", err
+	}
+	r.Replay(handler)
+	// the cache only saves time, so failing to write it is not an error of the analysis
+	_ = cache.Save(mainPkgPath, scope, r)
+
+	return r.MainPkgPath, nil
+}
@@ -0,0 +1,59 @@
+package application
+
+import (
+	"github.com/dddplayer/dp/internal/domain/code"
+	"github.com/dddplayer/dp/internal/domain/code/entity"
+	"github.com/dddplayer/dp/internal/domain/code/valueobject"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type mockCodeCache struct {
+	record *entity.Record
+	scope  string
+}
+
+func (c *mockCodeCache) Load(mainPkgPath, scope string) (*entity.Record, error) {
+	c.scope = scope
+	return c.record, nil
+}
+
+func (c *mockCodeCache) Save(mainPkgPath, scope string, r *entity.Record) error {
+	c.record = r
+	return nil
+}
+
+type mockCodeHandler struct {
+	nodes []*code.Node
+}
+
+func (h *mockCodeHandler) NodeHandler(node *code.Node) { h.nodes = append(h.nodes, node) }
+func (h *mockCodeHandler) LinkHandler(link *code.Link) {}
+
+func TestVisitCode_CacheHit(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/shop\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	key, err := entity.Fingerprint(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := entity.NewRecord(key, "example.com/shop/cmd")
+	r.NodeHandler(&code.Node{Meta: valueobject.NewMeta("example.com/shop/order", "Order")})
+	cache := &mockCodeCache{record: r}
+
+	h := &mockCodeHandler{}
+	mainPkg, err := visitCode(cache, root, "example.com/shop", h, CallGraphStatic)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if mainPkg != "example.com/shop/cmd" || len(h.nodes) != 1 || h.nodes[0].Meta.Name() != "Order" {
+		t.Errorf("Expected cached record to be replayed, but got %s with %d nodes", mainPkg, len(h.nodes))
+	}
+	if cache.scope == "" {
+		t.Error("Expected cache to be consulted with the analysis scope")
+	}
+}
@@ -0,0 +1,58 @@
+package application
+
+import (
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"strings"
+)
+
+// Callers traces back every call reaching the target functions, glob patterns of fully qualified
+// function names, and lists the caller trees followed by the entry points reached.
+func Callers(mainPkgPath string, targets []string, maxDepth int, algo CallGraph,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache) ([]string, error) {
+
+	arch, err := moduleArchWith(mainPkgPath, algo, objRepo, relRepo, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	trees, err := arch.Callers(targets, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for _, t := range trees {
+		lines = append(lines, callerLines(t, 0)...)
+		entries := t.Entries()
+		lines = append(lines, fmt.Sprintf("entry points: %d", len(entries)))
+		for _, e := range entries {
+			lines = append(lines, fmt.Sprintf("    %s: %s", e.Entry, e.Function.ID()))
+		}
+	}
+	return lines, nil
+}
+
+func callerLines(c *valueobject.Caller, depth int) []string {
+	line := c.Function.ID()
+	if depth > 0 {
+		line = fmt.Sprintf("%s<- %s (%s)", strings.Repeat("    ", depth), line,
+			location(valueobject.NewRelationPos(c.Pos, nil)))
+	}
+	if c.Entry != "" {
+		line += fmt.Sprintf(" [%s]", c.Entry)
+	}
+	switch {
+	case c.Repeated:
+		line += " ..."
+	case c.Truncated:
+		line += " (depth limit)"
+	}
+
+	lines := []string{line}
+	for _, cc := range c.Callers {
+		lines = append(lines, callerLines(cc, depth+1)...)
+	}
+	return lines
+}
@@ -0,0 +1,48 @@
+package application
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"reflect"
+	"testing"
+)
+
+func TestCallerLines(t *testing.T) {
+	tree := &valueobject.Caller{
+		Function: &MockObjIdentifier{id: "test/internal/domain/order/Order.Place"},
+		Callers: []*valueobject.Caller{
+			{
+				Function: &MockObjIdentifier{id: "test/internal/application/Service.Place"},
+				Pos:      &MockPosition{filename: "service.go", line: 30},
+				Entry:    valueobject.EntryApplication,
+				Callers: []*valueobject.Caller{
+					{
+						Function: &MockObjIdentifier{id: "test/cmd/main"},
+						Pos:      &MockPosition{filename: "main.go", line: 12},
+						Entry:    valueobject.EntryMain,
+					},
+				},
+			},
+			{
+				Function: &MockObjIdentifier{id: "test/internal/domain/order/Order.retry"},
+				Pos:      &MockPosition{filename: "order.go", line: 40},
+				Repeated: true,
+			},
+			{
+				Function:  &MockObjIdentifier{id: "test/internal/domain/order/Order.Cancel"},
+				Pos:       &MockPosition{filename: "order.go", line: 52},
+				Truncated: true,
+			},
+		},
+	}
+
+	expected := []string{
+		"test/internal/domain/order/Order.Place",
+		"    <- test/internal/application/Service.Place (service.go:30) [application]",
+		"        <- test/cmd/main (main.go:12) [main]",
+		"    <- test/internal/domain/order/Order.retry (order.go:40) ...",
+		"    <- test/internal/domain/order/Order.Cancel (order.go:52) (depth limit)",
+	}
+	if lines := callerLines(tree, 0); !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Expected %v, but got %v", expected, lines)
+	}
+}
@@ -0,0 +1,61 @@
+package application
+
+import (
+	"fmt"
+	archFactory "github.com/dddplayer/dp/internal/domain/arch/factory"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"github.com/dddplayer/dp/internal/domain/code"
+)
+
+type CallGraph string
+
+const (
+	CallGraphStatic  CallGraph = CallGraph(code.CallGraphTypeStatic)
+	CallGraphCha     CallGraph = CallGraph(code.CallGraphTypeCha)
+	CallGraphRta     CallGraph = CallGraph(code.CallGraphTypeRta)
+	CallGraphPointer CallGraph = CallGraph(code.CallGraphTypePointer)
+)
+
+func ParseCallGraph(s string) (CallGraph, error) {
+	switch cg := CallGraph(s); cg {
+	case CallGraphStatic, CallGraphCha, CallGraphRta, CallGraphPointer:
+		return cg, nil
+	}
+	return "", fmt.Errorf("unsupported call graph algorithm %q", s)
+}
+
+func CompareCallGraphs(mainPkgPath, domain string, base, other CallGraph,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache) ([]string, error) {
+
+	if base == other {
+		return nil, fmt.Errorf("cannot compare call graph algorithm %q with itself", base)
+	}
+
+	arch, err := archFactory.NewArch(domain, objRepo, relRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := visitCode(cache, mainPkgPath, domain, arch.ObjectHandler(), base, other); err != nil {
+		return nil, err
+	}
+
+	return callDiffLines(arch.CompareCalls(string(base), string(other))), nil
+}
+
+func callDiffLines(d *valueobject.CallDiff) []string {
+	lines := []string{fmt.Sprintf("%d calls found by both %s and %s, %d only by %s, %d only by %s",
+		d.Common, d.Base, d.Other, len(d.OnlyBase), d.Base, len(d.OnlyOther), d.Other)}
+	for _, dep := range d.OnlyBase {
+		lines = append(lines, fmt.Sprintf("    %s: %s only: %s -> %s",
+			location(valueobject.NewRelationPos(dep.From().Position(), dep.DependsOn().Position())),
+			d.Base, dep.From().Identifier().ID(), dep.DependsOn().Identifier().ID()))
+	}
+	for _, dep := range d.OnlyOther {
+		lines = append(lines, fmt.Sprintf("    %s: %s only: %s -> %s",
+			location(valueobject.NewRelationPos(dep.From().Position(), dep.DependsOn().Position())),
+			d.Other, dep.From().Identifier().ID(), dep.DependsOn().Identifier().ID()))
+	}
+	return lines
+}
@@ -0,0 +1,66 @@
+package application
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"testing"
+)
+
+type mockCall struct {
+	from, to *mockPlacedObj
+}
+
+func (c *mockCall) Type() arch.RelationType { return arch.RelationTypeDependency }
+func (c *mockCall) From() arch.Object       { return c.from }
+func (c *mockCall) DependsOn() arch.Object  { return c.to }
+func (c *mockCall) Algorithm() string       { return "cha" }
+
+func TestParseCallGraph(t *testing.T) {
+	for _, s := range []string{"static", "cha", "rta", "pointer"} {
+		cg, err := ParseCallGraph(s)
+		if err != nil {
+			t.Errorf("ParseCallGraph(%q) returned unexpected error: %v", s, err)
+		}
+		if string(cg) != s {
+			t.Errorf("ParseCallGraph(%q) = %q", s, cg)
+		}
+	}
+
+	if _, err := ParseCallGraph("vta"); err == nil {
+		t.Error("Expected error for unsupported call graph algorithm")
+	}
+}
+
+func TestCompareCallGraphs_SameAlgorithm(t *testing.T) {
+	if _, err := CompareCallGraphs("", "", CallGraphCha, CallGraphCha, nil, nil, nil); err == nil {
+		t.Error("Expected error when comparing an algorithm with itself")
+	}
+}
+
+func TestCallDiffLines(t *testing.T) {
+	d := &valueobject.CallDiff{
+		Base:   "static",
+		Other:  "cha",
+		Common: 3,
+		OnlyOther: []arch.DependenceRelation{
+			&mockCall{
+				from: &mockPlacedObj{MockDomainObj{id: "test/order/Order.Place"}, &MockPosition{filename: "order.go", line: 12}},
+				to:   &mockPlacedObj{MockDomainObj{id: "test/order/Notifier.Notify"}, &MockPosition{filename: "notify.go", line: 5}},
+			},
+		},
+	}
+
+	lines := callDiffLines(d)
+	expected := []string{
+		"3 calls found by both static and cha, 0 only by static, 1 only by cha",
+		"    order.go:12: cha only: test/order/Order.Place -> test/order/Notifier.Notify",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d lines, but got %v", len(expected), lines)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("callDiffLines()[%d] = %s, expected %s", i, lines[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package application
+
+import (
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/arch"
+	archEntity "github.com/dddplayer/dp/internal/domain/arch/entity"
+	archFactory "github.com/dddplayer/dp/internal/domain/arch/factory"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"github.com/dddplayer/dp/internal/domain/code/entity"
+	"github.com/dddplayer/dp/internal/domain/export"
+	"strings"
+)
+
+// Check reports the violations of the rules, of the aggregate boundaries when aggregates is set,
+// and the aggregates without exactly one repository when repositories is set.
+func Check(mainPkgPath string, rulesData []byte, aggregates, repositories bool,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache) ([]string, error) {
+
+	var rules []*valueobject.Rule
+	if rulesData != nil {
+		rs, err := archFactory.NewRules(rulesData)
+		if err != nil {
+			return nil, err
+		}
+		rules = rs
+	}
+
+	arch, err := moduleArch(mainPkgPath, objRepo, relRepo, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	if rules != nil {
+		violations, err := arch.Check(rules)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range violations {
+			lines = append(lines, violationLine(v))
+		}
+	}
+
+	if aggregates {
+		violations, err := arch.AggregateBoundaryViolations()
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range violations {
+			lines = append(lines, boundaryViolationLine(v))
+		}
+	}
+
+	if repositories {
+		findings, err := arch.RepositoryFindings()
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range findings {
+			lines = append(lines, repositoryFindingLine(f))
+		}
+	}
+
+	return lines, nil
+}
+
+func moduleArch(mainPkgPath string,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache) (*archEntity.Arch, error) {
+	return moduleArchWith(mainPkgPath, CallGraphStatic, objRepo, relRepo, cache)
+}
+
+func moduleArchWith(mainPkgPath string, algo CallGraph,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache) (*archEntity.Arch, error) {
+
+	root, err := entity.ModuleRoot(mainPkgPath)
+	if err != nil {
+		return nil, err
+	}
+	modPath, err := modulePath(root)
+	if err != nil {
+		return nil, err
+	}
+
+	arch, err := archFactory.NewArch(modPath, objRepo, relRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := visitCode(cache, mainPkgPath, modPath, arch.ObjectHandler(), algo); err != nil {
+		return nil, err
+	}
+
+	return arch, nil
+}
+
+func violationLine(v *valueobject.Violation) string {
+	return fmt.Sprintf("%s: %s -> %s (%s) violates rule %q",
+		location(v.Pos), v.From.ID(), v.To.ID(), export.RelationTypeNames[v.Type], v.Rule.Name)
+}
+
+func boundaryViolationLine(v *valueobject.BoundaryViolation) string {
+	reason := "bypasses the root of aggregate"
+	if v.Kind == valueobject.BoundaryViolationCall {
+		reason = "mutates a non-root entity of aggregate"
+	}
+	return fmt.Sprintf("%s: %s -> %s (%s) %s %q",
+		location(v.Pos), v.From.ID(), v.To.ID(), export.RelationTypeNames[v.Type], reason, v.ToAggregate)
+}
+
+func repositoryFindingLine(f *valueobject.RepositoryFinding) string {
+	if len(f.Repositories) == 0 {
+		return fmt.Sprintf("aggregate %q has no repository", f.Aggregate)
+	}
+	var ids []string
+	for _, r := range f.Repositories {
+		ids = append(ids, r.ID())
+	}
+	return fmt.Sprintf("aggregate %q has %d repositories: %s", f.Aggregate, len(ids), strings.Join(ids, ", "))
+}
+
+func location(pos arch.RelationPos) string {
+	if pos == nil || pos.From() == nil {
+		return "unknown"
+	}
+	return fmt.Sprintf("%s:%d", pos.From().Filename(), pos.From().Line())
+}
@@ -0,0 +1,81 @@
+package application
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"testing"
+)
+
+func TestCheck_RulesError(t *testing.T) {
+	_, err := Check("", []byte(`{"rules": []}`), false, false, nil, nil, nil)
+	if err == nil || err.Error() != "no rules defined" {
+		t.Errorf("Expected rules error, but got: %v", err)
+	}
+}
+
+func TestViolationLine(t *testing.T) {
+	v := &valueobject.Violation{
+		Rule: &valueobject.Rule{Name: "domain independent"},
+		From: &MockObjIdentifier{id: "test/internal/domain/a"},
+		To:   &MockObjIdentifier{id: "test/internal/infrastructure/b"},
+		Type: arch.RelationTypeDependency,
+		Pos: valueobject.NewRelationPos(
+			&MockPosition{filename: "a.go", line: 12},
+			&MockPosition{filename: "b.go", line: 3}),
+	}
+
+	expected := `a.go:12: test/internal/domain/a -> test/internal/infrastructure/b (dependency) violates rule "domain independent"`
+	if res := violationLine(v); res != expected {
+		t.Errorf("violationLine() = %s, expected %s", res, expected)
+	}
+
+	v.Pos = nil
+	expected = `unknown: test/internal/domain/a -> test/internal/infrastructure/b (dependency) violates rule "domain independent"`
+	if res := violationLine(v); res != expected {
+		t.Errorf("violationLine() = %s, expected %s", res, expected)
+	}
+}
+
+func TestBoundaryViolationLine(t *testing.T) {
+	v := &valueobject.BoundaryViolation{
+		Kind:          valueobject.BoundaryViolationReference,
+		From:          &MockObjIdentifier{id: "test/internal/domain/customer/entity/Customer"},
+		To:            &MockObjIdentifier{id: "test/internal/domain/order/entity/Line"},
+		FromAggregate: "customer",
+		ToAggregate:   "order",
+		Type:          arch.RelationTypeAssociationOneOne,
+		Pos: valueobject.NewRelationPos(
+			&MockPosition{filename: "customer.go", line: 8},
+			&MockPosition{filename: "line.go", line: 3}),
+	}
+
+	expected := `customer.go:8: test/internal/domain/customer/entity/Customer -> test/internal/domain/order/entity/Line (associationOneOne) bypasses the root of aggregate "order"`
+	if res := boundaryViolationLine(v); res != expected {
+		t.Errorf("boundaryViolationLine() = %s, expected %s", res, expected)
+	}
+
+	v.Kind = valueobject.BoundaryViolationCall
+	v.Type = arch.RelationTypeDependency
+	expected = `customer.go:8: test/internal/domain/customer/entity/Customer -> test/internal/domain/order/entity/Line (dependency) mutates a non-root entity of aggregate "order"`
+	if res := boundaryViolationLine(v); res != expected {
+		t.Errorf("boundaryViolationLine() = %s, expected %s", res, expected)
+	}
+}
+
+func TestRepositoryFindingLine(t *testing.T) {
+	f := &valueobject.RepositoryFinding{Aggregate: "payment"}
+
+	expected := `aggregate "payment" has no repository`
+	if res := repositoryFindingLine(f); res != expected {
+		t.Errorf("repositoryFindingLine() = %s, expected %s", res, expected)
+	}
+
+	f.Repositories = []arch.ObjIdentifier{
+		&MockObjIdentifier{id: "test/internal/domain/payment/repository/Payments"},
+		&MockObjIdentifier{id: "test/internal/domain/payment/repository/Ledger"},
+	}
+	expected = `aggregate "payment" has 2 repositories: test/internal/domain/payment/repository/Payments, test/internal/domain/payment/repository/Ledger`
+	if res := repositoryFindingLine(f); res != expected {
+		t.Errorf("repositoryFindingLine() = %s, expected %s", res, expected)
+	}
+}
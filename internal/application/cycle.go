@@ -0,0 +1,67 @@
+package application
+
+import (
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"github.com/dddplayer/dp/internal/domain/export"
+	"strings"
+)
+
+func ParseCycleLevel(s string) (valueobject.CycleLevel, error) {
+	switch l := valueobject.CycleLevel(s); l {
+	case valueobject.CycleLevelPackage, valueobject.CycleLevelObject:
+		return l, nil
+	}
+	return "", fmt.Errorf("unsupported cycle level %q", s)
+}
+
+func Cycles(mainPkgPath string, level valueobject.CycleLevel,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache) ([]string, error) {
+
+	arch, err := moduleArch(mainPkgPath, objRepo, relRepo, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	cycles, err := arch.Cycles(level)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for _, c := range cycles {
+		lines = append(lines, cycleLines(c)...)
+	}
+	return lines, nil
+}
+
+func CycleGraph(mainPkgPath string, level valueobject.CycleLevel,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache,
+	format Format) (string, error) {
+
+	arch, err := moduleArch(mainPkgPath, objRepo, relRepo, cache)
+	if err != nil {
+		return "", err
+	}
+
+	if format == FormatJSON {
+		return exportModel(arch)
+	}
+
+	g, err := arch.CycleDiagram(level)
+	if err != nil {
+		return "", err
+	}
+
+	return render(g, format)
+}
+
+func cycleLines(c *valueobject.Cycle) []string {
+	lines := []string{fmt.Sprintf("%s cycle: %s", c.Level, strings.Join(c.Members, " <-> "))}
+	for _, e := range c.Edges {
+		lines = append(lines, fmt.Sprintf("    %s: %s -> %s (%s)",
+			location(e.Pos), e.From.ID(), e.To.ID(), export.RelationTypeNames[e.Type]))
+	}
+	return lines
+}
@@ -0,0 +1,49 @@
+package application
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"testing"
+)
+
+func TestParseCycleLevel(t *testing.T) {
+	for _, s := range []string{"package", "object"} {
+		if l, err := ParseCycleLevel(s); err != nil || string(l) != s {
+			t.Errorf("ParseCycleLevel(%s) = %s, %v", s, l, err)
+		}
+	}
+	if _, err := ParseCycleLevel("module"); err == nil {
+		t.Error("Expected error for unsupported level, but got nil")
+	}
+}
+
+func TestCycleLines(t *testing.T) {
+	c := &valueobject.Cycle{
+		Level:   valueobject.CycleLevelPackage,
+		Members: []string{"test/a", "test/b"},
+		Edges: []*valueobject.CycleEdge{
+			{
+				From: &MockObjIdentifier{id: "test/a/fa"},
+				To:   &MockObjIdentifier{id: "test/b/fb"},
+				Type: arch.RelationTypeDependency,
+				Pos: valueobject.NewRelationPos(
+					&MockPosition{filename: "a.go", line: 7},
+					&MockPosition{filename: "b.go", line: 3}),
+			},
+		},
+	}
+
+	lines := cycleLines(c)
+	expected := []string{
+		"package cycle: test/a <-> test/b",
+		"    a.go:7: test/a/fa -> test/b/fb (dependency)",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d lines, but got %v", len(expected), lines)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("cycleLines()[%d] = %s, expected %s", i, lines[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"github.com/dddplayer/dp/internal/domain/arch"
+	archEntity "github.com/dddplayer/dp/internal/domain/arch/entity"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	exportEntity "github.com/dddplayer/dp/internal/domain/export/entity"
+)
+
+// StrategicDiagram analyses the code like StrategicGraph, returning the diagram with the
+// exported model instead of rendering them.
+func StrategicDiagram(ctx context.Context, mainPkgPath, domain string, algo CallGraph, layoutData []byte,
+	objRepo repository.ObjectRepository,
+	relRepo repository.RelationRepository, cache CodeCache) (arch.Diagram, *exportEntity.Model, error) {
+
+	a, err := layoutArch(ctx, mainPkgPath, domain, algo, layoutData, objRepo, relRepo, cache)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return diagramWithModel(a, a.StrategicGraph)
+}
+
+func TacticDiagram(ctx context.Context, mainPkgPath, domain string, algo CallGraph, layoutData []byte,
+	objRepo repository.ObjectRepository,
+	relRepo repository.RelationRepository, cache CodeCache,
+	all, composition bool) (arch.Diagram, *exportEntity.Model, error) {
+
+	a, err := layoutArch(ctx, mainPkgPath, domain, algo, layoutData, objRepo, relRepo, cache)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return diagramWithModel(a, func() (arch.Diagram, error) {
+		return a.TacticGraph(&options{all: all, composition: composition})
+	})
+}
+
+func GeneralDiagram(ctx context.Context, mainPkgPath, domain string, algo CallGraph,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache,
+	all, composition bool) (arch.Diagram, *exportEntity.Model, error) {
+
+	a, err := generalArch(ctx, mainPkgPath, domain, algo, objRepo, relRepo, cache)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return diagramWithModel(a, func() (arch.Diagram, error) {
+		return a.GeneralGraph(&options{all: all, composition: composition})
+	})
+}
+
+func MessageFlowDiagram(ctx context.Context, mainPkgPath, domain string, algo CallGraph,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache,
+	starts []string, end string, search valueobject.FlowSearch) (arch.Diagram, *exportEntity.Model, error) {
+
+	a, mainPkg, modPath, err := messageFlowArch(ctx, mainPkgPath, domain, algo, objRepo, relRepo, cache)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return diagramWithModel(a, func() (arch.Diagram, error) {
+		return a.MessageFlowDiagram(mainPkg, starts, flowEnd(domain, end), modPath, search)
+	})
+}
+
+// Render writes a diagram in one of the diagram formats, the model has its own json encoding.
+func Render(g arch.Diagram, format Format) (string, error) {
+	if format == FormatJSON {
+		return "", errors.New("json is not a diagram format, write the model instead")
+	}
+	return render(g, format)
+}
+
+func diagramWithModel(a *archEntity.Arch, build func() (arch.Diagram, error)) (arch.Diagram, *exportEntity.Model, error) {
+	g, err := build()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m, err := buildModel(a)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return g, m, nil
+}
@@ -0,0 +1,145 @@
+package application
+
+import (
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/arch"
+	archEntity "github.com/dddplayer/dp/internal/domain/arch/entity"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"github.com/dddplayer/dp/internal/domain/export"
+	exportEntity "github.com/dddplayer/dp/internal/domain/export/entity"
+	"path"
+	"strings"
+)
+
+type Revisions interface {
+	Checkout(dir, rev string) (string, func(), error)
+}
+
+type Repositories func() (repository.ObjectRepository, repository.RelationRepository)
+
+// RevisionDiff summarises how the domain model changes from base to head in markdown.
+func RevisionDiff(mainPkgPath, base, head string, revs Revisions, repos Repositories) ([]string, error) {
+	d, err := diffRevisions(mainPkgPath, base, head, revs, repos)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffLines(base, head, d), nil
+}
+
+func RevisionDiffGraph(mainPkgPath, base, head string, revs Revisions, repos Repositories,
+	format Format) (string, error) {
+
+	d, err := diffRevisions(mainPkgPath, base, head, revs, repos)
+	if err != nil {
+		return "", err
+	}
+	if d.Empty() {
+		return "", fmt.Errorf("no domain model changes between %s and %s", base, head)
+	}
+
+	objs, rels := diffChanges(d)
+	g, err := archEntity.ChangeDiagram(fmt.Sprintf("%s..%s", base, head), objs, rels)
+	if err != nil {
+		return "", err
+	}
+
+	return render(g, format)
+}
+
+func diffRevisions(mainPkgPath, base, head string, revs Revisions, repos Repositories) (*exportEntity.Diff, error) {
+	if base == head {
+		return nil, fmt.Errorf("cannot compare revision %q with itself", base)
+	}
+
+	baseModel, err := revisionModel(mainPkgPath, base, revs, repos)
+	if err != nil {
+		return nil, err
+	}
+	headModel, err := revisionModel(mainPkgPath, head, revs, repos)
+	if err != nil {
+		return nil, err
+	}
+
+	return exportEntity.Compare(baseModel, headModel), nil
+}
+
+func revisionModel(mainPkgPath, rev string, revs Revisions, repos Repositories) (*exportEntity.Model, error) {
+	dir, cleanup, err := revs.Checkout(mainPkgPath, rev)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	objRepo, relRepo := repos()
+	a, err := moduleArch(dir, objRepo, relRepo, nil)
+	if err != nil {
+		return nil, fmt.Errorf("revision %s: %w", rev, err)
+	}
+
+	return buildModel(a)
+}
+
+func diffLines(base, head string, d *exportEntity.Diff) []string {
+	if d.Empty() {
+		return []string{fmt.Sprintf("No domain model changes between `%s` and `%s`.", base, head)}
+	}
+
+	lines := []string{fmt.Sprintf("### Domain model changes `%s`..`%s`", base, head)}
+	if len(d.Aggregates) > 0 {
+		lines = append(lines, "", "#### Aggregates")
+		for _, a := range d.Aggregates {
+			lines = append(lines, withDetails(fmt.Sprintf("- %s `%s`", a.Change, path.Join(a.Domain, a.Name)), a.Details))
+		}
+	}
+	if len(d.Objects) > 0 {
+		lines = append(lines, "", "#### Objects")
+		for _, o := range d.Objects {
+			kind := o.Object.Role
+			if kind == "" {
+				kind = o.Object.Kind
+			}
+			lines = append(lines, withDetails(fmt.Sprintf("- %s %s `%s`", o.Change, kind, o.Object.ID), o.Details))
+		}
+	}
+	if len(d.Relations) > 0 {
+		lines = append(lines, "", "#### Relations")
+		for _, r := range d.Relations {
+			lines = append(lines, fmt.Sprintf("- %s %s `%s` -> `%s`", r.Change, r.Type, r.From, r.To))
+		}
+	}
+	return lines
+}
+
+func withDetails(line string, details []string) string {
+	if len(details) == 0 {
+		return line
+	}
+	return fmt.Sprintf("%s: %s", line, strings.Join(details, "; "))
+}
+
+func diffChanges(d *exportEntity.Diff) ([]*valueobject.ChangedObj, []*valueobject.ChangedRelation) {
+	var objs []*valueobject.ChangedObj
+	for _, a := range d.Aggregates {
+		objs = append(objs, valueobject.NewChangedObj(path.Join(a.Domain, a.Name), valueobject.ChangeStatus(a.Change)))
+	}
+	for _, o := range d.Objects {
+		objs = append(objs, valueobject.NewChangedObj(o.Object.ID, valueobject.ChangeStatus(o.Change)))
+	}
+
+	types := make(map[string]arch.RelationType)
+	for t, name := range export.RelationTypeNames {
+		types[name] = t
+	}
+	var rels []*valueobject.ChangedRelation
+	for _, r := range d.Relations {
+		rels = append(rels, &valueobject.ChangedRelation{
+			From:   r.From,
+			To:     r.To,
+			Type:   types[r.Type],
+			Status: valueobject.ChangeStatus(r.Change),
+		})
+	}
+	return objs, rels
+}
@@ -0,0 +1,59 @@
+package application
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	exportEntity "github.com/dddplayer/dp/internal/domain/export/entity"
+	"reflect"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	d := &exportEntity.Diff{
+		Aggregates: []*exportEntity.AggregateDiff{
+			{Name: "Order", Domain: "order", Change: exportEntity.ChangeChanged, Details: []string{"valueObject +app/order/Address"}},
+		},
+		Objects: []*exportEntity.ObjectDiff{
+			{Object: &exportEntity.Object{ID: "app/order/Address", Kind: "class", Role: "valueObject"}, Change: exportEntity.ChangeAdded},
+			{Object: &exportEntity.Object{ID: "app/order/Store", Kind: "interface"}, Change: exportEntity.ChangeRemoved},
+		},
+		Relations: []*exportEntity.RelationDiff{
+			{Type: "association", From: "app/order/Order", To: "app/order/Address", Change: exportEntity.ChangeAdded},
+		},
+	}
+
+	expected := []string{
+		"### Domain model changes `main`..`feature`",
+		"",
+		"#### Aggregates",
+		"- changed `order/Order`: valueObject +app/order/Address",
+		"",
+		"#### Objects",
+		"- added valueObject `app/order/Address`",
+		"- removed interface `app/order/Store`",
+		"",
+		"#### Relations",
+		"- added association `app/order/Order` -> `app/order/Address`",
+	}
+	if lines := diffLines("main", "feature", d); !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Expected %v, but got %v", expected, lines)
+	}
+
+	if lines := diffLines("main", "feature", &exportEntity.Diff{}); len(lines) != 1 {
+		t.Errorf("Expected a single line without changes, but got %v", lines)
+	}
+
+	objs, rels := diffChanges(d)
+	if len(objs) != 3 || objs[0].Identifier().ID() != "order/Order" || objs[0].Status != valueobject.ChangeStatusChanged {
+		t.Errorf("Expected the aggregate and the objects as changed objects, but got %v", objs)
+	}
+	if len(rels) != 1 || rels[0].Type != arch.RelationTypeAssociation || rels[0].Status != valueobject.ChangeStatusAdded {
+		t.Errorf("Expected an added association, but got %v", rels)
+	}
+}
+
+func TestRevisionDiff_SameRevision(t *testing.T) {
+	if _, err := RevisionDiff("", "main", "main", nil, nil); err == nil {
+		t.Errorf("Expected comparing a revision with itself to fail")
+	}
+}
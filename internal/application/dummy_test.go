@@ -14,6 +14,18 @@ func (mrr *MockRelationRepository) Insert(rel arch.Relation) error {
 	return nil
 }
 
+func (mrr *MockRelationRepository) Delete(match func(rel arch.Relation) bool) int {
+	var kept []arch.Relation
+	for _, rel := range mrr.relations {
+		if !match(rel) {
+			kept = append(kept, rel)
+		}
+	}
+	deleted := len(mrr.relations) - len(kept)
+	mrr.relations = kept
+	return deleted
+}
+
 func (mrr *MockRelationRepository) Walk(walker func(rel arch.Relation) error) {
 	for _, rel := range mrr.relations {
 		if err := walker(rel); err != nil {
@@ -55,6 +67,28 @@ func (mor *MockObjectRepository) Insert(obj arch.Object) error {
 	return nil
 }
 
+func (mor *MockObjectRepository) Update(obj arch.Object) error {
+	if _, ok := mor.objects[obj.Identifier().ID()]; !ok {
+		return errors.New("object not found in mock repository")
+	}
+	mor.objects[obj.Identifier().ID()] = obj
+	return nil
+}
+
+func (mor *MockObjectRepository) Delete(id arch.ObjIdentifier) error {
+	if _, ok := mor.objects[id.ID()]; !ok {
+		return errors.New("object not found in mock repository")
+	}
+	delete(mor.objects, id.ID())
+	for i, ident := range mor.idents {
+		if ident.ID() == id.ID() {
+			mor.idents = append(mor.idents[:i], mor.idents[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
 func (mor *MockObjectRepository) Walk(walker func(obj arch.Object) error) {
 	for _, obj := range mor.objects {
 		if err := walker(obj); err != nil {
@@ -80,3 +114,34 @@ func main() {
 	vo := &valueobject.VO{}
 	fmt.Println(t, vo)
 }`
+
+type MockObjIdentifier struct {
+	id string
+}
+
+func (m *MockObjIdentifier) ID() string               { return m.id }
+func (m *MockObjIdentifier) Name() string             { return m.id }
+func (m *MockObjIdentifier) NameSeparatorLength() int { return 1 }
+func (m *MockObjIdentifier) Dir() string              { return m.id }
+
+type MockPosition struct {
+	filename string
+	line     int
+}
+
+func (p *MockPosition) Filename() string { return p.filename }
+func (p *MockPosition) Offset() int      { return 0 }
+func (p *MockPosition) Line() int        { return p.line }
+func (p *MockPosition) Column() int      { return 0 }
+func (p *MockPosition) IsEqual(pos arch.Position) bool {
+	return p.filename == pos.Filename() && p.line == pos.Line()
+}
+
+type MockDomainObj struct {
+	id string
+}
+
+func (m *MockDomainObj) Identifier() arch.ObjIdentifier       { return &MockObjIdentifier{id: m.id} }
+func (m *MockDomainObj) OriginIdentifier() arch.ObjIdentifier { return &MockObjIdentifier{id: m.id} }
+func (m *MockDomainObj) Position() arch.Position              { return &MockPosition{} }
+func (m *MockDomainObj) Domain() string                       { return m.id }
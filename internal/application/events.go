@@ -0,0 +1,61 @@
+package application
+
+import (
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+)
+
+func EventFlows(mainPkgPath string,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache) ([]string, error) {
+
+	arch, err := moduleArch(mainPkgPath, objRepo, relRepo, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	flows, err := arch.EventFlows()
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for _, f := range flows {
+		lines = append(lines, eventFlowLines(f)...)
+	}
+	return lines, nil
+}
+
+func EventFlowGraph(mainPkgPath string,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache,
+	format Format) (string, error) {
+
+	arch, err := moduleArch(mainPkgPath, objRepo, relRepo, cache)
+	if err != nil {
+		return "", err
+	}
+
+	if format == FormatJSON {
+		return exportModel(arch)
+	}
+
+	g, err := arch.EventFlowDiagram()
+	if err != nil {
+		return "", err
+	}
+
+	return render(g, format)
+}
+
+func eventFlowLines(f *valueobject.EventFlow) []string {
+	lines := []string{fmt.Sprintf("%s %s (%s)", f.Kind, f.Message.Identifier().ID(), f.Context)}
+	for _, ep := range f.Publishers {
+		lines = append(lines, fmt.Sprintf("    %s: publisher %s",
+			location(valueobject.NewRelationPos(ep.Site, f.Message.Position())), ep.Function.Identifier().ID()))
+	}
+	for _, ep := range f.Subscribers {
+		lines = append(lines, fmt.Sprintf("    %s: subscriber %s",
+			location(valueobject.NewRelationPos(ep.Site, f.Message.Position())), ep.Function.Identifier().ID()))
+	}
+	return lines
+}
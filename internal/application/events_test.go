@@ -0,0 +1,35 @@
+package application
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"testing"
+)
+
+func TestEventFlowLines(t *testing.T) {
+	f := &valueobject.EventFlow{
+		Kind:    valueobject.MessageKindEvent,
+		Message: valueobject.NewClass(&MockDomainObj{id: "test/order/OrderPlaced"}, nil, nil),
+		Context: "order",
+		Publishers: []*valueobject.EventEndpoint{
+			{Function: &MockDomainObj{id: "test/order/Order.Place"}, Site: &MockPosition{filename: "order.go", line: 12}},
+		},
+		Subscribers: []*valueobject.EventEndpoint{
+			{Function: &MockDomainObj{id: "test/app/Notify"}, Site: &MockPosition{filename: "notify.go", line: 5}},
+		},
+	}
+
+	lines := eventFlowLines(f)
+	expected := []string{
+		"event " + f.Message.Identifier().ID() + " (order)",
+		"    order.go:12: publisher test/order/Order.Place",
+		"    notify.go:5: subscriber test/app/Notify",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d lines, but got %v", len(expected), lines)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("eventFlowLines()[%d] = %s, expected %s", i, lines[i], expected[i])
+		}
+	}
+}
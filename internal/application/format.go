@@ -0,0 +1,118 @@
+package application
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/arch"
+	archEntity "github.com/dddplayer/dp/internal/domain/arch/entity"
+	dotFactory "github.com/dddplayer/dp/internal/domain/dot/factory"
+	exportEntity "github.com/dddplayer/dp/internal/domain/export/entity"
+	exportFactory "github.com/dddplayer/dp/internal/domain/export/factory"
+	exportVO "github.com/dddplayer/dp/internal/domain/export/valueobject"
+	mermaidFactory "github.com/dddplayer/dp/internal/domain/mermaid/factory"
+	plantumlFactory "github.com/dddplayer/dp/internal/domain/plantuml/factory"
+)
+
+type Format string
+
+const (
+	FormatDot      Format = "dot"
+	FormatMermaid  Format = "mermaid"
+	FormatPlantUML Format = "plantuml"
+	FormatJSON     Format = "json"
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatDot, FormatMermaid, FormatPlantUML, FormatJSON:
+		return f, nil
+	}
+	return "", fmt.Errorf("unsupported output format %q", s)
+}
+
+func render(g arch.Diagram, f Format) (string, error) {
+	var buf bytes.Buffer
+
+	switch f {
+	case FormatMermaid:
+		m, err := mermaidFactory.NewMermaidBuilder(g).Build()
+		if err != nil {
+			return "", err
+		}
+		if err := m.Write(&buf); err != nil {
+			return "", err
+		}
+	case FormatPlantUML:
+		uml, err := plantumlFactory.NewPlantUMLBuilder(g).Build()
+		if err != nil {
+			return "", err
+		}
+		if err := uml.Write(&buf); err != nil {
+			return "", err
+		}
+	default:
+		dot, err := dotFactory.NewDotBuilder(g).Build()
+		if err != nil {
+			return "", err
+		}
+		if err := dot.Write(&buf); err != nil {
+			return "", err
+		}
+	}
+
+	return buf.String(), nil
+}
+
+func renderSequence(s arch.Sequence, f Format) (string, error) {
+	var buf bytes.Buffer
+
+	switch f {
+	case FormatMermaid:
+		m, err := mermaidFactory.NewSequenceBuilder(s).Build()
+		if err != nil {
+			return "", err
+		}
+		if err := m.Write(&buf); err != nil {
+			return "", err
+		}
+	case FormatPlantUML:
+		uml, err := plantumlFactory.NewSequenceBuilder(s).Build()
+		if err != nil {
+			return "", err
+		}
+		if err := uml.Write(&buf); err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("unsupported sequence diagram format %q", f)
+	}
+
+	return buf.String(), nil
+}
+
+func exportModel(a *archEntity.Arch) (string, error) {
+	m, err := buildModel(a)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := m.Write(&buf); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func buildModel(a *archEntity.Arch) (*exportEntity.Model, error) {
+	ags, err := a.Aggregates()
+	if err != nil {
+		return nil, err
+	}
+
+	return exportFactory.NewModelBuilder(a.Scope, a.ObjRepo, a.RelRepo, ags).Build()
+}
+
+func ModelSchema() string {
+	return exportVO.Schema
+}
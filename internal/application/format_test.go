@@ -0,0 +1,107 @@
+package application
+
+import (
+	"encoding/json"
+	"github.com/dddplayer/dp/internal/domain/arch"
+	archEntity "github.com/dddplayer/dp/internal/domain/arch/entity"
+	archFactory "github.com/dddplayer/dp/internal/domain/arch/factory"
+	"github.com/dddplayer/dp/internal/domain/export"
+	"strings"
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+	for _, s := range []string{"dot", "mermaid", "plantuml", "json"} {
+		f, err := ParseFormat(s)
+		if err != nil {
+			t.Errorf("ParseFormat(%q) returned unexpected error: %v", s, err)
+		}
+		if string(f) != s {
+			t.Errorf("ParseFormat(%q) = %q", s, f)
+		}
+	}
+
+	if _, err := ParseFormat("svg"); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestRender(t *testing.T) {
+	g, err := archEntity.NewDiagram("render", arch.PlainDiagram)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	dot, err := render(g, FormatDot)
+	if err != nil {
+		t.Errorf("render() returned unexpected error: %v", err)
+	}
+	if !strings.Contains(dot, "digraph") {
+		t.Errorf("Expected dot output, but got:\n%s", dot)
+	}
+
+	mmd, err := render(g, FormatMermaid)
+	if err != nil {
+		t.Errorf("render() returned unexpected error: %v", err)
+	}
+	if !strings.Contains(mmd, "flowchart LR") {
+		t.Errorf("Expected mermaid output, but got:\n%s", mmd)
+	}
+
+	uml, err := render(g, FormatPlantUML)
+	if err != nil {
+		t.Errorf("render() returned unexpected error: %v", err)
+	}
+	if !strings.Contains(uml, "@startuml") {
+		t.Errorf("Expected plantuml output, but got:\n%s", uml)
+	}
+}
+
+func TestRenderSequence(t *testing.T) {
+	s := archEntity.NewSequence("sequence")
+
+	mmd, err := renderSequence(s, FormatMermaid)
+	if err != nil {
+		t.Errorf("renderSequence() returned unexpected error: %v", err)
+	}
+	if !strings.Contains(mmd, "sequenceDiagram") {
+		t.Errorf("Expected mermaid sequence output, but got:\n%s", mmd)
+	}
+
+	uml, err := renderSequence(s, FormatPlantUML)
+	if err != nil {
+		t.Errorf("renderSequence() returned unexpected error: %v", err)
+	}
+	if !strings.Contains(uml, "title sequence") {
+		t.Errorf("Expected plantuml sequence output, but got:\n%s", uml)
+	}
+
+	if _, err := renderSequence(s, FormatDot); err == nil {
+		t.Error("Expected error for the dot format")
+	}
+}
+
+func TestExportModel(t *testing.T) {
+	a, err := archFactory.NewArch("scope", &MockObjectRepository{
+		objects: make(map[string]arch.Object),
+		idents:  []arch.ObjIdentifier{},
+	}, &MockRelationRepository{
+		relations: make([]arch.Relation, 0),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	out, err := exportModel(a)
+	if err != nil {
+		t.Fatalf("exportModel() returned unexpected error: %v", err)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &m); err != nil {
+		t.Fatalf("Expected json output, but got: %v", err)
+	}
+	if m["schemaVersion"] != export.SchemaVersion || m["scope"] != "scope" {
+		t.Errorf("Unexpected model header %v", m)
+	}
+}
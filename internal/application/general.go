@@ -1,44 +1,41 @@
 package application
 
 import (
-	"bytes"
+	"context"
+	archEntity "github.com/dddplayer/dp/internal/domain/arch/entity"
 	archFactory "github.com/dddplayer/dp/internal/domain/arch/factory"
 	"github.com/dddplayer/dp/internal/domain/arch/repository"
-	"github.com/dddplayer/dp/internal/domain/code/entity"
-	"github.com/dddplayer/dp/internal/domain/dot/factory"
 )
 
-func GeneralGraph(mainPkgPath, domain string,
-	objRepo repository.ObjectRepository, relRepo repository.RelationRepository) (string, error) {
-	return generateGeneralGraph(mainPkgPath, domain, objRepo, relRepo, false, false)
+func GeneralGraph(mainPkgPath, domain string, algo CallGraph,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache,
+	format Format) (string, error) {
+	return generateGeneralGraph(mainPkgPath, domain, algo, objRepo, relRepo, cache, false, false, format)
 }
 
-func CompositionGeneralGraph(mainPkgPath, domain string,
-	objRepo repository.ObjectRepository, relRepo repository.RelationRepository) (string, error) {
-	return generateGeneralGraph(mainPkgPath, domain, objRepo, relRepo, false, true)
+func CompositionGeneralGraph(mainPkgPath, domain string, algo CallGraph,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache,
+	format Format) (string, error) {
+	return generateGeneralGraph(mainPkgPath, domain, algo, objRepo, relRepo, cache, false, true, format)
 }
 
-func DetailGeneralGraph(mainPkgPath, domain string,
-	objRepo repository.ObjectRepository, relRepo repository.RelationRepository) (string, error) {
-	return generateGeneralGraph(mainPkgPath, domain, objRepo, relRepo, true, false)
+func DetailGeneralGraph(mainPkgPath, domain string, algo CallGraph,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache,
+	format Format) (string, error) {
+	return generateGeneralGraph(mainPkgPath, domain, algo, objRepo, relRepo, cache, true, false, format)
 }
 
-func generateGeneralGraph(mainPkgPath, domain string,
-	objRepo repository.ObjectRepository, relRepo repository.RelationRepository,
-	all, composition bool) (string, error) {
+func generateGeneralGraph(mainPkgPath, domain string, algo CallGraph,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache,
+	all, composition bool, format Format) (string, error) {
 
-	arch, err := archFactory.NewArch(domain, objRepo, relRepo)
-	if err != nil {
-		return "", err
-	}
-
-	c, err := entity.NewCode(mainPkgPath, domain)
+	arch, err := generalArch(context.Background(), mainPkgPath, domain, algo, objRepo, relRepo, cache)
 	if err != nil {
 		return "", err
 	}
 
-	if err := c.VisitFast(arch.ObjectHandler()); err != nil {
-		return "", err
+	if format == FormatJSON {
+		return exportModel(arch)
 	}
 
 	g, err := arch.GeneralGraph(&options{
@@ -49,15 +46,20 @@ func generateGeneralGraph(mainPkgPath, domain string,
 		return "", err
 	}
 
-	dot, err := factory.NewDotBuilder(g).Build()
+	return render(g, format)
+}
+
+func generalArch(ctx context.Context, mainPkgPath, domain string, algo CallGraph,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache) (*archEntity.Arch, error) {
+
+	arch, err := archFactory.NewArch(domain, objRepo, relRepo)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	var buf bytes.Buffer
-	if err := dot.Write(&buf); err != nil {
-		return "", err
+	if _, err := visitCodeContext(ctx, cache, mainPkgPath, domain, arch.ObjectHandler(), algo); err != nil {
+		return nil, err
 	}
 
-	return string(buf.Bytes()), nil
+	return arch, nil
 }
@@ -0,0 +1,49 @@
+package application
+
+import (
+	"context"
+	archEntity "github.com/dddplayer/dp/internal/domain/arch/entity"
+	archFactory "github.com/dddplayer/dp/internal/domain/arch/factory"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/domain/code/entity"
+)
+
+// applyLayout sets the layout of the arch, its paths are resolved against the module of mainPkgPath.
+func applyLayout(arch *archEntity.Arch, mainPkgPath string, layoutData []byte) error {
+	if layoutData == nil {
+		return nil
+	}
+
+	l, err := archFactory.NewLayout(layoutData)
+	if err != nil {
+		return err
+	}
+	if root, err := entity.ModuleRoot(mainPkgPath); err == nil {
+		if l.Module, err = modulePath(root); err != nil {
+			return err
+		}
+	}
+	arch.SetLayout(l)
+
+	return nil
+}
+
+// layoutArch analyses the domain of the strategic and tactic diagrams, grouping it by the layout.
+func layoutArch(ctx context.Context, mainPkgPath, domain string, algo CallGraph, layoutData []byte,
+	objRepo repository.ObjectRepository,
+	relRepo repository.RelationRepository, cache CodeCache) (*archEntity.Arch, error) {
+
+	arch, err := archFactory.NewArch(domain, objRepo, relRepo)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyLayout(arch, mainPkgPath, layoutData); err != nil {
+		return nil, err
+	}
+
+	if _, err := visitCodeContext(ctx, cache, mainPkgPath, domain, arch.ObjectHandler(), algo); err != nil {
+		return nil, err
+	}
+
+	return arch, nil
+}
@@ -0,0 +1,135 @@
+package application
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+type MetricsFormat string
+
+const (
+	MetricsFormatTable MetricsFormat = "table"
+	MetricsFormatJSON  MetricsFormat = "json"
+	MetricsFormatCSV   MetricsFormat = "csv"
+)
+
+func ParseMetricsFormat(s string) (MetricsFormat, error) {
+	switch f := MetricsFormat(s); f {
+	case MetricsFormatTable, MetricsFormatJSON, MetricsFormatCSV:
+		return f, nil
+	}
+	return "", fmt.Errorf("unsupported metrics format %q", s)
+}
+
+func Metrics(mainPkgPath string,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache,
+	format MetricsFormat) (string, error) {
+
+	arch, err := moduleArch(mainPkgPath, objRepo, relRepo, cache)
+	if err != nil {
+		return "", err
+	}
+
+	ms, err := arch.Metrics()
+	if err != nil {
+		return "", err
+	}
+
+	return renderMetrics(ms, format)
+}
+
+var metricsHeader = []string{
+	"kind", "name", "ca", "ce", "classes", "interfaces", "instability", "abstractness", "distance",
+}
+
+type metricJSON struct {
+	Kind         string  `json:"kind"`
+	Name         string  `json:"name"`
+	Afferent     int     `json:"ca"`
+	Efferent     int     `json:"ce"`
+	Classes      int     `json:"classes"`
+	Interfaces   int     `json:"interfaces"`
+	Instability  float64 `json:"instability"`
+	Abstractness float64 `json:"abstractness"`
+	Distance     float64 `json:"distance"`
+}
+
+func renderMetrics(ms []*valueobject.Metric, format MetricsFormat) (string, error) {
+	var buf bytes.Buffer
+
+	switch format {
+	case MetricsFormatJSON:
+		out := struct {
+			Metrics []metricJSON `json:"metrics"`
+		}{Metrics: []metricJSON{}}
+		for _, m := range ms {
+			out.Metrics = append(out.Metrics, metricJSON{
+				Kind:         string(m.Kind),
+				Name:         m.Name,
+				Afferent:     m.Afferent,
+				Efferent:     m.Efferent,
+				Classes:      m.Classes,
+				Interfaces:   m.Interfaces,
+				Instability:  round(m.Instability),
+				Abstractness: round(m.Abstractness),
+				Distance:     round(m.Distance),
+			})
+		}
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(out); err != nil {
+			return "", err
+		}
+	case MetricsFormatCSV:
+		w := csv.NewWriter(&buf)
+		if err := w.Write(metricsHeader); err != nil {
+			return "", err
+		}
+		for _, m := range ms {
+			if err := w.Write(metricRow(m)); err != nil {
+				return "", err
+			}
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return "", err
+		}
+	default:
+		w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, strings.Join(metricsHeader, "\t"))
+		for _, m := range ms {
+			_, _ = fmt.Fprintln(w, strings.Join(metricRow(m), "\t"))
+		}
+		if err := w.Flush(); err != nil {
+			return "", err
+		}
+	}
+
+	return buf.String(), nil
+}
+
+func metricRow(m *valueobject.Metric) []string {
+	return []string{
+		string(m.Kind),
+		m.Name,
+		strconv.Itoa(m.Afferent),
+		strconv.Itoa(m.Efferent),
+		strconv.Itoa(m.Classes),
+		strconv.Itoa(m.Interfaces),
+		strconv.FormatFloat(m.Instability, 'f', 2, 64),
+		strconv.FormatFloat(m.Abstractness, 'f', 2, 64),
+		strconv.FormatFloat(m.Distance, 'f', 2, 64),
+	}
+}
+
+func round(f float64) float64 {
+	v, _ := strconv.ParseFloat(strconv.FormatFloat(f, 'f', 4, 64), 64)
+	return v
+}
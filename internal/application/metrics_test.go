@@ -0,0 +1,57 @@
+package application
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"strings"
+	"testing"
+)
+
+func TestParseMetricsFormat(t *testing.T) {
+	for _, s := range []string{"table", "json", "csv"} {
+		if f, err := ParseMetricsFormat(s); err != nil || string(f) != s {
+			t.Errorf("ParseMetricsFormat(%s) = %s, %v", s, f, err)
+		}
+	}
+	if _, err := ParseMetricsFormat("xml"); err == nil {
+		t.Error("Expected error for unsupported format, but got nil")
+	}
+}
+
+func TestRenderMetrics(t *testing.T) {
+	ms := []*valueobject.Metric{
+		{Kind: valueobject.MetricKindPackage, Name: "test/a", Afferent: 1, Efferent: 2,
+			Classes: 2, Interfaces: 1, Instability: 2.0 / 3, Abstractness: 1.0 / 3, Distance: 0},
+		{Kind: valueobject.MetricKindAggregate, Name: "order", Afferent: 0, Efferent: 1,
+			Classes: 1, Instability: 1, Distance: 0},
+	}
+
+	out, err := renderMetrics(ms, MetricsFormatCSV)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	expected := "kind,name,ca,ce,classes,interfaces,instability,abstractness,distance\n" +
+		"package,test/a,1,2,2,1,0.67,0.33,0.00\n" +
+		"aggregate,order,0,1,1,0,1.00,0.00,0.00\n"
+	if out != expected {
+		t.Errorf("Unexpected csv output:\n%s", out)
+	}
+
+	out, err = renderMetrics(ms, MetricsFormatJSON)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	for _, s := range []string{`"kind": "package"`, `"ca": 1`, `"instability": 0.6667`} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Expected json output to contain %s, got:\n%s", s, out)
+		}
+	}
+
+	out, err = renderMetrics(ms, MetricsFormatTable)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "kind") || !strings.Contains(lines[1], "test/a") {
+		t.Errorf("Unexpected table output:\n%s", out)
+	}
+}
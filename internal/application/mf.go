@@ -1,66 +1,117 @@
 package application
 
 import (
-	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	archEntity "github.com/dddplayer/dp/internal/domain/arch/entity"
 	archFactory "github.com/dddplayer/dp/internal/domain/arch/factory"
 	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
 	"github.com/dddplayer/dp/internal/domain/code/entity"
-	"github.com/dddplayer/dp/internal/domain/dot/factory"
 	"golang.org/x/mod/modfile"
 	"os"
-	"path"
 	"path/filepath"
 )
 
-func MessageFlowGraph(mainPkgPath, domain string,
-	objRepo repository.ObjectRepository, relRepo repository.RelationRepository) (string, error) {
+func NewFlowSearch(mode string, maxDepth, maxPaths, maxSteps int) (valueobject.FlowSearch, error) {
+	switch m := valueobject.FlowMode(mode); m {
+	case valueobject.FlowModeAll, valueobject.FlowModeShortest, valueobject.FlowModeReach:
+		if maxDepth < 0 || maxPaths < 0 || maxSteps < 0 {
+			return valueobject.FlowSearch{}, errors.New("message flow limits cannot be negative")
+		}
+		return valueobject.FlowSearch{Mode: m, MaxDepth: maxDepth, MaxPaths: maxPaths, MaxSteps: maxSteps}, nil
+	}
+	return valueobject.FlowSearch{}, fmt.Errorf("unsupported message flow mode %q", mode)
+}
 
-	goModFilePath, err := findGoModFile(mainPkgPath)
+// MessageFlowGraph traces the message flows from the start functions, glob patterns of fully
+// qualified function names, to the end package or function, which is the domain when empty.
+// Without start functions the flows begin at the main func, search bounds how the paths are found.
+func MessageFlowGraph(mainPkgPath, domain string, algo CallGraph,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache,
+	starts []string, end string, search valueobject.FlowSearch, format Format) (string, error) {
+
+	arch, mainPkg, modPath, err := messageFlowArch(context.Background(), mainPkgPath, domain, algo, objRepo, relRepo, cache)
 	if err != nil {
 		return "", err
 	}
 
-	modPath, err := modulePath(goModFilePath)
+	if format == FormatJSON {
+		return exportModel(arch)
+	}
+
+	g, err := arch.MessageFlowDiagram(mainPkg, starts, flowEnd(domain, end), modPath, search)
 	if err != nil {
 		return "", err
 	}
 
-	arch, err := archFactory.NewArch(modPath, objRepo, relRepo)
+	return render(g, format)
+}
+
+// MessageFlowSequence renders the message flows as a sequence diagram in mermaid or plantuml,
+// the calls ordered by their call sites.
+func MessageFlowSequence(mainPkgPath, domain string, algo CallGraph,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache,
+	starts []string, end string, search valueobject.FlowSearch, format Format) (string, error) {
+
+	if format != FormatMermaid && format != FormatPlantUML {
+		return "", fmt.Errorf("sequence diagrams are rendered as mermaid or plantuml, not %s", format)
+	}
+
+	arch, mainPkg, modPath, err := messageFlowArch(context.Background(), mainPkgPath, domain, algo, objRepo, relRepo, cache)
 	if err != nil {
 		return "", err
 	}
 
-	c, err := entity.NewCode(mainPkgPath, modPath)
+	s, err := arch.MessageFlowSequence(mainPkg, starts, flowEnd(domain, end), modPath, search)
 	if err != nil {
 		return "", err
 	}
 
-	if err := c.VisitFast(arch.ObjectHandler()); err != nil {
-		return "", err
+	return renderSequence(s, format)
+}
+
+// messageFlowArch analyses the whole module, returning the main package and module paths
+// the message flows are traced between. Without a go.mod above mainPkgPath only the domain is analysed.
+func messageFlowArch(ctx context.Context, mainPkgPath, domain string, algo CallGraph,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache) (*archEntity.Arch, string, string, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, "", "", err
 	}
 
-	g, err := arch.MessageFlowDiagram(c.MainPkgPath(), domain, modPath)
-	if err != nil {
-		return "", err
+	modPath := domain
+	if root, err := entity.ModuleRoot(mainPkgPath); err == nil {
+		if modPath, err = modulePath(root); err != nil {
+			return nil, "", "", err
+		}
 	}
 
-	dot, err := factory.NewDotBuilder(g).Build()
+	arch, err := archFactory.NewArch(modPath, objRepo, relRepo)
 	if err != nil {
-		return "", err
+		return nil, "", "", err
 	}
 
-	var buf bytes.Buffer
-	if err := dot.Write(&buf); err != nil {
-		return "", err
+	mainPkg, err := visitCodeContext(ctx, cache, mainPkgPath, modPath, arch.ObjectHandler(), algo)
+	if err != nil {
+		return nil, "", "", err
 	}
 
-	return string(buf.Bytes()), nil
+	return arch, mainPkg, modPath, nil
+}
+
+func flowEnd(domain, end string) string {
+	if end == "" {
+		return domain
+	}
+	return end
 }
 
-func modulePath(modFilePath string) (string, error) {
+// modulePath returns the module path declared in the go.mod of the module root.
+func modulePath(root string) (string, error) {
 	// 读取go.mod文件内容
-	modBytes, err := os.ReadFile(modFilePath)
+	modBytes, err := os.ReadFile(filepath.Join(root, "go.mod"))
 	if err != nil {
 		return "", err
 	}
@@ -74,23 +125,3 @@ func modulePath(modFilePath string) (string, error) {
 	// 打印模块的名称和版本
 	return modFile.Module.Mod.Path, nil
 }
-
-func findGoModFile(startDir string) (string, error) {
-	// 从指定的目录开始向上逐级查找包含 go.mod 文件的目录
-	dir := startDir
-	for {
-		goModPath := filepath.Join(dir, "go.mod")
-		_, err := os.Stat(goModPath)
-		if err == nil {
-			return path.Join(dir, "go.mod"), nil
-		}
-
-		// 如果已经到达根目录，仍未找到 go.mod 文件，则返回错误
-		if dir == "/" || dir == "." {
-			return "", fmt.Errorf("cannot find go.mod file")
-		}
-
-		// 向上级目录移动一级
-		dir = filepath.Dir(dir)
-	}
-}
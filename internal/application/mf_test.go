@@ -0,0 +1,41 @@
+package application
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"testing"
+)
+
+func TestNewFlowSearch(t *testing.T) {
+	s, err := NewFlowSearch("shortest", 8, 3, 100)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := valueobject.FlowSearch{Mode: valueobject.FlowModeShortest, MaxDepth: 8, MaxPaths: 3, MaxSteps: 100}
+	if s != expected {
+		t.Errorf("Expected %+v, but got %+v", expected, s)
+	}
+
+	for _, c := range []struct {
+		mode                      string
+		depth, maxPaths, maxSteps int
+	}{
+		{"longest", 0, 0, 0},
+		{"", 0, 0, 0},
+		{"all", -1, 0, 0},
+		{"reach", 0, -1, 0},
+		{"all", 0, 0, -1},
+	} {
+		if _, err := NewFlowSearch(c.mode, c.depth, c.maxPaths, c.maxSteps); err == nil {
+			t.Errorf("Expected %+v to be rejected", c)
+		}
+	}
+}
+
+func TestFlowEnd(t *testing.T) {
+	if e := flowEnd("example.com/app/domain", ""); e != "example.com/app/domain" {
+		t.Errorf("Expected the domain by default, but got %s", e)
+	}
+	if e := flowEnd("example.com/app/domain", "example.com/app/infra"); e != "example.com/app/infra" {
+		t.Errorf("Expected the given end, but got %s", e)
+	}
+}
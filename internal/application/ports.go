@@ -0,0 +1,68 @@
+package application
+
+import (
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+)
+
+func Ports(mainPkgPath string,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache) ([]string, error) {
+
+	arch, err := moduleArch(mainPkgPath, objRepo, relRepo, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	ports, err := arch.Ports()
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for _, p := range ports {
+		lines = append(lines, portLines(p)...)
+	}
+	return lines, nil
+}
+
+func PortsGraph(mainPkgPath string,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache,
+	format Format) (string, error) {
+
+	arch, err := moduleArch(mainPkgPath, objRepo, relRepo, cache)
+	if err != nil {
+		return "", err
+	}
+
+	if format == FormatJSON {
+		return exportModel(arch)
+	}
+
+	g, err := arch.PortsDiagram()
+	if err != nil {
+		return "", err
+	}
+
+	return render(g, format)
+}
+
+func portLines(p *valueobject.Port) []string {
+	var summary string
+	switch len(p.Adapters) {
+	case 0:
+		summary = "no adapter"
+	case 1:
+		summary = "1 adapter"
+	default:
+		summary = fmt.Sprintf("%d adapters", len(p.Adapters))
+	}
+
+	lines := []string{fmt.Sprintf("%s port %s: %s", p.Layer, p.Interface.Identifier().ID(), summary)}
+	for _, a := range p.Adapters {
+		lines = append(lines, fmt.Sprintf("    %s: %s (%s)",
+			location(valueobject.NewRelationPos(a.Object.Position(), p.Interface.Position())),
+			a.Object.Identifier().ID(), a.Layer))
+	}
+	return lines
+}
@@ -0,0 +1,72 @@
+package application
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"testing"
+)
+
+type mockPlacedObj struct {
+	MockDomainObj
+	pos *MockPosition
+}
+
+func (m *mockPlacedObj) Position() arch.Position { return m.pos }
+
+func TestPortLines(t *testing.T) {
+	port := valueobject.NewInterface(&MockDomainObj{id: "test/internal/domain/order/Repository"}, nil)
+
+	tests := []struct {
+		name     string
+		adapters []*valueobject.Adapter
+		expected []string
+	}{
+		{
+			name:     "No adapter",
+			expected: []string{"domain port " + port.Identifier().ID() + ": no adapter"},
+		},
+		{
+			name: "Single adapter",
+			adapters: []*valueobject.Adapter{
+				{Object: &mockPlacedObj{MockDomainObj{id: "test/internal/infrastructure/OrderRepo"},
+					&MockPosition{filename: "repo.go", line: 8}}, Layer: arch.HexagonDirectoryInfrastructure},
+			},
+			expected: []string{
+				"domain port " + port.Identifier().ID() + ": 1 adapter",
+				"    repo.go:8: test/internal/infrastructure/OrderRepo (infrastructure)",
+			},
+		},
+		{
+			name: "Multiple adapters",
+			adapters: []*valueobject.Adapter{
+				{Object: &mockPlacedObj{MockDomainObj{id: "test/internal/infrastructure/OrderRepo"},
+					&MockPosition{filename: "repo.go", line: 8}}, Layer: arch.HexagonDirectoryInfrastructure},
+				{Object: &mockPlacedObj{MockDomainObj{id: "test/internal/interfaces/MemRepo"},
+					&MockPosition{filename: "mem.go", line: 3}}, Layer: arch.HexagonDirectoryInterfaces},
+			},
+			expected: []string{
+				"domain port " + port.Identifier().ID() + ": 2 adapters",
+				"    repo.go:8: test/internal/infrastructure/OrderRepo (infrastructure)",
+				"    mem.go:3: test/internal/interfaces/MemRepo (interfaces)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := portLines(&valueobject.Port{
+				Interface: port,
+				Layer:     arch.HexagonDirectoryDomain,
+				Adapters:  tt.adapters,
+			})
+			if len(lines) != len(tt.expected) {
+				t.Fatalf("Expected %d lines, but got %v", len(tt.expected), lines)
+			}
+			for i := range tt.expected {
+				if lines[i] != tt.expected[i] {
+					t.Errorf("portLines()[%d] = %s, expected %s", i, lines[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
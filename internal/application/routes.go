@@ -0,0 +1,28 @@
+package application
+
+import (
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+)
+
+// Routes lists the http routes registered in the module with their handler functions.
+func Routes(mainPkgPath string,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache) ([]string, error) {
+
+	arch, err := moduleArch(mainPkgPath, objRepo, relRepo, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for _, r := range arch.Routes() {
+		lines = append(lines, routeLine(r))
+	}
+	return lines, nil
+}
+
+func routeLine(r *valueobject.Route) string {
+	return fmt.Sprintf("%s -> %s [%s] (%s)", r.Name(), r.Handler.ID(), r.Router,
+		location(valueobject.NewRelationPos(r.Pos, nil)))
+}
@@ -0,0 +1,38 @@
+package application
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"testing"
+)
+
+func TestRouteLine(t *testing.T) {
+	tests := []struct {
+		route    *valueobject.Route
+		expected string
+	}{
+		{
+			&valueobject.Route{
+				Router:  "gin",
+				Method:  "GET",
+				Path:    "/v1/orders/:id",
+				Handler: &MockObjIdentifier{id: "test/internal/interfaces/api/Server.GetOrder"},
+				Pos:     &MockPosition{filename: "routes.go", line: 21},
+			},
+			"GET /v1/orders/:id -> test/internal/interfaces/api/Server.GetOrder [gin] (routes.go:21)",
+		},
+		{
+			&valueobject.Route{
+				Router:  "net/http",
+				Path:    "/",
+				Handler: &MockObjIdentifier{id: "test/cmd/main"},
+			},
+			"ANY / -> test/cmd/main [net/http] (unknown)",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := routeLine(tt.route); got != tt.expected {
+			t.Errorf("Expected %q, but got %q", tt.expected, got)
+		}
+	}
+}
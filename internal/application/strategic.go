@@ -1,35 +1,22 @@
 package application
 
 import (
-	"bytes"
-	archFactory "github.com/dddplayer/dp/internal/domain/arch/factory"
+	"context"
 	"github.com/dddplayer/dp/internal/domain/arch/repository"
-	"github.com/dddplayer/dp/internal/domain/code/entity"
-	"github.com/dddplayer/dp/internal/domain/dot/factory"
 )
 
-func StrategicGraph(mainPkgPath, domain string, deep bool,
+func StrategicGraph(mainPkgPath, domain string, algo CallGraph, layoutData []byte,
 	objRepo repository.ObjectRepository,
-	relRepo repository.RelationRepository) (string, error) {
+	relRepo repository.RelationRepository, cache CodeCache,
+	format Format) (string, error) {
 
-	arch, err := archFactory.NewArch(domain, objRepo, relRepo)
+	arch, err := layoutArch(context.Background(), mainPkgPath, domain, algo, layoutData, objRepo, relRepo, cache)
 	if err != nil {
 		return "", err
 	}
 
-	c, err := entity.NewCode(mainPkgPath, domain)
-	if err != nil {
-		return "", err
-	}
-
-	if deep {
-		if err := c.VisitDeep(arch.ObjectHandler()); err != nil {
-			return "", err
-		}
-	} else {
-		if err := c.VisitFast(arch.ObjectHandler()); err != nil {
-			return "", err
-		}
+	if format == FormatJSON {
+		return exportModel(arch)
 	}
 
 	g, err := arch.StrategicGraph()
@@ -37,15 +24,5 @@ func StrategicGraph(mainPkgPath, domain string, deep bool,
 		return "", err
 	}
 
-	dot, err := factory.NewDotBuilder(g).Build()
-	if err != nil {
-		return "", err
-	}
-
-	var buf bytes.Buffer
-	if err := dot.Write(&buf); err != nil {
-		return "", err
-	}
-
-	return string(buf.Bytes()), nil
+	return render(g, format)
 }
@@ -4,26 +4,14 @@ import (
 	"errors"
 	"github.com/dddplayer/dp/internal/domain/arch"
 	"github.com/dddplayer/dp/internal/domain/dot/valueobject"
-	"io/ioutil"
-	"os"
 	"path"
-	"reflect"
 	"strings"
 	"testing"
 )
 
 func TestStrategicGraph(t *testing.T) {
-	// create a temporary directory to hold the test package
-	tempDir, err := ioutil.TempDir(".", "testpkg")
-	if err != nil {
-		t.Fatalf("failed to create temp dir: %v", err)
-	}
-	defer func(path string) {
-		err := os.RemoveAll(path)
-		if err != nil {
-			t.Fatalf("failed to remove temp dir: %v", err)
-		}
-	}(tempDir)
+	// create a temporary module to hold the test package
+	tempDir := newTestModule(t)
 
 	// create a test package in the temporary directory
 	if err := createHexagonTestPackage(tempDir); err != nil {
@@ -39,9 +27,9 @@ func TestStrategicGraph(t *testing.T) {
 	}
 
 	result, err := StrategicGraph(tempDir,
-		path.Join(reflect.TypeOf(MockObjectRepository{}).PkgPath(), path.Base(tempDir)),
-		false,
-		mockRepo, mockRelRepo)
+		testModule,
+		CallGraphStatic, nil,
+		mockRepo, mockRelRepo, nil, FormatDot)
 
 	if err != nil {
 		t.Errorf("StrategicGraph() returned unexpected error:\nActual: %v", err)
@@ -55,7 +43,7 @@ func TestStrategicGraph(t *testing.T) {
 }
 
 func TestStrategicGraph_ArchFactoryError(t *testing.T) {
-	_, err := StrategicGraph("", "", false, nil, nil)
+	_, err := StrategicGraph("", "", CallGraphStatic, nil, nil, nil, nil, FormatDot)
 
 	if err == nil || err.Error() != "objRepo cannot be nil" {
 		t.Errorf("Expected error 'objRepo cannot be nil', but got: %v", err)
@@ -70,10 +58,41 @@ func TestStrategicGraph_EntityNewCodeError(t *testing.T) {
 	// 模拟 entity.NewCode 函数返回错误
 	expectedError := errors.New("packages contain errors")
 
-	_, err := StrategicGraph("non-exist", "dummy", false, mockObjRepo, mockRelRepo)
+	_, err := StrategicGraph("non-exist", "dummy", CallGraphStatic, nil, mockObjRepo, mockRelRepo, nil, FormatDot)
 
 	// 验证返回的错误是否符合预期
 	if err.Error() != expectedError.Error() {
 		t.Errorf("Expected error: %v, but got: %v", expectedError, err)
 	}
 }
+
+func TestStrategicGraph_ScopedLayout(t *testing.T) {
+	// create a temporary module to hold the test package
+	tempDir := newTestModule(t)
+
+	if err := createHexagonTestPackage(tempDir); err != nil {
+		t.Fatalf("failed to create test package: %v", err)
+	}
+
+	mockRelRepo := &MockRelationRepository{
+		relations: make([]arch.Relation, 0),
+	}
+	mockRepo := &MockObjectRepository{
+		objects: make(map[string]arch.Object),
+		idents:  []arch.ObjIdentifier{},
+	}
+
+	// the layout is relative to the module root, while the analysis is scoped to the domain
+	layout := []byte(`{"layout": {"domain": "internal/domain"}}`)
+	result, err := StrategicGraph(tempDir,
+		path.Join(testModule, "internal/domain"),
+		CallGraphStatic, layout,
+		mockRepo, mockRelRepo, nil, FormatDot)
+
+	if err != nil {
+		t.Fatalf("StrategicGraph() returned unexpected error:\nActual: %v", err)
+	}
+	if !strings.Contains(result, valueobject.GenerateShortURL("test_entity_Test")) {
+		t.Errorf("StrategicGraph() returned unexpected output:\nActual: %v", result)
+	}
+}
@@ -0,0 +1,60 @@
+package application
+
+import (
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+)
+
+func SuggestAggregates(mainPkgPath string, threshold float64,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache) ([]string, error) {
+
+	arch, err := moduleArch(mainPkgPath, objRepo, relRepo, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions, err := arch.SuggestAggregates(threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for _, sa := range suggestions {
+		lines = append(lines, suggestionLines(sa)...)
+	}
+	return lines, nil
+}
+
+func SuggestedAggregatesGraph(mainPkgPath string, threshold float64,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository, cache CodeCache,
+	format Format) (string, error) {
+
+	arch, err := moduleArch(mainPkgPath, objRepo, relRepo, cache)
+	if err != nil {
+		return "", err
+	}
+
+	if format == FormatJSON {
+		return exportModel(arch)
+	}
+
+	g, err := arch.SuggestedAggregatesDiagram(threshold)
+	if err != nil {
+		return "", err
+	}
+
+	return render(g, format)
+}
+
+func suggestionLines(sa *valueobject.SuggestedAggregate) []string {
+	lines := []string{fmt.Sprintf("suggested aggregate %s (%d objects)", sa.Name, len(sa.Members))}
+	for _, m := range sa.Members {
+		line := fmt.Sprintf("    %s", m.Object.Identifier().ID())
+		if m.Misplaced {
+			line = fmt.Sprintf("%s  misplaced: currently in aggregate %s", line, m.Aggregate)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
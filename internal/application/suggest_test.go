@@ -0,0 +1,31 @@
+package application
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"testing"
+)
+
+func TestSuggestionLines(t *testing.T) {
+	sa := &valueobject.SuggestedAggregate{
+		Name: "order",
+		Members: []*valueobject.SuggestedMember{
+			{Object: &MockDomainObj{id: "order/entity/Order"}, Aggregate: "order"},
+			{Object: &MockDomainObj{id: "customer/valueobject/Address"}, Aggregate: "customer", Misplaced: true},
+		},
+	}
+
+	lines := suggestionLines(sa)
+	expected := []string{
+		"suggested aggregate order (2 objects)",
+		"    order/entity/Order",
+		"    customer/valueobject/Address  misplaced: currently in aggregate customer",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d lines, but got %v", len(expected), lines)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("suggestionLines()[%d] = %s, expected %s", i, lines[i], expected[i])
+		}
+	}
+}
@@ -1,44 +1,38 @@
 package application
 
 import (
-	"bytes"
-	archFactory "github.com/dddplayer/dp/internal/domain/arch/factory"
+	"context"
 	"github.com/dddplayer/dp/internal/domain/arch/repository"
-	"github.com/dddplayer/dp/internal/domain/code/entity"
-	"github.com/dddplayer/dp/internal/domain/dot/factory"
 )
 
-func TacticGraph(mainPkgPath, domain string,
+func TacticGraph(mainPkgPath, domain string, algo CallGraph, layoutData []byte,
 	objRepo repository.ObjectRepository,
-	relRepo repository.RelationRepository) (string, error) {
+	relRepo repository.RelationRepository, cache CodeCache,
+	format Format) (string, error) {
 
-	return generateTacticGraph(mainPkgPath, domain, objRepo, relRepo, false, false)
+	return generateTacticGraph(mainPkgPath, domain, algo, layoutData, objRepo, relRepo, cache, false, false, format)
 }
 
-func DetailTacticGraph(mainPkgPath, domain string,
+func DetailTacticGraph(mainPkgPath, domain string, algo CallGraph, layoutData []byte,
 	objRepo repository.ObjectRepository,
-	relRepo repository.RelationRepository) (string, error) {
+	relRepo repository.RelationRepository, cache CodeCache,
+	format Format) (string, error) {
 
-	return generateTacticGraph(mainPkgPath, domain, objRepo, relRepo, true, false)
+	return generateTacticGraph(mainPkgPath, domain, algo, layoutData, objRepo, relRepo, cache, true, false, format)
 }
 
-func generateTacticGraph(mainPkgPath, domain string,
+func generateTacticGraph(mainPkgPath, domain string, algo CallGraph, layoutData []byte,
 	objRepo repository.ObjectRepository,
-	relRepo repository.RelationRepository,
-	all, composition bool) (string, error) {
+	relRepo repository.RelationRepository, cache CodeCache,
+	all, composition bool, format Format) (string, error) {
 
-	arch, err := archFactory.NewArch(domain, objRepo, relRepo)
+	arch, err := layoutArch(context.Background(), mainPkgPath, domain, algo, layoutData, objRepo, relRepo, cache)
 	if err != nil {
 		return "", err
 	}
 
-	c, err := entity.NewCode(mainPkgPath, domain)
-	if err != nil {
-		return "", err
-	}
-
-	if err := c.VisitFast(arch.ObjectHandler()); err != nil {
-		return "", err
+	if format == FormatJSON {
+		return exportModel(arch)
 	}
 
 	g, err := arch.TacticGraph(&options{
@@ -49,15 +43,5 @@ func generateTacticGraph(mainPkgPath, domain string,
 		return "", err
 	}
 
-	dot, err := factory.NewDotBuilder(g).Build()
-	if err != nil {
-		return "", err
-	}
-
-	var buf bytes.Buffer
-	if err := dot.Write(&buf); err != nil {
-		return "", err
-	}
-
-	return string(buf.Bytes()), nil
+	return render(g, format)
 }
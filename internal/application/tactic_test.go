@@ -6,25 +6,14 @@ import (
 	"github.com/dddplayer/dp/internal/domain/dot/valueobject"
 	"io/ioutil"
 	"os"
-	"path"
 	"path/filepath"
-	"reflect"
 	"strings"
 	"testing"
 )
 
 func TestGeneralGraph(t *testing.T) {
-	// create a temporary directory to hold the test package
-	tempDir, err := ioutil.TempDir(".", "testpkg")
-	if err != nil {
-		t.Fatalf("failed to create temp dir: %v", err)
-	}
-	defer func(path string) {
-		err := os.RemoveAll(path)
-		if err != nil {
-			t.Fatalf("failed to remove temp dir: %v", err)
-		}
-	}(tempDir)
+	// create a temporary module to hold the test package
+	tempDir := newTestModule(t)
 
 	// create a test package in the temporary directory
 	if err := createGeneralTestPackage(tempDir); err != nil {
@@ -39,7 +28,7 @@ func TestGeneralGraph(t *testing.T) {
 		idents:  []arch.ObjIdentifier{},
 	}
 
-	result, err := GeneralGraph(tempDir, path.Join(reflect.TypeOf(MockObjectRepository{}).PkgPath(), path.Base(tempDir)), mockRepo, mockRelRepo)
+	result, err := GeneralGraph(tempDir, testModule, CallGraphStatic, mockRepo, mockRelRepo, nil, FormatDot)
 
 	if err != nil {
 		t.Errorf("GeneralGraph() returned unexpected error:\nActual: %v", err)
@@ -54,6 +43,18 @@ func TestGeneralGraph(t *testing.T) {
 	}
 }
 
+// testModule is the module path of the test packages.
+const testModule = "example.com/testpkg"
+
+// newTestModule creates a temporary module for the test packages, outside the source tree.
+func newTestModule(t *testing.T) string {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module "+testModule+"\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
 // createTestPackage creates a test package in the specified directory
 func createGeneralTestPackage(dir string) error {
 	// create some test files in the package directory
@@ -125,7 +126,7 @@ func createHexagonTestPackage(dir string) error {
 
 	// Create the package initialization file
 	initFile := filepath.Join(dir, "main.go")
-	fileStr := strings.ReplaceAll(main, "module", path.Join(reflect.TypeOf(MockObjectRepository{}).PkgPath(), path.Base(dir)))
+	fileStr := strings.ReplaceAll(main, "module", testModule)
 	if err := ioutil.WriteFile(initFile, []byte(fileStr), 0644); err != nil {
 		return err
 	}
@@ -134,17 +135,8 @@ func createHexagonTestPackage(dir string) error {
 }
 
 func TestTacticGraph(t *testing.T) {
-	// create a temporary directory to hold the test package
-	tempDir, err := ioutil.TempDir(".", "testpkg")
-	if err != nil {
-		t.Fatalf("failed to create temp dir: %v", err)
-	}
-	defer func(path string) {
-		err := os.RemoveAll(path)
-		if err != nil {
-			t.Fatalf("failed to remove temp dir: %v", err)
-		}
-	}(tempDir)
+	// create a temporary module to hold the test package
+	tempDir := newTestModule(t)
 
 	// create a test package in the temporary directory
 	if err := createHexagonTestPackage(tempDir); err != nil {
@@ -159,7 +151,10 @@ func TestTacticGraph(t *testing.T) {
 		idents:  []arch.ObjIdentifier{},
 	}
 
-	result, err := TacticGraph(tempDir, path.Join(reflect.TypeOf(MockObjectRepository{}).PkgPath(), path.Base(tempDir)), mockRepo, mockRelRepo)
+	result, err := TacticGraph(tempDir, testModule, CallGraphStatic, nil, mockRepo, mockRelRepo, nil, FormatDot)
+	if err != nil {
+		t.Errorf("TacticGraph() returned unexpected error:\nActual: %v", err)
+	}
 
 	// Verify the output matches the expected DOT directed
 	if strings.Contains(result, valueobject.GenerateShortURL("test_entity")) == false ||
@@ -0,0 +1,248 @@
+package application
+
+import (
+	archFactory "github.com/dddplayer/dp/internal/domain/arch/factory"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"github.com/dddplayer/dp/internal/domain/code"
+	"github.com/dddplayer/dp/internal/domain/code/entity"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watcher keeps the repositories of a module up to date with its Go files,
+// handling again only the changed packages and the packages depending on them.
+type Watcher struct {
+	mainPkgPath string
+	domain      string
+	algo        CallGraph
+	objRepo     repository.ObjectRepository
+	relRepo     repository.RelationRepository
+
+	code  *entity.Code
+	root  string
+	pkgs  []*code.Package
+	files map[string]fileStamp
+}
+
+func NewWatcher(mainPkgPath, domain string, algo CallGraph,
+	objRepo repository.ObjectRepository, relRepo repository.RelationRepository) *Watcher {
+	return &Watcher{
+		mainPkgPath: mainPkgPath,
+		domain:      domain,
+		algo:        algo,
+		objRepo:     objRepo,
+		relRepo:     relRepo,
+	}
+}
+
+// Build handles the whole module and returns its general graph in dot format.
+func (w *Watcher) Build() (string, error) {
+	return w.update(nil)
+}
+
+// Changed returns the Go files added, modified or removed in the module since the last build or update.
+func (w *Watcher) Changed() []string {
+	return changedFiles(w.files, scanFiles(w.root))
+}
+
+// Update handles again the packages of the changed files and their dependents,
+// and returns the refreshed general graph in dot format with the handled packages.
+func (w *Watcher) Update(files []string) (string, []string, error) {
+	pkgs, ok := affectedPackages(w.pkgs, files)
+	if !ok {
+		raw, err := w.update(nil)
+		return raw, nil, err
+	}
+	raw, err := w.update(pkgs)
+	return raw, pkgs, err
+}
+
+func (w *Watcher) update(pkgs []string) (string, error) {
+	if w.root == "" {
+		root, err := entity.ModuleRoot(w.mainPkgPath)
+		if err != nil {
+			return "", err
+		}
+		w.root = root
+	}
+
+	c, err := w.load(pkgs)
+	if err != nil {
+		return "", err
+	}
+
+	arch, err := archFactory.NewArch(w.domain, w.objRepo, w.relRepo)
+	if err != nil {
+		return "", err
+	}
+
+	loaded := c.Packages()
+	if pkgs == nil {
+		for _, p := range w.pkgs {
+			pkgs = append(pkgs, p.Path)
+		}
+		for _, p := range loaded {
+			pkgs = append(pkgs, p.Path)
+		}
+	} else {
+		pkgs = append(pkgs, newPackages(w.pkgs, loaded)...)
+	}
+
+	handler := valueobject.NewPackageHandler(arch.CodeHandler, pkgs)
+	if err := handler.Purge(); err != nil {
+		return "", err
+	}
+	if err := c.Visit(handler, code.CallGraphType(w.algo)); err != nil {
+		return "", err
+	}
+
+	w.code = c
+	w.pkgs = loaded
+	w.files = scanFiles(w.root)
+
+	arch, err = archFactory.NewArch(w.domain, w.objRepo, w.relRepo)
+	if err != nil {
+		return "", err
+	}
+	g, err := arch.GeneralGraph(&options{})
+	if err != nil {
+		return "", err
+	}
+
+	return render(g, FormatDot)
+}
+
+// load reloads only pkgs into the code loaded before, and loads the whole module
+// on the first build or when the packages can't be reloaded.
+func (w *Watcher) load(pkgs []string) (*entity.Code, error) {
+	if w.code != nil && pkgs != nil {
+		if err := w.code.Reload(pkgs); err == nil {
+			return w.code, nil
+		}
+	}
+	return entity.NewCode(w.mainPkgPath, w.domain)
+}
+
+// affectedPackages returns the packages of files and all the packages importing them,
+// or false when a file belongs to no known package directory.
+func affectedPackages(pkgs []*code.Package, files []string) ([]string, bool) {
+	dirs := map[string]string{}
+	importers := map[string][]string{}
+	for _, p := range pkgs {
+		for _, f := range p.Files {
+			dirs[filepath.Dir(f)] = p.Path
+		}
+		for _, imp := range p.Imports {
+			importers[imp] = append(importers[imp], p.Path)
+		}
+	}
+
+	affected := map[string]bool{}
+	var queue []string
+	for _, f := range files {
+		p, ok := dirs[filepath.Dir(f)]
+		if !ok {
+			return nil, false
+		}
+		if !affected[p] {
+			affected[p] = true
+			queue = append(queue, p)
+		}
+	}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, importer := range importers[p] {
+			if !affected[importer] {
+				affected[importer] = true
+				queue = append(queue, importer)
+			}
+		}
+	}
+
+	var result []string
+	for p := range affected {
+		result = append(result, p)
+	}
+	sort.Strings(result)
+	return result, true
+}
+
+// newPackages returns the packages which appear in only one of the two loads.
+func newPackages(before, after []*code.Package) []string {
+	seen := map[string]int{}
+	for _, p := range before {
+		seen[p.Path]++
+	}
+	for _, p := range after {
+		seen[p.Path] += 2
+	}
+	var pkgs []string
+	for p, n := range seen {
+		if n != 3 {
+			pkgs = append(pkgs, p)
+		}
+	}
+	sort.Strings(pkgs)
+	return pkgs
+}
+
+// scanFiles stamps the non-test Go files in the module tree under root, so the files of a new
+// package directory are noticed too. Hidden, testdata and vendor directories and nested modules are skipped.
+func scanFiles(root string) map[string]fileStamp {
+	files := map[string]fileStamp{}
+	_ = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if p == root {
+				return nil
+			}
+			if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata" || name == "vendor" {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(p, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		files[p] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		return nil
+	})
+	return files
+}
+
+func changedFiles(before, after map[string]fileStamp) []string {
+	var changed []string
+	for f, s := range after {
+		if b, ok := before[f]; !ok || !b.modTime.Equal(s.modTime) || b.size != s.size {
+			changed = append(changed, f)
+		}
+	}
+	for f := range before {
+		if _, ok := after[f]; !ok {
+			changed = append(changed, f)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
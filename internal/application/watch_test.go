@@ -0,0 +1,80 @@
+package application
+
+import (
+	"github.com/dddplayer/dp/internal/domain/code"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAffectedPackages(t *testing.T) {
+	pkgs := []*code.Package{
+		{Path: "app/domain/entity", Files: []string{"/app/domain/entity/order.go"}},
+		{Path: "app/domain/repo", Files: []string{"/app/domain/repo/repo.go"}, Imports: []string{"app/domain/entity"}},
+		{Path: "app/domain/service", Files: []string{"/app/domain/service/svc.go"}, Imports: []string{"app/domain/repo"}},
+		{Path: "app/domain/util", Files: []string{"/app/domain/util/util.go"}},
+	}
+
+	affected, ok := affectedPackages(pkgs, []string{"/app/domain/entity/new.go"})
+	if !ok {
+		t.Fatalf("Expected the file to belong to a known package")
+	}
+	expected := []string{"app/domain/entity", "app/domain/repo", "app/domain/service"}
+	if !reflect.DeepEqual(affected, expected) {
+		t.Errorf("Expected %v, but got %v", expected, affected)
+	}
+
+	if _, ok := affectedPackages(pkgs, []string{"/app/domain/other/other.go"}); ok {
+		t.Errorf("Expected a file of an unknown directory to need a full build")
+	}
+}
+
+func TestNewPackages(t *testing.T) {
+	before := []*code.Package{{Path: "a"}, {Path: "b"}}
+	after := []*code.Package{{Path: "b"}, {Path: "c"}}
+
+	if pkgs := newPackages(before, after); !reflect.DeepEqual(pkgs, []string{"a", "c"}) {
+		t.Errorf("Expected [a c], but got %v", pkgs)
+	}
+}
+
+func TestChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	kept := filepath.Join(dir, "kept.go")
+	edited := filepath.Join(dir, "edited.go")
+	removed := filepath.Join(dir, "removed.go")
+	for _, d := range []string{"testdata", ".git", "nested"} {
+		if err := os.Mkdir(filepath.Join(dir, d), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, f := range []string{kept, edited, removed, filepath.Join(dir, "kept_test.go"),
+		filepath.Join(dir, "testdata", "data.go"), filepath.Join(dir, ".git", "hook.go"),
+		filepath.Join(dir, "nested", "go.mod"), filepath.Join(dir, "nested", "nested.go")} {
+		if err := os.WriteFile(f, []byte("package p\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	before := scanFiles(dir)
+	if len(before) != 3 {
+		t.Fatalf("Expected 3 files scanned, but got %v", before)
+	}
+
+	added := filepath.Join(dir, "added.go")
+	_ = os.WriteFile(added, []byte("package p\n"), 0644)
+	_ = os.Mkdir(filepath.Join(dir, "newpkg"), 0755)
+	newPkg := filepath.Join(dir, "newpkg", "new.go")
+	_ = os.WriteFile(newPkg, []byte("package newpkg\n"), 0644)
+	_ = os.WriteFile(edited, []byte("package p\n\nvar v int\n"), 0644)
+	_ = os.Chtimes(edited, time.Now().Add(time.Minute), time.Now().Add(time.Minute))
+	_ = os.Remove(removed)
+
+	changed := changedFiles(before, scanFiles(dir))
+	expected := []string{added, edited, newPkg, removed}
+	if !reflect.DeepEqual(changed, expected) {
+		t.Errorf("Expected %v, but got %v", expected, changed)
+	}
+}
@@ -0,0 +1,124 @@
+// Package archtest provides the arch diagrams and sequences shared by the tests of the renderers.
+package archtest
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+)
+
+type DummyDiagram struct {
+	NameVal        string
+	TypeVal        arch.DiagramType
+	SubDiagramsVal []arch.SubDiagram
+	EdgesVal       []arch.Edge
+}
+
+func (d *DummyDiagram) Name() string                   { return d.NameVal }
+func (d *DummyDiagram) Type() arch.DiagramType         { return d.TypeVal }
+func (d *DummyDiagram) SubDiagrams() []arch.SubDiagram { return d.SubDiagramsVal }
+func (d *DummyDiagram) Edges() []arch.Edge             { return d.EdgesVal }
+
+type DummySubDiagram struct {
+	NameVal      string
+	NodesVal     []arch.Node
+	SummaryVal   []arch.Element
+	SubGraphsVal []arch.SubDiagram
+}
+
+func (sd *DummySubDiagram) Name() string                 { return sd.NameVal }
+func (sd *DummySubDiagram) Nodes() []arch.Node           { return sd.NodesVal }
+func (sd *DummySubDiagram) Summary() []arch.Element      { return sd.SummaryVal }
+func (sd *DummySubDiagram) SubGraphs() []arch.SubDiagram { return sd.SubGraphsVal }
+
+type DummyNode struct {
+	IDVal    string
+	NameVal  string
+	ColorVal string
+}
+
+func (n *DummyNode) ID() string    { return n.IDVal }
+func (n *DummyNode) Name() string  { return n.NameVal }
+func (n *DummyNode) Color() string { return n.ColorVal }
+
+type DummyElement struct {
+	DummyNode
+	ChildrenVal []arch.Nodes
+}
+
+func (e *DummyElement) Children() []arch.Nodes { return e.ChildrenVal }
+
+type DummyEdge struct {
+	FromVal        string
+	ToVal          string
+	TypeVal        arch.RelationType
+	CountVal       int
+	HighlightedVal bool
+}
+
+func (e *DummyEdge) From() string            { return e.FromVal }
+func (e *DummyEdge) To() string              { return e.ToVal }
+func (e *DummyEdge) Type() arch.RelationType { return e.TypeVal }
+func (e *DummyEdge) Count() int              { return e.CountVal }
+func (e *DummyEdge) Pos() []arch.RelationPos { return []arch.RelationPos{} }
+func (e *DummyEdge) Highlighted() bool       { return e.HighlightedVal }
+
+// PlainDiagram returns an app package aggregating an order aggregate.
+func PlainDiagram() *DummyDiagram {
+	return &DummyDiagram{
+		NameVal: "plain",
+		TypeVal: arch.PlainDiagram,
+		SubDiagramsVal: []arch.SubDiagram{
+			&DummySubDiagram{
+				NameVal: "example.com/app",
+				NodesVal: []arch.Node{
+					&DummyNode{IDVal: "example.com/app", NameVal: "example.com/app", ColorVal: string(arch.ColorGeneral)},
+				},
+				SubGraphsVal: []arch.SubDiagram{
+					&DummySubDiagram{
+						NameVal: "order",
+						NodesVal: []arch.Node{
+							&DummyNode{IDVal: "order.Order", NameVal: "example.com/app/order.Order", ColorVal: string(arch.ColorAggregate)},
+						},
+					},
+				},
+			},
+		},
+		EdgesVal: []arch.Edge{
+			&DummyEdge{FromVal: "example.com/app", ToVal: "order.Order", TypeVal: arch.RelationTypeAggregationRoot, CountVal: 1},
+		},
+	}
+}
+
+// TableDiagram returns the order aggregate with an Order root holding Items, whose Pay method
+// depends on Item three times, on itself and on an unknown element.
+func TableDiagram() *DummyDiagram {
+	order := &DummyElement{
+		DummyNode: DummyNode{IDVal: "order.Order", NameVal: "Order", ColorVal: string(arch.ColorAggregate)},
+		ChildrenVal: []arch.Nodes{
+			{&DummyNode{IDVal: "order.Order.Pay", NameVal: "Pay", ColorVal: string(arch.ColorFunc)}},
+			{&DummyNode{IDVal: "order.Order.Items", NameVal: "Items", ColorVal: string(arch.ColorEntity)}},
+		},
+	}
+	item := &DummyElement{
+		DummyNode: DummyNode{IDVal: "order.Item", NameVal: "Item", ColorVal: string(arch.ColorEntity)},
+		ChildrenVal: []arch.Nodes{
+			{&DummyNode{IDVal: "order.Item.Price", NameVal: "Price", ColorVal: string(arch.ColorValueObject)}},
+		},
+	}
+	return &DummyDiagram{
+		NameVal: "table",
+		TypeVal: arch.TableDiagram,
+		SubDiagramsVal: []arch.SubDiagram{
+			&DummySubDiagram{
+				NameVal:    "order.Order",
+				SummaryVal: []arch.Element{order, item},
+			},
+		},
+		EdgesVal: []arch.Edge{
+			&DummyEdge{FromVal: "order.Order.Items", ToVal: "order.Item", TypeVal: arch.RelationTypeAssociationOneMany, CountVal: 1},
+			&DummyEdge{FromVal: "order.Order.Pay", ToVal: "order.Item.Price", TypeVal: arch.RelationTypeDependency, CountVal: 1},
+			&DummyEdge{FromVal: "order.Order.Pay", ToVal: "order.Item", TypeVal: arch.RelationTypeDependency, CountVal: 2},
+			&DummyEdge{FromVal: "order.Order.Pay", ToVal: "order.Order.Items", TypeVal: arch.RelationTypeDependency, CountVal: 1},
+			&DummyEdge{FromVal: "order.Order.Pay", ToVal: "unknown", TypeVal: arch.RelationTypeDependency, CountVal: 1},
+		},
+	}
+}
@@ -0,0 +1,41 @@
+package archtest
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+)
+
+type DummySequence struct {
+	NameVal         string
+	ParticipantsVal []arch.Node
+	MessagesVal     []arch.Message
+}
+
+func (s *DummySequence) Name() string              { return s.NameVal }
+func (s *DummySequence) Participants() []arch.Node { return s.ParticipantsVal }
+func (s *DummySequence) Messages() []arch.Message  { return s.MessagesVal }
+
+type DummyMessage struct {
+	FromVal string
+	ToVal   string
+	NameVal string
+}
+
+func (m *DummyMessage) From() string       { return m.FromVal }
+func (m *DummyMessage) To() string         { return m.ToVal }
+func (m *DummyMessage) Name() string       { return m.NameVal }
+func (m *DummyMessage) Pos() arch.Position { return nil }
+
+// Sequence returns cmd placing an order, which validates itself.
+func Sequence() *DummySequence {
+	return &DummySequence{
+		NameVal: "example.com/app",
+		ParticipantsVal: []arch.Node{
+			&DummyNode{IDVal: "example.com/app/cmd", NameVal: "cmd", ColorVal: string(arch.ColorFunc)},
+			&DummyNode{IDVal: "example.com/app/order/Order", NameVal: "order.Order", ColorVal: string(arch.ColorClass)},
+		},
+		MessagesVal: []arch.Message{
+			&DummyMessage{FromVal: "example.com/app/cmd", ToVal: "example.com/app/order/Order", NameVal: "Place"},
+			&DummyMessage{FromVal: "example.com/app/order/Order", ToVal: "example.com/app/order/Order", NameVal: "validate"},
+		},
+	}
+}
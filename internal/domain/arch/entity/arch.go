@@ -1,30 +1,40 @@
 package entity
 
 import (
+	"errors"
 	"fmt"
 	"github.com/dddplayer/dp/internal/domain/arch"
 	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
 	"github.com/dddplayer/dp/internal/domain/code"
 	"github.com/dddplayer/dp/pkg/datastructure/directed"
 	"path"
+	"sort"
 )
 
 type Arch struct {
 	*valueobject.CodeHandler
 	relationDigraph *RelationDigraph
 	directory       *Directory
+	layout          *valueobject.Layout
 }
 
 func (arc *Arch) ObjectHandler() code.Handler {
 	return arc.CodeHandler
 }
 
+func (arc *Arch) SetLayout(l *valueobject.Layout) {
+	arc.layout = l
+}
+
 func (arc *Arch) BuildHexagon() error {
 	if err := arc.buildDirectory(); err != nil {
 		return err
 	}
 
 	if arc.directory.ArchDesignPattern() != arch.DesignPatternHexagon {
+		if arc.layout != nil {
+			return fmt.Errorf("layout domain %s not found", arc.layout.Domain)
+		}
 		return fmt.Errorf("%s structure is only supported now", arch.DesignPatternHexagon)
 	}
 
@@ -35,6 +45,26 @@ func (arc *Arch) BuildHexagon() error {
 	return nil
 }
 
+func (arc *Arch) Aggregates() ([]*valueobject.AggregateGroup, error) {
+	if err := arc.buildDirectory(); err != nil {
+		return nil, err
+	}
+
+	if arc.directory.ArchDesignPattern() != arch.DesignPatternHexagon {
+		return []*valueobject.AggregateGroup{}, nil
+	}
+
+	dm, err := NewDomainModel(arc.ObjRepo, arc.directory)
+	if err != nil {
+		return nil, err
+	}
+	if err := dm.TacticGrouping(); err != nil {
+		return nil, err
+	}
+
+	return dm.aggregates, nil
+}
+
 func (arc *Arch) StrategicGraph() (arch.Diagram, error) {
 	if err := arc.BuildHexagon(); err != nil {
 		return nil, err
@@ -90,6 +120,14 @@ func (arc *Arch) buildStrategicArchGraph() (*Diagram, error) {
 			case *valueobject.VOGroup:
 				vos := sg.(*valueobject.VOGroup).ValueObjects()
 				sgObjs = append(sgObjs, vos.Objects()...)
+			case *valueobject.RepositoryGroup:
+				for _, r := range sg.(*valueobject.RepositoryGroup).Repositories() {
+					sgObjs = append(sgObjs, r)
+				}
+			case *valueobject.FactoryGroup:
+				for _, f := range sg.(*valueobject.FactoryGroup).Factories() {
+					sgObjs = append(sgObjs, f)
+				}
 			}
 		}
 
@@ -104,6 +142,10 @@ func (arc *Arch) buildStrategicArchGraph() (*Diagram, error) {
 		return nil, err
 	}
 
+	if err := NewComponentLinker(arc.RelRepo, dm.aggregates).addToDiagram(g); err != nil {
+		return nil, err
+	}
+
 	return g, nil
 }
 
@@ -201,6 +243,18 @@ func (arc *Arch) buildTacticArchGraph() (*Diagram, error) {
 						return nil, err
 					}
 				}
+			case *valueobject.RepositoryGroup:
+				for _, r := range sg.(*valueobject.RepositoryGroup).Repositories() {
+					if err := dm.addComponentToNode(g, componentKey, r, r.Methods); err != nil {
+						return nil, err
+					}
+				}
+			case *valueobject.FactoryGroup:
+				for _, f := range sg.(*valueobject.FactoryGroup).Factories() {
+					if err := dm.addComponentToNode(g, componentKey, f, f.Methods); err != nil {
+						return nil, err
+					}
+				}
 			}
 
 			if dg, ok := sg.(valueobject.DomainGroup); ok {
@@ -211,6 +265,10 @@ func (arc *Arch) buildTacticArchGraph() (*Diagram, error) {
 		}
 	}
 
+	if err := NewComponentLinker(arc.RelRepo, dm.aggregates).addToDiagram(g); err != nil {
+		return nil, err
+	}
+
 	return g, nil
 }
 
@@ -274,6 +332,7 @@ func (arc *Arch) buildDirectory() error {
 		dirMap[objDir] = append(dirMap[objDir], id)
 	}
 	arc.directory = NewDirectory(objPaths)
+	arc.directory.layout = arc.layout
 	for dir, objs := range dirMap {
 		if err := arc.directory.AddObjs(dir, objs); err != nil {
 			return err
@@ -324,23 +383,261 @@ func (arc *Arch) GeneralGraph(ops arch.Options) (arch.Diagram, error) {
 	return g, nil
 }
 
-func (arc *Arch) MessageFlowDiagram(startPath, endPath, modPath string) (arch.Diagram, error) {
+func (arc *Arch) Check(rules []*valueobject.Rule) ([]*valueobject.Violation, error) {
 	if err := arc.BuildPlain(); err != nil {
 		return nil, err
 	}
 
-	mf := &MessageFlow{
+	rc := &RuleChecker{
 		directory:       arc.directory,
 		relationDigraph: arc.relationDigraph,
+	}
+
+	return rc.Check(rules), nil
+}
+
+func (arc *Arch) AggregateBoundaryViolations() ([]*valueobject.BoundaryViolation, error) {
+	if err := arc.BuildHexagon(); err != nil {
+		return nil, err
+	}
+
+	dm, err := NewDomainModel(arc.ObjRepo, arc.directory)
+	if err != nil {
+		return nil, err
+	}
+	if err := dm.TacticGrouping(); err != nil {
+		return nil, err
+	}
+
+	bc, err := NewBoundaryChecker(dm.aggregates, arc.relationDigraph, arc.ObjRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	return bc.Check(), nil
+}
+
+func (arc *Arch) RepositoryFindings() ([]*valueobject.RepositoryFinding, error) {
+	ags, err := arc.Aggregates()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewComponentLinker(arc.RelRepo, ags).RepositoryFindings(), nil
+}
+
+func (arc *Arch) Metrics() ([]*valueobject.Metric, error) {
+	aggregates, err := arc.Aggregates()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := arc.buildOriginGraph(); err != nil {
+		return nil, err
+	}
+
+	mc := &MetricsCalculator{
 		objRepo:         arc.ObjRepo,
-		mainPkgPath:     startPath,
-		endPkgPath:      endPath,
-		modulePath:      modPath,
+		relationDigraph: arc.relationDigraph,
+		aggregates:      aggregates,
+	}
+
+	return mc.Metrics(), nil
+}
+
+func (arc *Arch) SuggestAggregates(threshold float64) ([]*valueobject.SuggestedAggregate, error) {
+	as, err := arc.aggregateSuggester(threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	return as.Suggest(), nil
+}
+
+func (arc *Arch) SuggestedAggregatesDiagram(threshold float64) (arch.Diagram, error) {
+	as, err := arc.aggregateSuggester(threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := as.Suggest()
+	if len(suggestions) == 0 {
+		return nil, errors.New("no aggregate suggestions found")
+	}
+
+	return as.buildDiagram(arc.Scope, suggestions)
+}
+
+func (arc *Arch) aggregateSuggester(threshold float64) (*AggregateSuggester, error) {
+	if err := arc.BuildHexagon(); err != nil {
+		return nil, err
+	}
+
+	dm, err := NewDomainModel(arc.ObjRepo, arc.directory)
+	if err != nil {
+		return nil, err
+	}
+	if err := dm.TacticGrouping(); err != nil {
+		return nil, err
+	}
+
+	return NewAggregateSuggester(dm.aggregates, arc.relationDigraph, threshold), nil
+}
+
+func (arc *Arch) Cycles(level valueobject.CycleLevel) ([]*valueobject.Cycle, error) {
+	if err := arc.BuildPlain(); err != nil {
+		return nil, err
+	}
+
+	return arc.cycleDetector().Cycles(level)
+}
+
+func (arc *Arch) CycleDiagram(level valueobject.CycleLevel) (arch.Diagram, error) {
+	if err := arc.BuildPlain(); err != nil {
+		return nil, err
+	}
+
+	cd := arc.cycleDetector()
+	cycles, err := cd.Cycles(level)
+	if err != nil {
+		return nil, err
+	}
+	if len(cycles) == 0 {
+		return nil, errors.New("no cycles found")
+	}
+
+	return cd.buildDiagram(arc.Scope, cycles)
+}
+
+func (arc *Arch) cycleDetector() *CycleDetector {
+	return &CycleDetector{
+		directory:       arc.directory,
+		objRepo:         arc.ObjRepo,
+		relationDigraph: arc.relationDigraph,
+	}
+}
+
+func (arc *Arch) CompareCalls(base, other string) *valueobject.CallDiff {
+	return NewCallComparator(arc.RelRepo).Compare(base, other)
+}
+
+func (arc *Arch) Ports() ([]*valueobject.Port, error) {
+	if err := arc.buildDirectory(); err != nil {
+		return nil, err
+	}
+
+	return arc.portMapper().Ports(), nil
+}
+
+func (arc *Arch) PortsDiagram() (arch.Diagram, error) {
+	if err := arc.buildDirectory(); err != nil {
+		return nil, err
+	}
+
+	pm := arc.portMapper()
+	ports := pm.Ports()
+	if len(ports) == 0 {
+		return nil, errors.New("no ports found")
+	}
+
+	return pm.buildDiagram(arc.Scope, ports)
+}
+
+func (arc *Arch) portMapper() *PortMapper {
+	return NewPortMapper(arc.directory, arc.ObjRepo, arc.RelRepo)
+}
+
+func (arc *Arch) EventFlows() ([]*valueobject.EventFlow, error) {
+	ed, err := arc.eventFlowDetector()
+	if err != nil {
+		return nil, err
+	}
+
+	return ed.Flows(), nil
+}
+
+func (arc *Arch) EventFlowDiagram() (arch.Diagram, error) {
+	ed, err := arc.eventFlowDetector()
+	if err != nil {
+		return nil, err
+	}
+
+	flows := ed.Flows()
+	if len(flows) == 0 {
+		return nil, errors.New("no domain events or commands found")
+	}
+
+	return ed.buildDiagram(arc.Scope, flows)
+}
+
+func (arc *Arch) eventFlowDetector() (*EventFlowDetector, error) {
+	ags, err := arc.Aggregates()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEventFlowDetector(arc.ObjRepo, arc.RelRepo, ags), nil
+}
+
+func (arc *Arch) MessageFlowDiagram(mainPkgPath string, startFuncs []string, endPath, modPath string,
+	search valueobject.FlowSearch) (arch.Diagram, error) {
+	mf, err := arc.messageFlow(mainPkgPath, startFuncs, endPath, modPath, search)
+	if err != nil {
+		return nil, err
 	}
 
 	return mf.buildDiagram()
 }
 
+func (arc *Arch) MessageFlowSequence(mainPkgPath string, startFuncs []string, endPath, modPath string,
+	search valueobject.FlowSearch) (arch.Sequence, error) {
+	mf, err := arc.messageFlow(mainPkgPath, startFuncs, endPath, modPath, search)
+	if err != nil {
+		return nil, err
+	}
+
+	return mf.buildSequence()
+}
+
+func (arc *Arch) Callers(targets []string, maxDepth int) ([]*valueobject.Caller, error) {
+	if err := arc.BuildPlain(); err != nil {
+		return nil, err
+	}
+
+	return NewCallerTracer(arc.directory, arc.relationDigraph, maxDepth).Trace(targets)
+}
+
+func (arc *Arch) messageFlow(mainPkgPath string, startFuncs []string, endPath, modPath string,
+	search valueobject.FlowSearch) (*MessageFlow, error) {
+	if err := arc.BuildPlain(); err != nil {
+		return nil, err
+	}
+
+	return &MessageFlow{
+		directory:       arc.directory,
+		relationDigraph: arc.relationDigraph,
+		objRepo:         arc.ObjRepo,
+		mainPkgPath:     mainPkgPath,
+		startFuncs:      startFuncs,
+		endPkgPath:      endPath,
+		modulePath:      modPath,
+		search:          search,
+		routes:          arc.Routes(),
+	}, nil
+}
+
+// Routes returns the http routes of the code ordered by path and method.
+func (arc *Arch) Routes() []*valueobject.Route {
+	routes := append([]*valueobject.Route(nil), arc.CodeHandler.Routes()...)
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes
+}
+
 func (arc *Arch) BuildPlain() error {
 	if err := arc.buildDirectory(); err != nil {
 		return err
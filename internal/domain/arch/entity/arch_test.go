@@ -1339,3 +1339,155 @@ func TestFilterAssociationMetas(t *testing.T) {
 		t.Errorf("Expected %d RelationMeta objects, got %d", expectedLength, len(filteredMetas))
 	}
 }
+
+func TestAggregates(t *testing.T) {
+	claObj1 := newMockObjectWithId("test/cmd", "cla1", 1)
+	claObj2 := newMockObjectWithId("test/internal/domain/testdomain", "cla2", 1)
+	claObj20 := newMockClassWithName("test/internal/domain/testdomain/entity", "testdomain")
+	claObj3 := newMockObjectWithId("test/pkg", "cla3", 1)
+	mockRepo := &MockObjectRepository{
+		objects: make(map[string]arch.Object),
+		idents:  []arch.ObjIdentifier{},
+	}
+	_ = mockRepo.Insert(claObj1)
+	_ = mockRepo.Insert(claObj2)
+	_ = mockRepo.Insert(claObj20)
+	_ = mockRepo.Insert(claObj3)
+
+	mockArch := &Arch{
+		CodeHandler: &valueobject.CodeHandler{
+			ObjRepo: mockRepo,
+			RelRepo: &MockRelationRepository{relations: make([]arch.Relation, 0)},
+			Scope:   "test",
+		},
+	}
+
+	ags, err := mockArch.Aggregates()
+	if err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if len(ags) != 1 || ags[0].Name() != "testdomain" {
+		t.Errorf("Expected aggregate testdomain, but got %v", ags)
+	}
+
+	plainRepo := &MockObjectRepository{
+		objects: make(map[string]arch.Object),
+		idents:  []arch.ObjIdentifier{},
+	}
+	_ = plainRepo.Insert(claObj1)
+	mockArch.ObjRepo = plainRepo
+
+	ags, err = mockArch.Aggregates()
+	if err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if len(ags) != 0 {
+		t.Errorf("Expected no aggregates for plain layout, but got %d", len(ags))
+	}
+}
+
+func TestArch_AggregatesWithLayout(t *testing.T) {
+	mainFunc := valueobject.NewFunction(newMockMethodWithName("test/cmd", "main", 1), nil)
+	order := newMockClassWithName("test/internal/core/order/model", "Order")
+	money := newMockClassWithName("test/internal/core/order/vo", "Money")
+	impl := newMockClassWithName("test/internal/infra/persistence", "Repo")
+
+	mockRepo := &MockObjectRepository{
+		objects: make(map[string]arch.Object),
+		idents:  []arch.ObjIdentifier{},
+	}
+	for _, o := range []arch.Object{mainFunc, order, money, impl} {
+		_ = mockRepo.Insert(o)
+	}
+
+	a := &Arch{
+		CodeHandler: &valueobject.CodeHandler{
+			ObjRepo: mockRepo,
+			RelRepo: &MockRelationRepository{},
+			Scope:   "test",
+		},
+	}
+
+	ags, err := a.Aggregates()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if len(ags) != 0 {
+		t.Fatalf("Expected no aggregates without layout, but got %d", len(ags))
+	}
+
+	a.SetLayout(&valueobject.Layout{
+		Domain: "internal/core",
+		Roles: map[arch.HexagonDirectory][]string{
+			arch.HexagonDirectoryAggregate:   {"internal/core/*"},
+			arch.HexagonDirectoryEntity:      {"internal/core/*/model"},
+			arch.HexagonDirectoryValueObject: {"internal/core/*/vo"},
+		},
+	})
+	ags, err = a.Aggregates()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if len(ags) != 1 {
+		t.Fatalf("Expected 1 aggregate, but got %d", len(ags))
+	}
+	if ags[0].Name() != "order" || ags[0].Domain() != "test/internal/core/order" {
+		t.Errorf("Unexpected aggregate %s in %s", ags[0].Name(), ags[0].Domain())
+	}
+
+	var entities, vos int
+	for _, sg := range ags[0].SubGroups() {
+		switch sg.(type) {
+		case *valueobject.EntityGroup:
+			entities++
+		case *valueobject.VOGroup:
+			vos++
+		}
+	}
+	if entities != 1 || vos != 1 {
+		t.Errorf("Expected 1 entity group and 1 value object group, but got %d and %d", entities, vos)
+	}
+}
+
+func TestArch_AggregatesWithScopedLayout(t *testing.T) {
+	order := newMockClassWithName("test/internal/core/order/model", "Order")
+	money := newMockClassWithName("test/internal/core/order/vo", "Money")
+	payment := newMockClassWithName("test/internal/core/payment/model", "Payment")
+
+	mockRepo := &MockObjectRepository{
+		objects: make(map[string]arch.Object),
+		idents:  []arch.ObjIdentifier{},
+	}
+	for _, o := range []arch.Object{order, money, payment} {
+		_ = mockRepo.Insert(o)
+	}
+
+	a := &Arch{
+		CodeHandler: &valueobject.CodeHandler{
+			ObjRepo: mockRepo,
+			RelRepo: &MockRelationRepository{},
+			Scope:   "test/internal/core",
+		},
+	}
+	a.SetLayout(&valueobject.Layout{
+		Module: "test",
+		Domain: "internal/core",
+		Roles: map[arch.HexagonDirectory][]string{
+			arch.HexagonDirectoryAggregate:   {"internal/core/*"},
+			arch.HexagonDirectoryEntity:      {"internal/core/*/model"},
+			arch.HexagonDirectoryValueObject: {"internal/core/*/vo"},
+		},
+	})
+
+	ags, err := a.Aggregates()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	domains := map[string]bool{}
+	for _, ag := range ags {
+		domains[ag.Domain()] = true
+	}
+	if len(ags) != 2 || !domains["test/internal/core/order"] || !domains["test/internal/core/payment"] {
+		t.Errorf("Expected the order and payment aggregates, but got %v", domains)
+	}
+}
@@ -0,0 +1,136 @@
+package entity
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"path"
+	"sort"
+	"strings"
+)
+
+type aggregateMember struct {
+	aggregate string
+	entity    bool
+}
+
+type BoundaryChecker struct {
+	aggregates      []*valueobject.AggregateGroup
+	relationDigraph *RelationDigraph
+	objRepo         repository.ObjectRepository
+	members         map[string]*aggregateMember
+}
+
+func NewBoundaryChecker(ags []*valueobject.AggregateGroup, g *RelationDigraph,
+	objRepo repository.ObjectRepository) (*BoundaryChecker, error) {
+	bc := &BoundaryChecker{
+		aggregates:      ags,
+		relationDigraph: g,
+		objRepo:         objRepo,
+		members:         make(map[string]*aggregateMember),
+	}
+
+	for _, ag := range ags {
+		a, err := ag.Aggregate()
+		if err != nil {
+			return nil, err
+		}
+		rootId := ""
+		if a.Entity != nil {
+			rootId = a.Entity.OriginIdentifier().ID()
+		}
+
+		for _, sg := range ag.SubGroups() {
+			switch g := sg.(type) {
+			case *valueobject.EntityGroup:
+				for _, e := range g.Entities() {
+					if id := e.OriginIdentifier().ID(); id != rootId {
+						bc.members[id] = &aggregateMember{aggregate: ag.Name(), entity: true}
+					}
+				}
+			case *valueobject.VOGroup:
+				for _, vo := range g.ValueObjects() {
+					bc.members[vo.OriginIdentifier().ID()] = &aggregateMember{aggregate: ag.Name()}
+				}
+			}
+		}
+	}
+
+	return bc, nil
+}
+
+// mutating tells whether the method assigns to the fields of its receiver, read-only calls keep the aggregate intact.
+func (bc *BoundaryChecker) mutating(id arch.ObjIdentifier) bool {
+	f, ok := bc.objRepo.Find(id).(*valueobject.Function)
+	return ok && f.Mutating
+}
+
+func (bc *BoundaryChecker) Check() []*valueobject.BoundaryViolation {
+	var violations []*valueobject.BoundaryViolation
+
+	for _, n := range bc.relationDigraph.Nodes {
+		from := n.Value.(arch.ObjIdentifier)
+		fromAggregate := bc.aggregateOf(from)
+
+		for _, e := range n.Edges {
+			to := e.To.Value.(arch.ObjIdentifier)
+			t := e.Type.(arch.RelationType)
+
+			var kind valueobject.BoundaryViolationKind
+			var m *aggregateMember
+			switch t {
+			case arch.RelationTypeAssociationOneOne, arch.RelationTypeAssociationOneMany,
+				arch.RelationTypeAssociation, arch.RelationTypeEmbedding:
+				m = bc.members[to.ID()]
+				kind = valueobject.BoundaryViolationReference
+			case arch.RelationTypeDependency:
+				if om := bc.members[ownerID(to)]; om != nil && om.entity && bc.mutating(to) {
+					m = om
+				}
+				kind = valueobject.BoundaryViolationCall
+			}
+			if m == nil || m.aggregate == fromAggregate {
+				continue
+			}
+
+			pos, _ := e.Value.(arch.RelationPos)
+			violations = append(violations, &valueobject.BoundaryViolation{
+				Kind:          kind,
+				From:          from,
+				To:            to,
+				FromAggregate: fromAggregate,
+				ToAggregate:   m.aggregate,
+				Type:          t,
+				Pos:           pos,
+			})
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		fi, li := relationLocation(violations[i].Pos)
+		fj, lj := relationLocation(violations[j].Pos)
+		if fi != fj {
+			return fi < fj
+		}
+		return li < lj
+	})
+
+	return violations
+}
+
+func (bc *BoundaryChecker) aggregateOf(id arch.ObjIdentifier) string {
+	for _, ag := range bc.aggregates {
+		if id.Dir() == ag.Domain() || strings.HasPrefix(id.Dir(), ag.Domain()+"/") {
+			return ag.Name()
+		}
+	}
+	return ""
+}
+
+func ownerID(id arch.ObjIdentifier) string {
+	name := id.Name()
+	if i := strings.Index(name, valueobject.DotJoiner); i > 0 {
+		return path.Join(id.Dir(), name[:i])
+	}
+	return ""
+}
@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"github.com/dddplayer/dp/pkg/datastructure/directed"
+	"path"
+	"testing"
+)
+
+func newMockMethodWithName(dir, name string, line int) *MockObject {
+	return &MockObject{
+		id: &MockObjIdentifier{
+			id:   path.Join(dir, name),
+			name: name,
+			dir:  dir},
+		name:     name,
+		position: &MockPosition{FilenameVal: "mockfile", LineVal: line},
+	}
+}
+
+func TestBoundaryChecker_Check(t *testing.T) {
+	orderDomain := "test/internal/domain/order"
+	customerDomain := "test/internal/domain/customer"
+
+	order := newMockClassWithName(path.Join(orderDomain, "entity"), "Order")
+	line := newMockClassWithName(path.Join(orderDomain, "entity"), "Line")
+	address := newMockClassWithName(path.Join(orderDomain, "valueobject"), "Address")
+	customer := newMockClassWithName(path.Join(customerDomain, "entity"), "Customer")
+	cancel := newMockMethodWithName(path.Join(orderDomain, "entity"), "Line.Cancel", 3)
+	total := newMockMethodWithName(path.Join(orderDomain, "entity"), "Line.Total", 5)
+	place := newMockMethodWithName(path.Join(customerDomain, "entity"), "Customer.Place", 4)
+
+	orderAg := valueobject.NewAggregateGroup(&valueobject.Aggregate{Name: "order"}, orderDomain)
+	orderAg.AppendGroups(
+		valueobject.NewEntityGroup(orderDomain, order, line),
+		valueobject.NewVOGroup(orderDomain, address))
+	customerAg := valueobject.NewAggregateGroup(&valueobject.Aggregate{Name: "customer"}, customerDomain)
+	customerAg.AppendGroups(valueobject.NewEntityGroup(customerDomain, customer))
+
+	g := &RelationDigraph{Graph: directed.NewDirectedGraph()}
+	for _, o := range []arch.Object{order, line, address, customer, cancel, total, place} {
+		if err := g.AddObj(o.Identifier()); err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+	}
+	rels := []arch.Relation{
+		&MockAssociationRelation{from: order, refer: line, associationType: arch.RelationTypeAssociationOneMany},
+		&MockAssociationRelation{from: customer, refer: order, associationType: arch.RelationTypeAssociationOneOne},
+		&MockAssociationRelation{from: customer, refer: line, associationType: arch.RelationTypeAssociationOneOne},
+		&MockAssociationRelation{from: customer, refer: address, associationType: arch.RelationTypeAssociation},
+		&MockDependenceRelation{from: place, dependsOn: cancel},
+		&MockDependenceRelation{from: place, dependsOn: total},
+	}
+	for _, r := range rels {
+		if err := g.AddRelation(r); err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+	}
+
+	repo := &MockObjectRepository{objects: make(map[string]arch.Object)}
+	mutating := valueobject.NewFunction(cancel, nil)
+	mutating.Mutating = true
+	for _, o := range []arch.Object{mutating, valueobject.NewFunction(total, nil)} {
+		if err := repo.Insert(o); err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+	}
+
+	bc, err := NewBoundaryChecker([]*valueobject.AggregateGroup{orderAg, customerAg}, g, repo)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	violations := bc.Check()
+	if len(violations) != 3 {
+		t.Fatalf("Expected 3 violations, but got %d", len(violations))
+	}
+
+	found := map[string]valueobject.BoundaryViolationKind{}
+	for _, v := range violations {
+		found[v.From.Name()+"->"+v.To.Name()] = v.Kind
+		if v.FromAggregate != "customer" || v.ToAggregate != "order" {
+			t.Errorf("Expected customer -> order, but got %s -> %s", v.FromAggregate, v.ToAggregate)
+		}
+	}
+
+	expected := map[string]valueobject.BoundaryViolationKind{
+		"Customer->Line":              valueobject.BoundaryViolationReference,
+		"Customer->Address":           valueobject.BoundaryViolationReference,
+		"Customer.Place->Line.Cancel": valueobject.BoundaryViolationCall,
+	}
+	for k, kind := range expected {
+		if found[k] != kind {
+			t.Errorf("Expected %s violation for %s, but got %q", kind, k, found[k])
+		}
+	}
+
+	if violations[0].Kind != valueobject.BoundaryViolationCall {
+		t.Errorf("Expected violations sorted by line, but got %s first", violations[0].Kind)
+	}
+}
+
+func TestOwnerID(t *testing.T) {
+	if id := ownerID(&MockObjIdentifier{name: "Order.Pay", dir: "a/b"}); id != "a/b/Order" {
+		t.Errorf("Expected a/b/Order, but got %s", id)
+	}
+	if id := ownerID(&MockObjIdentifier{name: "main", dir: "a/b"}); id != "" {
+		t.Errorf("Expected empty owner, but got %s", id)
+	}
+}
@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"github.com/dddplayer/dp/pkg/datastructure/directed"
+	"sort"
+	"unicode"
+)
+
+type CallerTracer struct {
+	directory       *Directory
+	relationDigraph *RelationDigraph
+	maxDepth        int
+	incoming        map[*directed.Node][]*directed.Edge
+}
+
+func NewCallerTracer(d *Directory, g *RelationDigraph, maxDepth int) *CallerTracer {
+	return &CallerTracer{directory: d, relationDigraph: g, maxDepth: maxDepth}
+}
+
+// Trace walks the calls back from the functions matching the targets, a caller tree each.
+// The callers of a function are expanded once per tree, later occurrences are marked as repeated.
+func (ct *CallerTracer) Trace(targets []string) ([]*valueobject.Caller, error) {
+	keys, err := ct.relationDigraph.MatchKeys(targets)
+	if err != nil {
+		return nil, err
+	}
+
+	ct.incoming = make(map[*directed.Node][]*directed.Edge)
+	for _, n := range ct.relationDigraph.Nodes {
+		for _, e := range n.Edges {
+			if e.Type == arch.RelationTypeDependency {
+				ct.incoming[e.To] = append(ct.incoming[e.To], e)
+			}
+		}
+	}
+	for _, es := range ct.incoming {
+		sort.SliceStable(es, func(i, j int) bool {
+			if es[i].From.Key != es[j].From.Key {
+				return es[i].From.Key < es[j].From.Key
+			}
+			return positionLess(callSite(es[i]), callSite(es[j]))
+		})
+	}
+
+	var trees []*valueobject.Caller
+	for _, k := range keys {
+		n := ct.relationDigraph.FindNodeByKey(k)
+		root := ct.caller(n, nil)
+		ct.expand(root, n, 0, map[*directed.Node]bool{}, map[*directed.Node]bool{})
+		trees = append(trees, root)
+	}
+	return trees, nil
+}
+
+func (ct *CallerTracer) expand(c *valueobject.Caller, n *directed.Node, depth int,
+	expanded, onPath map[*directed.Node]bool) {
+	if len(ct.incoming[n]) == 0 {
+		return
+	}
+	if expanded[n] {
+		c.Repeated = true
+		return
+	}
+	if ct.maxDepth > 0 && depth >= ct.maxDepth {
+		c.Truncated = true
+		return
+	}
+	expanded[n] = true
+	onPath[n] = true
+
+	for _, e := range ct.incoming[n] {
+		cc := ct.caller(e.From, callSite(e))
+		c.Callers = append(c.Callers, cc)
+		if onPath[e.From] {
+			cc.Repeated = true
+			continue
+		}
+		ct.expand(cc, e.From, depth+1, expanded, onPath)
+	}
+	onPath[n] = false
+}
+
+func (ct *CallerTracer) caller(n *directed.Node, pos arch.Position) *valueobject.Caller {
+	id := n.Value.(arch.ObjIdentifier)
+	return &valueobject.Caller{Function: id, Pos: pos, Entry: ct.entry(id)}
+}
+
+// entry tells whether a function is an entry point: the main func, a http handler or an exported
+// function of the interfaces layer, or an exported function of the application layer.
+func (ct *CallerTracer) entry(id arch.ObjIdentifier) valueobject.EntryKind {
+	name := funcName(id)
+	if id.Name() == "main" {
+		return valueobject.EntryMain
+	}
+	if name == "ServeHTTP" {
+		return valueobject.EntryHandler
+	}
+	if name == "" || !unicode.IsUpper([]rune(name)[0]) {
+		return ""
+	}
+	switch ct.directory.Layer(id.Dir()) {
+	case arch.HexagonDirectoryInterfaces:
+		return valueobject.EntryHandler
+	case arch.HexagonDirectoryApplication:
+		return valueobject.EntryApplication
+	}
+	return ""
+}
@@ -0,0 +1,154 @@
+package entity
+
+import (
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"github.com/dddplayer/dp/pkg/datastructure/directed"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newCallerTracer(maxDepth int) *CallerTracer {
+	g := &RelationDigraph{Graph: directed.NewDirectedGraph()}
+
+	ids := make(map[string]*MockObjIdentifier)
+	for _, id := range []string{
+		"test/cmd/main",
+		"test/internal/interfaces/http/Handler.Create",
+		"test/internal/application/Service.Place",
+		"test/internal/domain/order/Order.Place",
+		"test/internal/domain/order/Order.retry",
+	} {
+		ids[id] = &MockObjIdentifier{id: id, name: path.Base(id), dir: path.Dir(id)}
+		_ = g.AddObj(ids[id])
+	}
+
+	call := func(from, to string, line int) {
+		_ = g.AddRelation(&MockDependenceRelation{
+			from:      MockObject{id: ids[from], position: &MockPosition{FilenameVal: "f.go", LineVal: line}},
+			dependsOn: MockObject{id: ids[to], position: &MockPosition{}},
+		})
+	}
+	call("test/cmd/main", "test/internal/interfaces/http/Handler.Create", 10)
+	call("test/cmd/main", "test/internal/application/Service.Place", 12)
+	call("test/internal/interfaces/http/Handler.Create", "test/internal/application/Service.Place", 25)
+	call("test/internal/interfaces/http/Handler.Create", "test/internal/application/Service.Place", 20)
+	call("test/internal/application/Service.Place", "test/internal/domain/order/Order.Place", 30)
+	call("test/internal/domain/order/Order.retry", "test/internal/domain/order/Order.Place", 40)
+	call("test/internal/domain/order/Order.Place", "test/internal/domain/order/Order.retry", 5)
+
+	d := NewDirectory([]string{
+		"test/cmd/main",
+		"test/internal/interfaces/http/Handler",
+		"test/internal/application/Service",
+		"test/internal/domain/order/Order",
+	})
+
+	return NewCallerTracer(d, g, maxDepth)
+}
+
+func callerLines(c *valueobject.Caller, depth int) []string {
+	line := strings.Repeat("  ", depth) + c.Function.ID()
+	if c.Pos != nil {
+		line += fmt.Sprintf("@%d", c.Pos.Line())
+	}
+	if c.Entry != "" {
+		line += " " + string(c.Entry)
+	}
+	if c.Repeated {
+		line += " repeated"
+	}
+	if c.Truncated {
+		line += " truncated"
+	}
+	lines := []string{line}
+	for _, cc := range c.Callers {
+		lines = append(lines, callerLines(cc, depth+1)...)
+	}
+	return lines
+}
+
+func TestCallerTracer_Trace(t *testing.T) {
+	trees, err := newCallerTracer(0).Trace([]string{"test/internal/domain/order/Order.Place"})
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if len(trees) != 1 {
+		t.Fatalf("Expected 1 tree, but got %d", len(trees))
+	}
+
+	expected := []string{
+		"test/internal/domain/order/Order.Place",
+		"  test/internal/application/Service.Place@30 application",
+		"    test/cmd/main@12 main",
+		"    test/internal/interfaces/http/Handler.Create@20 handler",
+		"      test/cmd/main@10 main",
+		"    test/internal/interfaces/http/Handler.Create@25 handler repeated",
+		"  test/internal/domain/order/Order.retry@40",
+		"    test/internal/domain/order/Order.Place@5 repeated",
+	}
+	if lines := callerLines(trees[0], 0); !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Expected tree\n%s\nbut got\n%s", strings.Join(expected, "\n"), strings.Join(lines, "\n"))
+	}
+
+	var entries []string
+	for _, e := range trees[0].Entries() {
+		entries = append(entries, e.Function.ID())
+	}
+	expectedEntries := []string{
+		"test/internal/application/Service.Place",
+		"test/cmd/main",
+		"test/internal/interfaces/http/Handler.Create",
+	}
+	if !reflect.DeepEqual(entries, expectedEntries) {
+		t.Errorf("Expected entries %v, but got %v", expectedEntries, entries)
+	}
+}
+
+func TestCallerTracer_TraceTargets(t *testing.T) {
+	trees, err := newCallerTracer(0).Trace([]string{"test/internal/domain/order/Order.*"})
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if len(trees) != 2 {
+		t.Fatalf("Expected 2 trees, but got %d", len(trees))
+	}
+
+	for _, tree := range trees {
+		var entries []string
+		for _, e := range tree.Entries() {
+			entries = append(entries, e.Function.ID())
+		}
+		if len(entries) != 3 {
+			t.Errorf("Expected 3 entries for %s, but got %v", tree.Function.ID(), entries)
+		}
+	}
+}
+
+func TestCallerTracer_TraceMaxDepth(t *testing.T) {
+	trees, err := newCallerTracer(1).Trace([]string{"test/internal/domain/order/Order.*"})
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if len(trees) != 2 {
+		t.Fatalf("Expected 2 trees, but got %d", len(trees))
+	}
+
+	expected := []string{
+		"test/internal/domain/order/Order.Place",
+		"  test/internal/application/Service.Place@30 application truncated",
+		"  test/internal/domain/order/Order.retry@40 truncated",
+	}
+	if lines := callerLines(trees[0], 0); !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Expected tree\n%s\nbut got\n%s", strings.Join(expected, "\n"), strings.Join(lines, "\n"))
+	}
+	if lines := callerLines(trees[1], 0); len(lines) != 2 || lines[1] != "  test/internal/domain/order/Order.Place@5 truncated" {
+		t.Errorf("Expected the second tree to truncate Order.Place, but got %v", lines)
+	}
+
+	if _, err := newCallerTracer(0).Trace([]string{"test/other/*"}); err == nil {
+		t.Error("Expected an error for targets matching no function")
+	}
+}
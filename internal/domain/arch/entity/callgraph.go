@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"sort"
+)
+
+type CallComparator struct {
+	relRepo repository.RelationRepository
+}
+
+func NewCallComparator(relRepo repository.RelationRepository) *CallComparator {
+	return &CallComparator{relRepo: relRepo}
+}
+
+// Compare splits the call edges found by the base and other algorithms into
+// the ones both agree on and the ones only one of them reports.
+func (cc *CallComparator) Compare(base, other string) *valueobject.CallDiff {
+	calls := map[string]map[string]arch.DependenceRelation{
+		base:  {},
+		other: {},
+	}
+	cc.relRepo.Walk(func(rel arch.Relation) error {
+		if dep, ok := rel.(arch.DependenceRelation); ok {
+			if edges, ok := calls[dep.Algorithm()]; ok {
+				edges[callKey(dep)] = dep
+			}
+		}
+		return nil
+	})
+
+	diff := &valueobject.CallDiff{Base: base, Other: other}
+	for key, dep := range calls[base] {
+		if _, ok := calls[other][key]; ok {
+			diff.Common++
+		} else {
+			diff.OnlyBase = append(diff.OnlyBase, dep)
+		}
+	}
+	for key, dep := range calls[other] {
+		if _, ok := calls[base][key]; !ok {
+			diff.OnlyOther = append(diff.OnlyOther, dep)
+		}
+	}
+	sortCalls(diff.OnlyBase)
+	sortCalls(diff.OnlyOther)
+
+	return diff
+}
+
+func callKey(dep arch.DependenceRelation) string {
+	site := ""
+	if pos := dep.From().Position(); pos != nil {
+		site = fmt.Sprintf("%s:%d:%d", pos.Filename(), pos.Line(), pos.Column())
+	}
+	return fmt.Sprintf("%s->%s@%s", dep.From().Identifier().ID(), dep.DependsOn().Identifier().ID(), site)
+}
+
+func sortCalls(deps []arch.DependenceRelation) {
+	sort.Slice(deps, func(i, j int) bool {
+		return callKey(deps[i]) < callKey(deps[j])
+	})
+}
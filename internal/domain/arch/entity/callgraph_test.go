@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"testing"
+)
+
+func TestCallComparator_Compare(t *testing.T) {
+	dir := "test/internal/domain/order"
+	place := newMockMethodWithName(dir, "Order.Place", 10)
+	pay := newMockMethodWithName(dir, "Order.Pay", 20)
+	notify := newMockMethodWithName(dir, "Notifier.Notify", 30)
+	log := newMockMethodWithName(dir, "Log", 40)
+
+	relRepo := &MockRelationRepository{}
+	_ = relRepo.Insert(&MockDependenceRelation{from: place, dependsOn: pay, algorithm: "static"})
+	_ = relRepo.Insert(&MockDependenceRelation{from: place, dependsOn: pay, algorithm: "cha"})
+	_ = relRepo.Insert(&MockDependenceRelation{from: place, dependsOn: notify, algorithm: "cha"})
+	_ = relRepo.Insert(&MockDependenceRelation{from: pay, dependsOn: log, algorithm: "static"})
+	_ = relRepo.Insert(&MockDependenceRelation{from: pay, dependsOn: notify, algorithm: "pointer"})
+	_ = relRepo.Insert(&MockDependenceRelation{from: pay, dependsOn: log})
+
+	d := NewCallComparator(relRepo).Compare("static", "cha")
+
+	if d.Base != "static" || d.Other != "cha" || d.Common != 1 {
+		t.Errorf("Expected 1 call common to static and cha, but got %d (%s, %s)", d.Common, d.Base, d.Other)
+	}
+	if len(d.OnlyBase) != 1 || d.OnlyBase[0].DependsOn() != log {
+		t.Errorf("Expected Log to be called only in static, but got %v", d.OnlyBase)
+	}
+	if len(d.OnlyOther) != 1 || d.OnlyOther[0].DependsOn() != notify || d.OnlyOther[0].From() != place {
+		t.Errorf("Expected Notify to be called only in cha, but got %v", d.OnlyOther)
+	}
+}
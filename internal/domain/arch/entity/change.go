@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+)
+
+var changeGroups = []valueobject.ChangeStatus{
+	valueobject.ChangeStatusAdded,
+	valueobject.ChangeStatusChanged,
+	valueobject.ChangeStatusRemoved,
+	valueobject.ChangeStatusUnchanged,
+}
+
+// ChangeDiagram groups the objects by their change, adding the unchanged ends of the
+// changed relations, which are highlighted.
+func ChangeDiagram(name string, objs []*valueobject.ChangedObj, rels []*valueobject.ChangedRelation) (arch.Diagram, error) {
+	g, err := NewDiagram(name, arch.PlainDiagram)
+	if err != nil {
+		return nil, err
+	}
+
+	grouped := make(map[valueobject.ChangeStatus][]*valueobject.ChangedObj)
+	known := make(map[string]bool)
+	for _, o := range objs {
+		if known[o.Identifier().ID()] {
+			continue
+		}
+		grouped[o.Status] = append(grouped[o.Status], o)
+		known[o.Identifier().ID()] = true
+	}
+	for _, r := range rels {
+		for _, id := range []string{r.From, r.To} {
+			if !known[id] {
+				o := valueobject.NewChangedObj(id, valueobject.ChangeStatusUnchanged)
+				grouped[o.Status] = append(grouped[o.Status], o)
+				known[id] = true
+			}
+		}
+	}
+
+	for _, status := range changeGroups {
+		if len(grouped[status]) == 0 {
+			continue
+		}
+		key := string(status)
+		if err := g.AddStringTo(key, g.Name(), arch.RelationTypeAggregationRoot); err != nil {
+			return nil, err
+		}
+		for _, o := range grouped[status] {
+			if err := g.AddObjTo(o, key, arch.RelationTypeAggregation); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, r := range rels {
+		t := r.Type
+		switch t {
+		case arch.RelationTypeComposition, arch.RelationTypeEmbedding,
+			arch.RelationTypeAttribution, arch.RelationTypeBehavior:
+			t = arch.RelationTypeDependency
+		}
+		if err := g.AddEdge(r.From, r.To, t, valueobject.NewEmptyRelationPos()); err != nil {
+			return nil, err
+		}
+		g.Highlight(r.From, r.To)
+	}
+
+	return g, nil
+}
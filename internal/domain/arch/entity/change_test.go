@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"testing"
+)
+
+func TestChangeDiagram(t *testing.T) {
+	objs := []*valueobject.ChangedObj{
+		valueobject.NewChangedObj("app/order/Address", valueobject.ChangeStatusAdded),
+		valueobject.NewChangedObj("app/order/Item", valueobject.ChangeStatusChanged),
+		valueobject.NewChangedObj("app/order/Money", valueobject.ChangeStatusRemoved),
+	}
+	rels := []*valueobject.ChangedRelation{
+		{From: "app/order/Order", To: "app/order/Address", Type: arch.RelationTypeAssociation, Status: valueobject.ChangeStatusAdded},
+		{From: "app/order/Item", To: "app/order/Money", Type: arch.RelationTypeComposition, Status: valueobject.ChangeStatusRemoved},
+	}
+
+	d, err := ChangeDiagram("diff", objs, rels)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	g := d.(*Diagram)
+
+	for _, key := range []string{"added", "changed", "removed", "unchanged"} {
+		if g.FindNodeByKey(key) == nil {
+			t.Errorf("Expected group %s in diagram", key)
+		}
+	}
+	if n := g.FindNodeByKey("app/order/Order"); n == nil ||
+		n.Value.(*valueobject.ChangedObj).Status != valueobject.ChangeStatusUnchanged {
+		t.Errorf("Expected the unchanged end of a changed relation in diagram")
+	}
+
+	edges := make(map[string]arch.RelationType)
+	for _, e := range g.Edges() {
+		if e.Type() == arch.RelationTypeAggregationRoot {
+			continue
+		}
+		if !e.Highlighted() {
+			t.Errorf("Expected edge %s -> %s to be highlighted", e.From(), e.To())
+		}
+		edges[e.From()+" -> "+e.To()] = e.Type()
+	}
+	if len(edges) != 2 ||
+		edges["app/order/Order -> app/order/Address"] != arch.RelationTypeAssociation ||
+		edges["app/order/Item -> app/order/Money"] != arch.RelationTypeDependency {
+		t.Errorf("Expected the association and the composition drawn as dependency, but got %v", edges)
+	}
+}
@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"path"
+	"sort"
+	"strings"
+)
+
+type RuleChecker struct {
+	directory       *Directory
+	relationDigraph *RelationDigraph
+}
+
+func (rc *RuleChecker) Check(rules []*valueobject.Rule) []*valueobject.Violation {
+	var violations []*valueobject.Violation
+
+	for _, n := range rc.relationDigraph.Nodes {
+		from := n.Value.(arch.ObjIdentifier)
+		for _, e := range n.Edges {
+			to := e.To.Value.(arch.ObjIdentifier)
+			for _, r := range rules {
+				if !rc.violates(r, from.Dir(), to.Dir()) {
+					continue
+				}
+				pos, _ := e.Value.(arch.RelationPos)
+				violations = append(violations, &valueobject.Violation{
+					Rule: r,
+					From: from,
+					To:   to,
+					Type: e.Type.(arch.RelationType),
+					Pos:  pos,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		fi, li := relationLocation(violations[i].Pos)
+		fj, lj := relationLocation(violations[j].Pos)
+		if fi != fj {
+			return fi < fj
+		}
+		return li < lj
+	})
+
+	return violations
+}
+
+func (rc *RuleChecker) violates(r *valueobject.Rule, fromDir, toDir string) bool {
+	if !rc.match(r.From, fromDir) {
+		return false
+	}
+
+	for _, f := range r.Forbid {
+		if rc.match(f, toDir) {
+			return true
+		}
+	}
+
+	if len(r.Allow) == 0 || rc.match(r.From, toDir) {
+		return false
+	}
+	for _, a := range r.Allow {
+		if rc.match(a, toDir) {
+			return false
+		}
+	}
+	return true
+}
+
+func (rc *RuleChecker) match(selector, dir string) bool {
+	switch arch.HexagonDirectory(selector) {
+	case arch.HexagonDirectoryCmd, arch.HexagonDirectoryPkg, arch.HexagonDirectoryInternal,
+		arch.HexagonDirectoryDomain, arch.HexagonDirectoryApplication,
+		arch.HexagonDirectoryInfrastructure, arch.HexagonDirectoryInterfaces:
+		layer := rc.directory.Layer(dir)
+		if arch.HexagonDirectory(selector) == arch.HexagonDirectoryInternal {
+			return layer != arch.HexagonDirectoryInvalid && layer != arch.HexagonDirectoryCmd &&
+				layer != arch.HexagonDirectoryPkg
+		}
+		return layer == arch.HexagonDirectory(selector)
+	}
+
+	pkg := selector
+	if !rc.directory.isValid(pkg) {
+		pkg = path.Join(rc.directory.RootDir(), pkg)
+	}
+	return dir == pkg || strings.HasPrefix(dir, pkg+"/")
+}
+
+func relationLocation(pos arch.RelationPos) (string, int) {
+	if pos == nil || pos.From() == nil {
+		return "", 0
+	}
+	return pos.From().Filename(), pos.From().Line()
+}
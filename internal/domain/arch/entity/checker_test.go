@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"testing"
+)
+
+func newMockCheckArch() *Arch {
+	cmdObj := newMockObjectWithId("test/cmd", "main", 1)
+	domainObj := newMockObjectWithId("test/internal/domain/order/entity", "Order", 1)
+	appObj := newMockObjectWithId("test/internal/application", "Service", 1)
+	infraObj := newMockObjectWithId("test/internal/infrastructure/persistence", "Repo", 1)
+	ifaceObj := newMockObjectWithId("test/internal/interfaces/api", "Handler", 1)
+	pkgObj := newMockObjectWithId("test/pkg/util", "Util", 1)
+
+	mockRepo := &MockObjectRepository{
+		objects: make(map[string]arch.Object),
+		idents:  []arch.ObjIdentifier{},
+	}
+	for _, o := range []*MockObject{cmdObj, domainObj, appObj, infraObj, ifaceObj, pkgObj} {
+		_ = mockRepo.Insert(o)
+	}
+
+	mockRelRepo := &MockRelationRepository{relations: []arch.Relation{
+		&MockDependenceRelation{from: domainObj, dependsOn: infraObj},
+		&MockDependenceRelation{from: appObj, dependsOn: domainObj},
+		&MockDependenceRelation{from: ifaceObj, dependsOn: appObj},
+		&MockDependenceRelation{from: ifaceObj, dependsOn: domainObj},
+		&MockDependenceRelation{from: ifaceObj, dependsOn: pkgObj},
+		&MockDependenceRelation{from: cmdObj, dependsOn: ifaceObj},
+	}}
+
+	return &Arch{
+		CodeHandler: &valueobject.CodeHandler{
+			ObjRepo: mockRepo,
+			RelRepo: mockRelRepo,
+			Scope:   "test",
+		},
+	}
+}
+
+func TestArch_Check(t *testing.T) {
+	a := newMockCheckArch()
+
+	violations, err := a.Check([]*valueobject.Rule{
+		{Name: "domain independent", From: "domain", Forbid: []string{"infrastructure"}},
+		{Name: "interfaces via application", From: "interfaces", Allow: []string{"application", "pkg"}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	if len(violations) != 2 {
+		t.Fatalf("Expected 2 violations, but got %d", len(violations))
+	}
+
+	found := map[string]string{}
+	for _, v := range violations {
+		found[v.Rule.Name] = v.From.Dir() + "->" + v.To.Dir()
+		if v.Type != arch.RelationTypeDependency {
+			t.Errorf("Expected dependency relation, but got %v", v.Type)
+		}
+		if v.Pos == nil || v.Pos.From().Filename() != "mockfile" {
+			t.Errorf("Expected violation position, but got %v", v.Pos)
+		}
+	}
+	if found["domain independent"] != "test/internal/domain/order/entity->test/internal/infrastructure/persistence" {
+		t.Errorf("Unexpected domain violation %s", found["domain independent"])
+	}
+	if found["interfaces via application"] != "test/internal/interfaces/api->test/internal/domain/order/entity" {
+		t.Errorf("Unexpected interfaces violation %s", found["interfaces via application"])
+	}
+}
+
+func TestRuleChecker_Match(t *testing.T) {
+	rc := &RuleChecker{directory: NewDirectory([]string{"test/cmd/a", "test/internal/domain/b"})}
+
+	tests := []struct {
+		selector string
+		dir      string
+		expected bool
+	}{
+		{"domain", "test/internal/domain/order", true},
+		{"internal", "test/internal/domain/order", true},
+		{"internal", "test/cmd", false},
+		{"cmd", "test/internal/domain", false},
+		{"internal/domain/order", "test/internal/domain/order/entity", true},
+		{"test/internal/domain/order", "test/internal/domain/order", true},
+		{"internal/domain/order", "test/internal/domain/orders", false},
+	}
+	for _, tt := range tests {
+		if res := rc.match(tt.selector, tt.dir); res != tt.expected {
+			t.Errorf("match(%q, %q) = %v, expected %v", tt.selector, tt.dir, res, tt.expected)
+		}
+	}
+}
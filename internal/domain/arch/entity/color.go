@@ -13,6 +13,12 @@ func objColor(object arch.Object) arch.ObjColor {
 		return arch.ColorEntity
 	case *valueobject.ValueObject:
 		return arch.ColorValueObject
+	case *valueobject.Repository, *valueobject.Factory:
+		return arch.ColorFactory
+	case *valueobject.DomainEvent:
+		return arch.ColorEvent
+	case *valueobject.DomainCommand:
+		return arch.ColorCommand
 	case *valueobject.Class:
 		return arch.ColorClass
 	case *valueobject.DomainInterface, *valueobject.Interface:
@@ -23,14 +29,29 @@ func objColor(object arch.Object) arch.ObjColor {
 		return arch.ColorFunc
 	case *valueobject.StringObj:
 		return arch.ColorWhite
+	case *valueobject.ChangedObj:
+		return changeColor(object.(*valueobject.ChangedObj).Status)
 	default:
 		return arch.ColorGeneral
 	}
 }
 
+func changeColor(status valueobject.ChangeStatus) arch.ObjColor {
+	switch status {
+	case valueobject.ChangeStatusAdded:
+		return arch.ColorAdded
+	case valueobject.ChangeStatusRemoved:
+		return arch.ColorRemoved
+	case valueobject.ChangeStatusChanged:
+		return arch.ColorChanged
+	}
+	return arch.ColorWhite
+}
+
 func objColorWithParent(object, parent arch.Object) arch.ObjColor {
 	switch parent.(type) {
-	case *valueobject.Aggregate, *valueobject.Entity, *valueobject.ValueObject, *valueobject.Class:
+	case *valueobject.Aggregate, *valueobject.Entity, *valueobject.ValueObject, *valueobject.Class,
+		*valueobject.Repository, *valueobject.Factory:
 		switch object.(type) {
 		case *valueobject.DomainFunction, *valueobject.Function:
 			return arch.ColorMethod
@@ -50,6 +50,28 @@ func TestObjColor(t *testing.T) {
 		}
 	})
 
+	t.Run("Test objColor with Repository and Factory", func(t *testing.T) {
+		for _, o := range []arch.Object{&valueobject.Repository{}, &valueobject.Factory{}} {
+			if color := objColor(o); color != arch.ColorFactory {
+				t.Errorf("Expected color %s, but got %s", arch.ColorFactory, color)
+			}
+		}
+	})
+
+	t.Run("Test objColor with DomainEvent", func(t *testing.T) {
+		color := objColor(&valueobject.DomainEvent{})
+		if color != arch.ColorEvent {
+			t.Errorf("Expected color %s, but got %s", arch.ColorEvent, color)
+		}
+	})
+
+	t.Run("Test objColor with DomainCommand", func(t *testing.T) {
+		color := objColor(&valueobject.DomainCommand{})
+		if color != arch.ColorCommand {
+			t.Errorf("Expected color %s, but got %s", arch.ColorCommand, color)
+		}
+	})
+
 	t.Run("Test objColor with StringObj", func(t *testing.T) {
 		color := objColor(mockStringObj)
 		if color != arch.ColorWhite {
@@ -57,6 +79,19 @@ func TestObjColor(t *testing.T) {
 		}
 	})
 
+	t.Run("Test objColor with ChangedObj", func(t *testing.T) {
+		for status, expected := range map[valueobject.ChangeStatus]arch.ObjColor{
+			valueobject.ChangeStatusAdded:     arch.ColorAdded,
+			valueobject.ChangeStatusRemoved:   arch.ColorRemoved,
+			valueobject.ChangeStatusChanged:   arch.ColorChanged,
+			valueobject.ChangeStatusUnchanged: arch.ColorWhite,
+		} {
+			if color := objColor(valueobject.NewChangedObj("app/order/Order", status)); color != expected {
+				t.Errorf("Expected color %s for %s, but got %s", expected, status, color)
+			}
+		}
+	})
+
 	t.Run("Test objColor with default", func(t *testing.T) {
 		color := objColor(mockObject)
 		if color != arch.ColorGeneral {
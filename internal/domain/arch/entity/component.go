@@ -0,0 +1,150 @@
+package entity
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"sort"
+)
+
+type ComponentLinker struct {
+	relRepo    repository.RelationRepository
+	aggregates []*valueobject.AggregateGroup
+}
+
+func NewComponentLinker(relRepo repository.RelationRepository, ags []*valueobject.AggregateGroup) *ComponentLinker {
+	return &ComponentLinker{
+		relRepo:    relRepo,
+		aggregates: ags,
+	}
+}
+
+// Links relates repositories to the aggregate roots in their method signatures,
+// and factories to the domain objects they create or return.
+func (cl *ComponentLinker) Links() []*valueobject.ComponentLink {
+	roots := make(map[string]arch.DomainObj)
+	products := make(map[string]arch.DomainObj)
+	owners := make(map[string]arch.DomainObj)
+
+	for _, ag := range cl.aggregates {
+		a, err := ag.Aggregate()
+		if err != nil {
+			continue
+		}
+		for _, sg := range ag.SubGroups() {
+			switch g := sg.(type) {
+			case *valueobject.EntityGroup:
+				for _, e := range g.Entities() {
+					products[e.OriginIdentifier().ID()] = e
+				}
+			case *valueobject.VOGroup:
+				for _, vo := range g.ValueObjects() {
+					products[vo.OriginIdentifier().ID()] = vo
+				}
+			case *valueobject.RepositoryGroup:
+				for _, r := range g.Repositories() {
+					for _, m := range r.Methods {
+						owners[m.OriginIdentifier().ID()] = r
+					}
+				}
+			case *valueobject.FactoryGroup:
+				for _, f := range g.Factories() {
+					owners[f.OriginIdentifier().ID()] = f
+					for _, m := range f.Methods {
+						owners[m.OriginIdentifier().ID()] = f
+					}
+				}
+			}
+		}
+		if a.Entity != nil {
+			roots[a.Entity.OriginIdentifier().ID()] = a
+			products[a.Entity.OriginIdentifier().ID()] = a
+		}
+	}
+
+	if len(owners) == 0 {
+		return nil
+	}
+
+	var links []*valueobject.ComponentLink
+	linked := make(map[string]bool)
+	cl.relRepo.Walk(func(rel arch.Relation) error {
+		u, ok := rel.(arch.UsageRelation)
+		if !ok {
+			return nil
+		}
+		owner := owners[u.From().Identifier().ID()]
+		if owner == nil {
+			return nil
+		}
+
+		var to arch.DomainObj
+		var t arch.RelationType
+		switch owner.(type) {
+		case *valueobject.Repository:
+			if u.Type() != arch.RelationTypeInstantiation {
+				to, t = roots[u.Used().Identifier().ID()], arch.RelationTypeAssociation
+			}
+		case *valueobject.Factory:
+			if u.Type() != arch.RelationTypeParameter {
+				to, t = products[u.Used().Identifier().ID()], arch.RelationTypeDependency
+			}
+		}
+		if to == nil || linked[highlightKey(owner.Identifier().ID(), to.Identifier().ID())] {
+			return nil
+		}
+
+		linked[highlightKey(owner.Identifier().ID(), to.Identifier().ID())] = true
+		links = append(links, &valueobject.ComponentLink{
+			From: owner,
+			To:   to,
+			Type: t,
+			Pos:  valueobject.NewRelationPos(u.From().Position(), u.Used().Position()),
+		})
+		return nil
+	})
+
+	return links
+}
+
+func (cl *ComponentLinker) RepositoryFindings() []*valueobject.RepositoryFinding {
+	repositories := make(map[string][]arch.ObjIdentifier)
+	for _, l := range cl.Links() {
+		if _, ok := l.From.(*valueobject.Repository); ok {
+			id := l.To.Identifier().ID()
+			repositories[id] = append(repositories[id], l.From.OriginIdentifier())
+		}
+	}
+
+	var findings []*valueobject.RepositoryFinding
+	for _, ag := range cl.aggregates {
+		a, err := ag.Aggregate()
+		if err != nil || a.Entity == nil {
+			continue
+		}
+		rs := repositories[a.Identifier().ID()]
+		if len(rs) == 1 {
+			continue
+		}
+		sort.Slice(rs, func(i, j int) bool { return rs[i].ID() < rs[j].ID() })
+		findings = append(findings, &valueobject.RepositoryFinding{
+			Aggregate:    ag.Name(),
+			Repositories: rs,
+		})
+	}
+
+	return findings
+}
+
+func (cl *ComponentLinker) addToDiagram(g *Diagram) error {
+	for _, l := range cl.Links() {
+		fromId, toId := l.From.Identifier().ID(), l.To.Identifier().ID()
+		if g.FindNodeByKey(fromId) == nil || g.FindNodeByKey(toId) == nil {
+			continue
+		}
+		if err := g.AddEdge(fromId, toId, l.Type, l.Pos); err != nil {
+			return err
+		}
+	}
+	return nil
+}
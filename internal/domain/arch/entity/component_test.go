@@ -0,0 +1,150 @@
+package entity
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"testing"
+)
+
+func newMockComponentArch() *Arch {
+	orderDir := "test/internal/domain/order"
+	paymentDir := "test/internal/domain/payment"
+
+	order := newMockClassWithName(orderDir+"/entity", "Order")
+	line := newMockClassWithName(orderDir+"/valueobject", "Line")
+	payment := newMockClassWithName(paymentDir+"/entity", "Payment")
+
+	saveObj := newMockMethodWithName(orderDir+"/repository", "OrderRepo.Save", 10)
+	repo := valueobject.NewClass(newMockMethodWithName(orderDir+"/repository", "OrderRepo", 9),
+		nil, []arch.ObjIdentifier{saveObj.Identifier()})
+	save := valueobject.NewFunction(saveObj, repo.Identifier())
+	newOrder := newMockMethodWithName(orderDir+"/factory", "NewOrder", 20)
+	newLine := newMockMethodWithName(orderDir+"/factory", "NewLine", 30)
+
+	objRepo := &MockObjectRepository{
+		objects: make(map[string]arch.Object),
+		idents:  []arch.ObjIdentifier{},
+	}
+	for _, o := range []arch.Object{
+		valueobject.NewFunction(newMockMethodWithName("test/cmd", "main", 1), nil),
+		valueobject.NewFunction(newMockMethodWithName("test/pkg/util", "Util", 1), nil),
+		order, line, payment, repo, save,
+		valueobject.NewFunction(newOrder, nil), valueobject.NewFunction(newLine, nil),
+	} {
+		_ = objRepo.Insert(o)
+	}
+
+	relRepo := &MockRelationRepository{}
+	_ = relRepo.Insert(&MockUsageRelation{relType: arch.RelationTypeParameter, from: saveObj, used: order})
+	_ = relRepo.Insert(&MockUsageRelation{relType: arch.RelationTypeResult, from: newOrder, used: order})
+	_ = relRepo.Insert(&MockUsageRelation{relType: arch.RelationTypeInstantiation, from: newOrder, used: line})
+	_ = relRepo.Insert(&MockUsageRelation{relType: arch.RelationTypeParameter, from: newLine, used: line})
+
+	return &Arch{
+		CodeHandler: &valueobject.CodeHandler{
+			ObjRepo: objRepo,
+			RelRepo: relRepo,
+			Scope:   "test",
+		},
+	}
+}
+
+func TestComponentLinker_Links(t *testing.T) {
+	a := newMockComponentArch()
+	ags, err := a.Aggregates()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	var repositories, factories int
+	for _, ag := range ags {
+		for _, sg := range ag.SubGroups() {
+			switch g := sg.(type) {
+			case *valueobject.RepositoryGroup:
+				repositories += len(g.Repositories())
+			case *valueobject.FactoryGroup:
+				factories += len(g.Factories())
+			}
+		}
+	}
+	if repositories != 1 || factories != 2 {
+		t.Fatalf("Expected 1 repository and 2 factories, but got %d and %d", repositories, factories)
+	}
+
+	expected := map[string]arch.RelationType{
+		"order/repository/OrderRepo -> order/entity/Order": arch.RelationTypeAssociation,
+		"order/factory/NewOrder -> order/entity/Order":     arch.RelationTypeDependency,
+		"order/factory/NewOrder -> order/valueobject/Line": arch.RelationTypeDependency,
+	}
+	links := NewComponentLinker(a.RelRepo, ags).Links()
+	if len(links) != len(expected) {
+		t.Fatalf("Expected %d links, but got %d", len(expected), len(links))
+	}
+	for _, l := range links {
+		key := l.From.Identifier().ID() + " -> " + l.To.Identifier().ID()
+		if tp, ok := expected[key]; !ok || tp != l.Type {
+			t.Errorf("Unexpected link %s (%v)", key, l.Type)
+		}
+	}
+}
+
+func TestComponentLinker_RepositoryFindings(t *testing.T) {
+	a := newMockComponentArch()
+	findings, err := a.RepositoryFindings()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	if len(findings) != 1 {
+		t.Fatalf("Expected 1 finding, but got %d", len(findings))
+	}
+	if findings[0].Aggregate != "payment" || len(findings[0].Repositories) != 0 {
+		t.Errorf("Expected payment without repository, but got %s with %v",
+			findings[0].Aggregate, findings[0].Repositories)
+	}
+}
+
+func TestArch_StrategicGraphWithComponents(t *testing.T) {
+	a := newMockComponentArch()
+	g, err := a.StrategicGraph()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	d := g.(*Diagram)
+	for _, key := range []string{"order/repository/OrderRepo", "order/factory/NewOrder", "order/factory/NewLine"} {
+		n := d.FindNodeByKey(key)
+		if n == nil {
+			t.Fatalf("Expected node %s in strategic diagram", key)
+		}
+		if c := objColor(n.Value.(arch.Object)); c != arch.ColorFactory {
+			t.Errorf("Expected %s to be coloured %s, but got %s", key, arch.ColorFactory, c)
+		}
+	}
+
+	var persists bool
+	for _, e := range d.Edges() {
+		if e.From() == "order/repository/OrderRepo" && e.To() == "order/entity/Order" &&
+			e.Type() == arch.RelationTypeAssociation {
+			persists = true
+		}
+	}
+	if !persists {
+		t.Error("Expected repository to be linked to the aggregate root")
+	}
+}
+
+func TestArch_TacticGraphWithComponents(t *testing.T) {
+	a := newMockComponentArch()
+	g, err := a.TacticGraph(&MockOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	d := g.(*Diagram)
+	for _, key := range []string{"order/repository", "order/factory", "order/repository/OrderRepo.Save"} {
+		if d.FindNodeByKey(key) == nil {
+			t.Errorf("Expected node %s in tactic diagram", key)
+		}
+	}
+}
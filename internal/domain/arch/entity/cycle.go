@@ -0,0 +1,201 @@
+package entity
+
+import (
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"github.com/dddplayer/dp/pkg/datastructure/directed"
+	"strings"
+)
+
+type CycleDetector struct {
+	directory       *Directory
+	objRepo         repository.ObjectRepository
+	relationDigraph *RelationDigraph
+}
+
+func (cd *CycleDetector) Cycles(level valueobject.CycleLevel) ([]*valueobject.Cycle, error) {
+	switch level {
+	case valueobject.CycleLevelObject:
+		return cd.objectCycles(), nil
+	case valueobject.CycleLevelPackage:
+		return cd.packageCycles(), nil
+	}
+	return nil, fmt.Errorf("unsupported cycle level: %s", level)
+}
+
+// objectCycles leaves out recursion, an object depending on itself is not a cycle between objects.
+func (cd *CycleDetector) objectCycles() []*valueobject.Cycle {
+	var cycles []*valueobject.Cycle
+	for _, c := range cd.relationDigraph.Cycles() {
+		if len(c) == 1 {
+			continue
+		}
+		members := make(map[string]bool)
+		cycle := &valueobject.Cycle{Level: valueobject.CycleLevelObject}
+		for _, n := range c {
+			members[n.Key] = true
+			cycle.Members = append(cycle.Members, n.Key)
+		}
+		cycle.Edges = cd.cycleEdges(func(from, to arch.ObjIdentifier) bool {
+			return from.ID() != to.ID() && members[from.ID()] && members[to.ID()]
+		})
+		cycles = append(cycles, cycle)
+	}
+	return cycles
+}
+
+func (cd *CycleDetector) packageCycles() []*valueobject.Cycle {
+	pg := directed.NewDirectedGraph()
+	linked := make(map[string]bool)
+	for _, n := range cd.relationDigraph.Nodes {
+		from := n.Value.(arch.ObjIdentifier).Dir()
+		if pg.FindNodeByKey(from) == nil {
+			_ = pg.AddNode(from, from)
+		}
+		for _, e := range n.Edges {
+			to := e.To.Value.(arch.ObjIdentifier).Dir()
+			if from == to || linked[highlightKey(from, to)] {
+				continue
+			}
+			if pg.FindNodeByKey(to) == nil {
+				_ = pg.AddNode(to, to)
+			}
+			_ = pg.AddEdge(from, to, nil, nil)
+			linked[highlightKey(from, to)] = true
+		}
+	}
+
+	var cycles []*valueobject.Cycle
+	for _, c := range pg.Cycles() {
+		members := make(map[string]bool)
+		cycle := &valueobject.Cycle{Level: valueobject.CycleLevelPackage}
+		for _, n := range c {
+			members[n.Key] = true
+			cycle.Members = append(cycle.Members, n.Key)
+		}
+		cycle.Edges = cd.cycleEdges(func(from, to arch.ObjIdentifier) bool {
+			return from.Dir() != to.Dir() && members[from.Dir()] && members[to.Dir()]
+		})
+		cycles = append(cycles, cycle)
+	}
+	return cycles
+}
+
+func (cd *CycleDetector) cycleEdges(in func(from, to arch.ObjIdentifier) bool) []*valueobject.CycleEdge {
+	var edges []*valueobject.CycleEdge
+	for _, n := range cd.relationDigraph.Nodes {
+		from := n.Value.(arch.ObjIdentifier)
+		for _, e := range n.Edges {
+			to := e.To.Value.(arch.ObjIdentifier)
+			if !in(from, to) {
+				continue
+			}
+			pos, _ := e.Value.(arch.RelationPos)
+			edges = append(edges, &valueobject.CycleEdge{
+				From: from,
+				To:   to,
+				Type: e.Type.(arch.RelationType),
+				Pos:  pos,
+			})
+		}
+	}
+	return edges
+}
+
+func (cd *CycleDetector) buildDiagram(name string, cycles []*valueobject.Cycle) (*Diagram, error) {
+	filter := newCycleFilter(cycles)
+
+	gm, err := NewGeneralModel(cd.objRepo, cd.directory)
+	if err != nil {
+		return nil, err
+	}
+	gm.GroupingWithFilter(filter)
+
+	g, err := NewDiagram(name, arch.TableDiagram)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := gm.addRootGroupToDiagram(g); err != nil {
+		return nil, err
+	}
+
+	highlights := make(map[string]bool)
+	for _, c := range cycles {
+		for _, e := range c.Edges {
+			highlights[highlightKey(e.From.ID(), e.To.ID())] = true
+		}
+	}
+
+	for _, e := range cd.cycleEdges(func(from, to arch.ObjIdentifier) bool {
+		return from.ID() != to.ID() && filter.ids[from.ID()] && filter.ids[to.ID()]
+	}) {
+		fromId, toId := e.From.ID(), e.To.ID()
+		if g.FindNodeByKey(fromId) == nil || g.FindNodeByKey(toId) == nil {
+			continue
+		}
+		if err := g.AddEdge(fromId, toId, e.Type, e.Pos); err != nil {
+			return nil, err
+		}
+		if highlights[highlightKey(fromId, toId)] {
+			g.Highlight(fromId, toId)
+		}
+	}
+
+	return g, nil
+}
+
+type cycleFilter struct {
+	pkgSet map[string]bool
+	ids    map[string]bool
+	owners map[string]bool
+}
+
+func newCycleFilter(cycles []*valueobject.Cycle) *cycleFilter {
+	cf := &cycleFilter{
+		pkgSet: make(map[string]bool),
+		ids:    make(map[string]bool),
+		owners: make(map[string]bool),
+	}
+	for _, c := range cycles {
+		for _, e := range c.Edges {
+			for _, id := range []arch.ObjIdentifier{e.From, e.To} {
+				cf.pkgSet[id.Dir()] = true
+				cf.ids[id.ID()] = true
+				if owner := ownerID(id); owner != "" {
+					cf.owners[owner] = true
+				}
+			}
+		}
+	}
+	return cf
+}
+
+func (cf *cycleFilter) IsValid(dir string) bool {
+	for pkg := range cf.pkgSet {
+		if pkg == dir || strings.HasPrefix(pkg, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (cf *cycleFilter) FilterObjs(objs []arch.Object) []arch.Object {
+	var result []arch.Object
+	for _, o := range objs {
+		id := o.Identifier().ID()
+		switch o.(type) {
+		case *valueobject.Class, *valueobject.Interface:
+			if cf.ids[id] || cf.owners[id] {
+				result = append(result, o)
+			}
+		default:
+			if cf.ids[id] {
+				result = append(result, o)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,116 @@
+package entity
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"testing"
+)
+
+func newMockCycleArch() *Arch {
+	fa := valueobject.NewFunction(newMockMethodWithName("test/a", "fa", 1), nil)
+	fb := valueobject.NewFunction(newMockMethodWithName("test/b", "fb", 2), nil)
+	fb2 := valueobject.NewFunction(newMockMethodWithName("test/b", "fb2", 3), nil)
+	fc := valueobject.NewFunction(newMockMethodWithName("test/c", "fc", 4), nil)
+
+	mockRepo := &MockObjectRepository{
+		objects: make(map[string]arch.Object),
+		idents:  []arch.ObjIdentifier{},
+	}
+	for _, f := range []*valueobject.Function{fa, fb, fb2, fc} {
+		_ = mockRepo.Insert(f)
+	}
+
+	mockRelRepo := &MockRelationRepository{relations: []arch.Relation{
+		&MockDependenceRelation{from: fa, dependsOn: fb},
+		&MockDependenceRelation{from: fb, dependsOn: fb2},
+		&MockDependenceRelation{from: fb2, dependsOn: fa},
+		&MockDependenceRelation{from: fb2, dependsOn: fc},
+		&MockDependenceRelation{from: fa, dependsOn: fa},
+		&MockDependenceRelation{from: fc, dependsOn: fc},
+	}}
+
+	return &Arch{
+		CodeHandler: &valueobject.CodeHandler{
+			ObjRepo: mockRepo,
+			RelRepo: mockRelRepo,
+			Scope:   "test",
+		},
+	}
+}
+
+func TestArch_Cycles(t *testing.T) {
+	a := newMockCycleArch()
+
+	cycles, err := a.Cycles(valueobject.CycleLevelObject)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if len(cycles) != 1 {
+		t.Fatalf("Expected 1 object cycle without the recursive fc, but got %d", len(cycles))
+	}
+	if len(cycles[0].Members) != 3 || len(cycles[0].Edges) != 3 {
+		t.Errorf("Expected 3 members and 3 edges, but got %v and %d", cycles[0].Members, len(cycles[0].Edges))
+	}
+
+	cycles, err = a.Cycles(valueobject.CycleLevelPackage)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if len(cycles) != 1 {
+		t.Fatalf("Expected 1 package cycle, but got %d", len(cycles))
+	}
+	if len(cycles[0].Members) != 2 {
+		t.Errorf("Expected packages test/a and test/b, but got %v", cycles[0].Members)
+	}
+	if len(cycles[0].Edges) != 2 {
+		t.Errorf("Expected 2 cross package edges, but got %d", len(cycles[0].Edges))
+	}
+	for _, e := range cycles[0].Edges {
+		if e.From.Dir() == e.To.Dir() {
+			t.Errorf("Expected cross package edge, but got %s -> %s", e.From.ID(), e.To.ID())
+		}
+	}
+
+	if _, err := a.Cycles("unknown"); err == nil {
+		t.Error("Expected error for unsupported level, but got nil")
+	}
+}
+
+func TestArch_CycleDiagram(t *testing.T) {
+	a := newMockCycleArch()
+
+	g, err := a.CycleDiagram(valueobject.CycleLevelPackage)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	var edges []arch.Edge
+	for _, e := range g.Edges() {
+		if e.Type() == arch.RelationTypeDependency {
+			edges = append(edges, e)
+		}
+	}
+	if len(edges) != 3 {
+		t.Fatalf("Expected 3 dependency edges, but got %d", len(edges))
+	}
+	highlighted := 0
+	for _, e := range edges {
+		if e.To() == "test/c/fc" {
+			t.Errorf("Expected objects outside the cycle to be excluded, but got edge to %s", e.To())
+		}
+		if e.Highlighted() {
+			highlighted++
+		}
+	}
+	if highlighted != 2 {
+		t.Errorf("Expected 2 highlighted edges, but got %d", highlighted)
+	}
+}
+
+func TestArch_CycleDiagram_NoCycles(t *testing.T) {
+	a := newMockCheckArch()
+
+	if _, err := a.CycleDiagram(valueobject.CycleLevelObject); err == nil {
+		t.Error("Expected error when no cycles found, but got nil")
+	}
+}
@@ -10,9 +10,10 @@ import (
 
 type Diagram struct {
 	*directed.Graph
-	root *directed.Node
-	objs []arch.Object
-	t    arch.DiagramType
+	root       *directed.Node
+	objs       []arch.Object
+	t          arch.DiagramType
+	highlights map[string]bool
 }
 
 func NewDiagram(name string, t arch.DiagramType) (*Diagram, error) {
@@ -21,9 +22,10 @@ func NewDiagram(name string, t arch.DiagramType) (*Diagram, error) {
 	}
 
 	g := &Diagram{
-		Graph: directed.NewDirectedGraph(),
-		objs:  []arch.Object{},
-		t:     t,
+		Graph:      directed.NewDirectedGraph(),
+		objs:       []arch.Object{},
+		t:          t,
+		highlights: make(map[string]bool),
 	}
 	if err := g.AddNode(name, valueobject.NewStringObj(name)); err != nil {
 		return nil, err
@@ -79,6 +81,14 @@ func (g *Diagram) AddRelations(fromId, toId string, metas []arch.RelationMeta) e
 	return nil
 }
 
+func (g *Diagram) Highlight(fromId, toId string) {
+	g.highlights[highlightKey(fromId, toId)] = true
+}
+
+func highlightKey(fromId, toId string) string {
+	return fmt.Sprintf("%s->%s", fromId, toId)
+}
+
 func (g *Diagram) Name() string {
 	return g.root.Value.(arch.Object).Identifier().Name()
 }
@@ -128,7 +138,9 @@ func (g *Diagram) parseNodeEdge(dn *directed.Node, visited map[*directed.Node]bo
 		if val, ok := e.Value.(arch.RelationPos); ok {
 			pos = val
 		}
-		es = append(es, newEdge(e.From.Key, e.To.Key, e.Type.(arch.RelationType), pos.From(), pos.To()))
+		ne := newEdge(e.From.Key, e.To.Key, e.Type.(arch.RelationType), pos.From(), pos.To())
+		ne.highlighted = g.highlights[highlightKey(e.From.Key, e.To.Key)]
+		es = append(es, ne)
 	}
 
 	return es
@@ -198,7 +210,9 @@ func (g *Diagram) parseSubDiagrams(dn *directed.Node) arch.SubDiagram {
 			sd.nodes = append(sd.nodes, n)
 			switch toObj.(type) {
 			case *valueobject.Entity, *valueobject.ValueObject, *valueobject.DomainInterface,
-				*valueobject.Class, *valueobject.Interface:
+				*valueobject.Class, *valueobject.Interface,
+				*valueobject.DomainEvent, *valueobject.DomainCommand,
+				*valueobject.Repository, *valueobject.Factory:
 				sd.elements = append(sd.elements, newElement(n, elementTypeClass))
 				g.parseNode(e.To, sd)
 			default:
@@ -330,6 +344,7 @@ type edge struct {
 	relationType arch.RelationType
 	fromPos      arch.Position
 	toPos        arch.Position
+	highlighted  bool
 }
 
 func newEdge(from, to string, relationType arch.RelationType, fromPos, toPos arch.Position) *edge {
@@ -354,6 +369,10 @@ func (e *edge) Type() arch.RelationType {
 	return e.relationType
 }
 
+func (e *edge) Highlighted() bool {
+	return e.highlighted
+}
+
 func (e *edge) Key() string {
 	return fmt.Sprintf("%s-%s-%d", e.From(), e.To(), e.Type())
 }
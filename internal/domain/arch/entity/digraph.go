@@ -6,6 +6,9 @@ import (
 	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
 	"github.com/dddplayer/dp/pkg/datastructure/directed"
 	"golang.org/x/exp/slices"
+	"path"
+	"sort"
+	"strings"
 )
 
 type RelationDigraph struct {
@@ -67,6 +70,33 @@ func (g *RelationDigraph) AddRelation(rel arch.Relation) error {
 	return nil
 }
 
+// MatchKeys returns the sorted keys of the objects matching one of the glob patterns.
+func (g *RelationDigraph) MatchKeys(patterns []string) ([]string, error) {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid function pattern %s: %w", pattern, err)
+		}
+	}
+
+	var keys []string
+	for _, n := range g.Nodes {
+		if _, ok := n.Value.(arch.ObjIdentifier); !ok {
+			continue
+		}
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, n.Key); ok {
+				keys = append(keys, n.Key)
+				break
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no function matches %s", strings.Join(patterns, ", "))
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
 func (g *RelationDigraph) RelationMetas(from, to arch.ObjIdentifier) ([]arch.RelationMeta, error) {
 	f := g.FindNodeByKey(from.ID())
 	t := g.FindNodeByKey(to.ID())
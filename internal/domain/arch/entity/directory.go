@@ -2,7 +2,9 @@ package entity
 
 import (
 	"errors"
+	"fmt"
 	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
 	"github.com/dddplayer/dp/pkg/datastructure/directory"
 	"path"
 	"path/filepath"
@@ -11,6 +13,7 @@ import (
 
 type Directory struct {
 	root     *directory.TreeNode
+	layout   *valueobject.Layout
 	walkErrs []error
 }
 
@@ -22,6 +25,12 @@ func NewDirectory(paths []string) *Directory {
 }
 
 func (d *Directory) ArchDesignPattern() arch.DesignPattern {
+	if d.layout != nil {
+		if d.hasLayoutDomain() {
+			return arch.DesignPatternHexagon
+		}
+		return arch.DesignPatternPlain
+	}
 	if d.isHexagon() {
 		return arch.DesignPatternHexagon
 	}
@@ -51,6 +60,12 @@ func (d *Directory) isHexagon() bool {
 }
 
 func (d *Directory) DomainDir() (string, error) {
+	if d.layout != nil {
+		if d.hasLayoutDomain() {
+			return d.layoutDomainDir(), nil
+		}
+		return "", fmt.Errorf("layout domain %s not found", d.layout.Domain)
+	}
 	if d.isHexagon() {
 		return path.Join(d.root.Name,
 			string(arch.HexagonDirectoryInternal),
@@ -60,11 +75,36 @@ func (d *Directory) DomainDir() (string, error) {
 	return "", errors.New("invalid arch")
 }
 
+func (d *Directory) layoutBase() string {
+	if d.layout.Module != "" {
+		return d.layout.Module
+	}
+	return d.root.Name
+}
+
+func (d *Directory) layoutDomainDir() string {
+	return path.Join(d.layoutBase(), d.layout.Domain)
+}
+
+func (d *Directory) hasLayoutDomain() bool {
+	domainDir := d.layoutDomainDir()
+	if d.isRoot(domainDir) || d.isUnder(domainDir) {
+		return true
+	}
+	if !strings.HasPrefix(domainDir, d.root.Name+"/") {
+		return false
+	}
+	return d.root.GetNode(strings.TrimPrefix(domainDir, d.root.Name+"/")) != nil
+}
+
 func (d *Directory) RootDir() string {
 	return d.root.Name
 }
 
 func (d *Directory) HexagonDirectory(dir string) arch.HexagonDirectory {
+	if d.layout != nil {
+		return d.layoutDirectory(dir)
+	}
 	if arch.HexagonDirectoryDomain == arch.HexagonDirectory(dir) {
 		return arch.HexagonDirectoryDomain
 	}
@@ -86,14 +126,82 @@ func (d *Directory) HexagonDirectory(dir string) arch.HexagonDirectory {
 	return arch.HexagonDirectoryInvalid
 }
 
+func (d *Directory) layoutDirectory(dir string) arch.HexagonDirectory {
+	full := path.Join(path.Dir(d.layout.Domain), dir)
+	if full == d.layout.Domain {
+		return arch.HexagonDirectoryDomain
+	}
+	return d.layout.Role(full)
+}
+
+func (d *Directory) Layer(dir string) arch.HexagonDirectory {
+	if !d.isValid(dir) || d.isRoot(dir) {
+		return arch.HexagonDirectoryInvalid
+	}
+
+	if d.layout != nil {
+		if layer := d.layoutLayer(strings.TrimPrefix(dir, d.layoutBase()+"/")); layer != arch.HexagonDirectoryInvalid {
+			return layer
+		}
+	}
+
+	parts := strings.Split(strings.TrimPrefix(dir, d.root.Name+"/"), "/")
+	switch arch.HexagonDirectory(parts[0]) {
+	case arch.HexagonDirectoryCmd, arch.HexagonDirectoryPkg:
+		return arch.HexagonDirectory(parts[0])
+	case arch.HexagonDirectoryInternal:
+		if len(parts) > 1 {
+			switch arch.HexagonDirectory(parts[1]) {
+			case arch.HexagonDirectoryDomain, arch.HexagonDirectoryApplication,
+				arch.HexagonDirectoryInfrastructure, arch.HexagonDirectoryInterfaces:
+				return arch.HexagonDirectory(parts[1])
+			}
+		}
+		return arch.HexagonDirectoryInternal
+	}
+
+	return arch.HexagonDirectoryInvalid
+}
+
+func (d *Directory) layoutLayer(dir string) arch.HexagonDirectory {
+	for p := dir; p != "." && p != "/"; p = path.Dir(p) {
+		if p == d.layout.Domain {
+			return arch.HexagonDirectoryDomain
+		}
+		for _, layer := range []arch.HexagonDirectory{
+			arch.HexagonDirectoryApplication,
+			arch.HexagonDirectoryInfrastructure,
+			arch.HexagonDirectoryInterfaces,
+		} {
+			if d.layout.Match(layer, p) {
+				return layer
+			}
+		}
+	}
+
+	return arch.HexagonDirectoryInvalid
+}
+
+// WalkDir walks the tree under dir, passing the directories relative to the parent of dir.
+// A dir containing the root walks the whole tree.
 func (d *Directory) WalkDir(dir string, cb func(string, []arch.ObjIdentifier) error) {
-	targetDir, err := d.getTargetDir(dir)
-	if err != nil {
-		d.walkErrs = append(d.walkErrs, err)
-		return
+	var node *directory.TreeNode
+	if d.isRoot(dir) || d.isUnder(dir) {
+		node = &directory.TreeNode{
+			Name:     strings.TrimPrefix(d.root.Name, path.Dir(dir)+"/"),
+			Value:    d.root.Value,
+			Children: d.root.Children,
+		}
+	} else {
+		targetDir, err := d.getTargetDir(dir)
+		if err != nil {
+			d.walkErrs = append(d.walkErrs, err)
+			return
+		}
+		node = d.root.GetNode(targetDir)
 	}
 
-	if node := d.root.GetNode(targetDir); node != nil {
+	if node != nil {
 		directory.Walk(node, func(dir string, val any) {
 			if val != nil {
 				if err := cb(dir, val.([]arch.ObjIdentifier)); err != nil {
@@ -169,3 +277,8 @@ func (d *Directory) GetObjs(targetDir string) ([]arch.ObjIdentifier, error) {
 func (d *Directory) isRoot(dir string) bool {
 	return dir == d.root.Name
 }
+
+// isUnder tells whether the root lies below dir.
+func (d *Directory) isUnder(dir string) bool {
+	return strings.HasPrefix(d.root.Name, dir+"/")
+}
@@ -3,8 +3,10 @@ package entity
 import (
 	"errors"
 	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
 	"github.com/dddplayer/dp/pkg/datastructure/directory"
 	"reflect"
+	"sort"
 	"testing"
 )
 
@@ -355,3 +357,152 @@ func TestDirectory_WalkDir(t *testing.T) {
 		t.Errorf("Expected 4 error, got %d", len(d.WalkErrs()))
 	}
 }
+
+func TestDirectory_Layer(t *testing.T) {
+	d := NewDirectory([]string{
+		"test/cmd/cla1",
+		"test/pkg/cla2",
+		"test/internal/domain/order/cla3",
+		"test/internal/application/cla4",
+		"test/internal/infrastructure/cla5",
+		"test/internal/interfaces/cla6",
+		"test/internal/tool/cla7",
+	})
+
+	tests := map[string]arch.HexagonDirectory{
+		"test/cmd":                     arch.HexagonDirectoryCmd,
+		"test/pkg/util":                arch.HexagonDirectoryPkg,
+		"test/internal/domain/order":   arch.HexagonDirectoryDomain,
+		"test/internal/application":    arch.HexagonDirectoryApplication,
+		"test/internal/infrastructure": arch.HexagonDirectoryInfrastructure,
+		"test/internal/interfaces/cmd": arch.HexagonDirectoryInterfaces,
+		"test/internal/tool":           arch.HexagonDirectoryInternal,
+		"test":                         arch.HexagonDirectoryInvalid,
+		"other/cmd":                    arch.HexagonDirectoryInvalid,
+	}
+	for dir, expected := range tests {
+		if res := d.Layer(dir); res != expected {
+			t.Errorf("Layer(%q) = %s, expected %s", dir, res, expected)
+		}
+	}
+}
+
+func TestDirectory_Layout(t *testing.T) {
+	d := NewDirectory([]string{
+		"test/cmd/cla1",
+		"test/internal/core/order/model/cla2",
+		"test/internal/core/order/vo/cla3",
+		"test/internal/app/cla4",
+		"test/internal/infra/db/cla5",
+	})
+	d.layout = &valueobject.Layout{
+		Domain: "internal/core",
+		Roles: map[arch.HexagonDirectory][]string{
+			arch.HexagonDirectoryAggregate:      {"internal/core/*"},
+			arch.HexagonDirectoryEntity:         {"internal/core/*/model"},
+			arch.HexagonDirectoryValueObject:    {"internal/core/*/vo"},
+			arch.HexagonDirectoryApplication:    {"internal/app"},
+			arch.HexagonDirectoryInfrastructure: {"internal/infra"},
+		},
+	}
+
+	if d.ArchDesignPattern() != arch.DesignPatternHexagon {
+		t.Errorf("Expected hexagon design pattern with layout, but got %s", d.ArchDesignPattern())
+	}
+	if domainDir, err := d.DomainDir(); err != nil || domainDir != "test/internal/core" {
+		t.Errorf("Unexpected domain dir %q, error: %v", domainDir, err)
+	}
+
+	roles := map[string]arch.HexagonDirectory{
+		"core":             arch.HexagonDirectoryDomain,
+		"core/order":       arch.HexagonDirectoryAggregate,
+		"core/order/model": arch.HexagonDirectoryEntity,
+		"core/order/vo":    arch.HexagonDirectoryValueObject,
+		"core/order/other": arch.HexagonDirectoryInvalid,
+	}
+	for dir, expected := range roles {
+		if res := d.HexagonDirectory(dir); res != expected {
+			t.Errorf("HexagonDirectory(%q) = %s, expected %s", dir, res, expected)
+		}
+	}
+
+	layers := map[string]arch.HexagonDirectory{
+		"test/cmd":                    arch.HexagonDirectoryCmd,
+		"test/internal/core/order/vo": arch.HexagonDirectoryDomain,
+		"test/internal/app":           arch.HexagonDirectoryApplication,
+		"test/internal/infra/db":      arch.HexagonDirectoryInfrastructure,
+		"test/internal/other":         arch.HexagonDirectoryInternal,
+	}
+	for dir, expected := range layers {
+		if res := d.Layer(dir); res != expected {
+			t.Errorf("Layer(%q) = %s, expected %s", dir, res, expected)
+		}
+	}
+
+	d.layout.Domain = "internal/domain"
+	if d.ArchDesignPattern() != arch.DesignPatternPlain {
+		t.Errorf("Expected plain design pattern for missing layout domain, but got %s", d.ArchDesignPattern())
+	}
+	if _, err := d.DomainDir(); err == nil {
+		t.Errorf("Expected error for missing layout domain")
+	}
+}
+
+func TestDirectory_ScopedLayout(t *testing.T) {
+	d := NewDirectory([]string{
+		"test/internal/core/order/model/cla1",
+		"test/internal/core/order/vo/cla2",
+		"test/internal/core/payment/vo/cla3",
+	})
+	d.layout = &valueobject.Layout{
+		Module: "test",
+		Domain: "internal/core",
+		Roles: map[arch.HexagonDirectory][]string{
+			arch.HexagonDirectoryAggregate:   {"internal/core/*"},
+			arch.HexagonDirectoryEntity:      {"internal/core/*/model"},
+			arch.HexagonDirectoryValueObject: {"internal/core/*/vo"},
+		},
+	}
+
+	if d.ArchDesignPattern() != arch.DesignPatternHexagon {
+		t.Errorf("Expected hexagon design pattern with scoped layout, but got %s", d.ArchDesignPattern())
+	}
+	if domainDir, err := d.DomainDir(); err != nil || domainDir != "test/internal/core" {
+		t.Errorf("Unexpected domain dir %q, error: %v", domainDir, err)
+	}
+	if res := d.Layer("test/internal/core/order/model"); res != arch.HexagonDirectoryDomain {
+		t.Errorf("Expected domain layer, but got %s", res)
+	}
+
+	var dirs []string
+	d.WalkDir("test/internal/core", func(dir string, _ []arch.ObjIdentifier) error {
+		dirs = append(dirs, dir)
+		return nil
+	})
+	sort.Strings(dirs)
+	expected := []string{"core", "core/order", "core/order/model", "core/order/vo", "core/payment", "core/payment/vo"}
+	if !reflect.DeepEqual(dirs, expected) {
+		t.Errorf("Expected walked dirs %v, but got %v", expected, dirs)
+	}
+
+	single := NewDirectory([]string{
+		"test/internal/core/order/model/cla1",
+		"test/internal/core/order/vo/cla2",
+	})
+	single.layout = d.layout
+	dirs = nil
+	single.WalkDir("test/internal/core", func(dir string, _ []arch.ObjIdentifier) error {
+		dirs = append(dirs, dir)
+		return nil
+	})
+	sort.Strings(dirs)
+	expected = []string{"core/order", "core/order/model", "core/order/vo"}
+	if single.ArchDesignPattern() != arch.DesignPatternHexagon || !reflect.DeepEqual(dirs, expected) {
+		t.Errorf("Expected walked dirs %v of a single aggregate, but got %v", expected, dirs)
+	}
+
+	d.layout.Module = "other"
+	if d.ArchDesignPattern() != arch.DesignPatternPlain {
+		t.Errorf("Expected plain design pattern for layout of another module, but got %s", d.ArchDesignPattern())
+	}
+}
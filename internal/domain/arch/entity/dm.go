@@ -45,13 +45,16 @@ func (dm *DomainModel) TacticGrouping() error {
 			name := path.Base(dir)
 			ag := valueobject.NewAggregateGroup(&valueobject.Aggregate{
 				Name: name,
-			}, path.Join(domainDir, name))
+			}, path.Join(path.Dir(domainDir), dir))
 			dm.aggregates = append(dm.aggregates, ag)
 			objs, err := dm.repo.GetObjects(objIds)
 			if err != nil {
 				return err
 			}
-			ag.AppendObjects(objs...)
+			entities, vos, rest := partitionByRole(objs)
+			ag.AppendObjects(rest...)
+			dm.appendComponents(ag, valueobject.EntityComponent, entities, false)
+			dm.appendComponents(ag, valueobject.VOComponent, vos, false)
 		case arch.HexagonDirectoryEntity:
 			err := dm.processObjects(objIds, valueobject.EntityComponent, dir)
 			if err != nil {
@@ -62,6 +65,16 @@ func (dm *DomainModel) TacticGrouping() error {
 			if err != nil {
 				return err
 			}
+		case arch.HexagonDirectoryRepository:
+			err := dm.processObjects(objIds, valueobject.RepositoryComponent, dir)
+			if err != nil {
+				return err
+			}
+		case arch.HexagonDirectoryFactory:
+			err := dm.processObjects(objIds, valueobject.FactoryComponent, dir)
+			if err != nil {
+				return err
+			}
 		case arch.HexagonDirectoryInvalid:
 			err = errors.New("invalid hexagon domain directory")
 			return err
@@ -84,11 +97,19 @@ func (dm *DomainModel) StrategicGrouping() error {
 			return nil
 		case arch.HexagonDirectoryAggregate:
 			name := path.Base(dir)
-			dm.aggregates = append(dm.aggregates, valueobject.NewAggregateGroup(
+			ag := valueobject.NewAggregateGroup(
 				&valueobject.Aggregate{
 					Name: name,
 				},
-				path.Join(domainDir, name)))
+				path.Join(path.Dir(domainDir), dir))
+			dm.aggregates = append(dm.aggregates, ag)
+			classes, err := dm.getClass(objIds)
+			if err != nil {
+				return err
+			}
+			entities, vos, _ := partitionByRole(classes)
+			dm.appendComponents(ag, valueobject.EntityComponent, entities, false)
+			dm.appendComponents(ag, valueobject.VOComponent, vos, false)
 		case arch.HexagonDirectoryEntity:
 			err := dm.processClasses(objIds, valueobject.EntityComponent, dir)
 			if err != nil {
@@ -100,9 +121,15 @@ func (dm *DomainModel) StrategicGrouping() error {
 				return err
 			}
 		case arch.HexagonDirectoryRepository:
-			//todo
+			err := dm.processObjects(objIds, valueobject.RepositoryComponent, dir)
+			if err != nil {
+				return err
+			}
 		case arch.HexagonDirectoryFactory:
-			//todo
+			err := dm.processObjects(objIds, valueobject.FactoryComponent, dir)
+			if err != nil {
+				return err
+			}
 		case arch.HexagonDirectoryInvalid:
 			err = errors.New("invalid hexagon domain directory")
 			return err
@@ -118,7 +145,7 @@ func (dm *DomainModel) StrategicGrouping() error {
 }
 
 func (dm *DomainModel) processObjects(objIds []arch.ObjIdentifier, groupType valueobject.ComponentType, dir string) error {
-	ag := dm.FindAggregateGroup(path.Base(path.Dir(dir)))
+	ag := dm.FindAggregateGroup(dm.aggregateName(dir))
 	objs, err := dm.repo.GetObjects(objIds)
 	if err != nil {
 		return err
@@ -128,7 +155,7 @@ func (dm *DomainModel) processObjects(objIds []arch.ObjIdentifier, groupType val
 }
 
 func (dm *DomainModel) processClasses(objIds []arch.ObjIdentifier, groupType valueobject.ComponentType, dir string) error {
-	ag := dm.FindAggregateGroup(path.Base(path.Dir(dir)))
+	ag := dm.FindAggregateGroup(dm.aggregateName(dir))
 	classes, err := dm.getClass(objIds)
 	if err != nil {
 		return err
@@ -137,15 +164,97 @@ func (dm *DomainModel) processClasses(objIds []arch.ObjIdentifier, groupType val
 	return dm.processComponent(ag, groupType, classes)
 }
 
+func (dm *DomainModel) aggregateName(dir string) string {
+	for p := path.Dir(dir); p != "." && p != "/"; p = path.Dir(p) {
+		if dm.directory.HexagonDirectory(p) == arch.HexagonDirectoryAggregate {
+			return path.Base(p)
+		}
+	}
+	return path.Base(path.Dir(dir))
+}
+
 func (dm *DomainModel) processComponent(ag *valueobject.AggregateGroup, groupType valueobject.ComponentType, objects []arch.Object) error {
+	if ag == nil {
+		return nil
+	}
+	entities, vos, rest := partitionByRole(objects)
+	switch groupType {
+	case valueobject.EntityComponent:
+		dm.appendComponents(ag, valueobject.EntityComponent, append(entities, rest...), true)
+		dm.appendComponents(ag, valueobject.VOComponent, vos, false)
+	case valueobject.VOComponent:
+		dm.appendComponents(ag, valueobject.VOComponent, append(vos, rest...), true)
+		dm.appendComponents(ag, valueobject.EntityComponent, entities, false)
+	case valueobject.RepositoryComponent, valueobject.FactoryComponent:
+		dm.appendComponents(ag, groupType, rest, true)
+		dm.appendComponents(ag, valueobject.EntityComponent, entities, false)
+		dm.appendComponents(ag, valueobject.VOComponent, vos, false)
+	}
+
+	return nil
+}
+
+func (dm *DomainModel) appendComponents(ag *valueobject.AggregateGroup, groupType valueobject.ComponentType, objects []arch.Object, always bool) {
+	if len(objects) == 0 && !always {
+		return
+	}
+	for _, sg := range ag.SubGroups() {
+		if sg.Name() == string(groupType) {
+			sg.AppendObjects(objects...)
+			return
+		}
+	}
+
 	switch groupType {
 	case valueobject.EntityComponent:
 		ag.AppendGroups(valueobject.NewEntityGroup(ag.Domain(), objects...))
 	case valueobject.VOComponent:
 		ag.AppendGroups(valueobject.NewVOGroup(ag.Domain(), objects...))
+	case valueobject.RepositoryComponent:
+		ag.AppendGroups(valueobject.NewRepositoryGroup(ag.Domain(), objects...))
+	case valueobject.FactoryComponent:
+		ag.AppendGroups(valueobject.NewFactoryGroup(ag.Domain(), objects...))
 	}
+}
 
-	return nil
+// partitionByRole moves classes annotated as entities or value objects, together
+// with their attributes and methods, out of the folder based classification.
+func partitionByRole(objs []arch.Object) (entities, vos, rest []arch.Object) {
+	targets := make(map[string]valueobject.ComponentType)
+	for _, o := range objs {
+		cla, ok := o.(*valueobject.Class)
+		if !ok {
+			continue
+		}
+		var target valueobject.ComponentType
+		switch {
+		case cla.HasRole(arch.DomainRoleAggregateRoot), cla.HasRole(arch.DomainRoleEntity):
+			target = valueobject.EntityComponent
+		case cla.HasRole(arch.DomainRoleValueObject):
+			target = valueobject.VOComponent
+		default:
+			continue
+		}
+		targets[cla.Identifier().ID()] = target
+		for _, a := range cla.Attributes() {
+			targets[a.ID()] = target
+		}
+		for _, m := range cla.Methods() {
+			targets[m.ID()] = target
+		}
+	}
+
+	for _, o := range objs {
+		switch targets[o.Identifier().ID()] {
+		case valueobject.EntityComponent:
+			entities = append(entities, o)
+		case valueobject.VOComponent:
+			vos = append(vos, o)
+		default:
+			rest = append(rest, o)
+		}
+	}
+	return entities, vos, rest
 }
 
 func (dm *DomainModel) getClass(objIds []arch.ObjIdentifier) ([]arch.Object, error) {
@@ -185,6 +294,18 @@ func (dm *DomainModel) addVOToNode(g *Diagram, pid string, vo *valueobject.Value
 	return dm.addDomainClass(g, vo.DomainClass)
 }
 
+func (dm *DomainModel) addComponentToNode(g *Diagram, pid string, c arch.DomainObj, methods []*valueobject.DomainFunction) error {
+	if err := g.AddObjTo(c, pid, arch.RelationTypeAggregation); err != nil {
+		return err
+	}
+	for _, m := range methods {
+		if err := g.AddObjTo(m, c.Identifier().ID(), arch.RelationTypeBehavior); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (dm *DomainModel) addNodeToAggregate(g *Diagram, key string, a *valueobject.Aggregate) error {
 	return g.AddStringTo(key, a.Identifier().ID(), arch.RelationTypeAggregationRoot)
 }
@@ -223,20 +344,27 @@ func (dm *DomainModel) buildDomainComponents(g *Diagram, group valueobject.Domai
 		return err
 	}
 
-	if err := dm.buildAbstractComponent(g, group, pid, valueobject.FunctionComponent); err != nil {
-		return err
-	}
-
 	_, isEntityGroup := group.(*valueobject.EntityGroup)
 	_, isVOGroup := group.(*valueobject.VOGroup)
-	if !isEntityGroup && !isVOGroup {
+	_, isRepositoryGroup := group.(*valueobject.RepositoryGroup)
+	_, isFactoryGroup := group.(*valueobject.FactoryGroup)
+
+	if !isFactoryGroup {
+		if err := dm.buildAbstractComponent(g, group, pid, valueobject.FunctionComponent); err != nil {
+			return err
+		}
+	}
+
+	if !isEntityGroup && !isVOGroup && !isRepositoryGroup && !isFactoryGroup {
 		if err := dm.buildComponents(g, group, pid, valueobject.ClassComponent); err != nil {
 			return err
 		}
 	}
 
-	if err := dm.buildComponents(g, group, pid, valueobject.InterfaceComponent); err != nil {
-		return err
+	if !isRepositoryGroup && !isFactoryGroup {
+		if err := dm.buildComponents(g, group, pid, valueobject.InterfaceComponent); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -1287,3 +1287,78 @@ func TestGeneralModel_TacticGrouping_Error(t *testing.T) {
 		t.Errorf("Expected an error, but got nil")
 	}
 }
+
+func TestDomainModel_TacticGroupingWithAnnotations(t *testing.T) {
+	orderDir := "test/internal/domain/order"
+	voDir := "test/internal/domain/order/valueobject"
+
+	purchaseObj := newMockMethodWithName(orderDir, "Purchase", 1)
+	purchaseObj.roles = []arch.DomainRole{arch.DomainRoleAggregateRoot}
+	pay := newMockMethodWithName(orderDir, "Purchase.Pay", 2)
+	purchase := valueobject.NewClass(purchaseObj, nil, []arch.ObjIdentifier{pay.Identifier()})
+
+	moneyObj := newMockMethodWithName(orderDir, "Money", 3)
+	moneyObj.roles = []arch.DomainRole{arch.DomainRoleValueObject}
+	money := valueobject.NewClass(moneyObj, nil, nil)
+
+	calc := newMockClassWithName(orderDir, "Calc")
+
+	itemObj := newMockMethodWithName(voDir, "Item", 4)
+	itemObj.roles = []arch.DomainRole{arch.DomainRoleEntity}
+	item := valueobject.NewClass(itemObj, nil, nil)
+	price := newMockClassWithName(voDir, "Price")
+
+	mockRepo := &MockObjectRepository{
+		objects: make(map[string]arch.Object),
+		idents:  []arch.ObjIdentifier{},
+	}
+	for _, o := range []arch.Object{
+		valueobject.NewFunction(newMockMethodWithName("test/cmd", "main", 1), nil),
+		valueobject.NewFunction(newMockMethodWithName("test/pkg/util", "Util", 1), nil),
+		purchase, valueobject.NewFunction(pay, purchase.Identifier()), money, calc, item, price,
+	} {
+		_ = mockRepo.Insert(o)
+	}
+
+	a := &Arch{
+		CodeHandler: &valueobject.CodeHandler{
+			ObjRepo: mockRepo,
+			RelRepo: &MockRelationRepository{},
+			Scope:   "test",
+		},
+	}
+	ags, err := a.Aggregates()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if len(ags) != 1 {
+		t.Fatalf("Expected 1 aggregate, but got %d", len(ags))
+	}
+
+	root, err := ags[0].Aggregate()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if root.Entity == nil || root.Entity.Identifier().Name() != "Purchase" {
+		t.Fatalf("Expected annotated Purchase as aggregate root, but got %v", root.Entity)
+	}
+	if len(root.Entity.Methods) != 1 {
+		t.Errorf("Expected Purchase to keep its method, but got %d", len(root.Entity.Methods))
+	}
+
+	groups := make(map[string][]string)
+	for _, sg := range ags[0].SubGroups() {
+		for _, cla := range sg.Classes() {
+			groups[sg.Name()] = append(groups[sg.Name()], cla.Identifier().Name())
+		}
+	}
+	if len(ags[0].SubGroups()) != 2 {
+		t.Errorf("Expected entity and valueobject groups, but got %d groups", len(ags[0].SubGroups()))
+	}
+	if len(groups["entity"]) != 2 || len(groups["valueobject"]) != 2 {
+		t.Errorf("Unexpected grouping %v", groups)
+	}
+	if classes := ags[0].Classes(); len(classes) != 1 || classes[0].Identifier().Name() != "Calc" {
+		t.Errorf("Expected only Calc left in the aggregate package, but got %v", classes)
+	}
+}
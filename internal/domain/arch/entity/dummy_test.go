@@ -40,6 +40,7 @@ func (m *MockObjIdentifier) NameSeparatorLength() int {
 type MockDependenceRelation struct {
 	from      arch.Object
 	dependsOn arch.Object
+	algorithm string
 }
 
 func (rel *MockDependenceRelation) Type() arch.RelationType {
@@ -54,6 +55,10 @@ func (rel *MockDependenceRelation) DependsOn() arch.Object {
 	return rel.dependsOn
 }
 
+func (rel *MockDependenceRelation) Algorithm() string {
+	return rel.algorithm
+}
+
 type MockCompositionRelation struct {
 	from  arch.Object
 	child arch.Object
@@ -131,12 +136,31 @@ func (rel *MockAssociationRelation) AssociationType() arch.RelationType {
 	return rel.associationType
 }
 
+type MockUsageRelation struct {
+	relType arch.RelationType
+	from    arch.Object
+	used    arch.Object
+}
+
+func (rel *MockUsageRelation) Type() arch.RelationType {
+	return rel.relType
+}
+
+func (rel *MockUsageRelation) From() arch.Object {
+	return rel.from
+}
+
+func (rel *MockUsageRelation) Used() arch.Object {
+	return rel.used
+}
+
 type MockObject struct {
 	id                     arch.ObjIdentifier
 	position               arch.Position
 	name                   string
 	dir                    string
 	NameSeparatorLengthVal int
+	roles                  []arch.DomainRole
 }
 
 // ID returns the identifier of the object
@@ -166,6 +190,19 @@ func (mo MockObject) NameSeparatorLength() int {
 	return mo.NameSeparatorLengthVal
 }
 
+func (mo MockObject) Roles() []arch.DomainRole {
+	return mo.roles
+}
+
+func (mo MockObject) HasRole(role arch.DomainRole) bool {
+	for _, r := range mo.roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
 type MockPosition struct {
 	FilenameVal string
 	OffsetVal   int
@@ -205,6 +242,18 @@ func (mrr *MockRelationRepository) Insert(rel arch.Relation) error {
 	return nil
 }
 
+func (mrr *MockRelationRepository) Delete(match func(rel arch.Relation) bool) int {
+	var kept []arch.Relation
+	for _, rel := range mrr.relations {
+		if !match(rel) {
+			kept = append(kept, rel)
+		}
+	}
+	deleted := len(mrr.relations) - len(kept)
+	mrr.relations = kept
+	return deleted
+}
+
 func (mrr *MockRelationRepository) Walk(walker func(rel arch.Relation) error) {
 	for _, rel := range mrr.relations {
 		if err := walker(rel); err != nil {
@@ -271,6 +320,28 @@ func (mor *MockObjectRepository) Insert(obj arch.Object) error {
 	return nil
 }
 
+func (mor *MockObjectRepository) Update(obj arch.Object) error {
+	if _, ok := mor.objects[obj.Identifier().ID()]; !ok {
+		return errors.New("object not found in mock repository")
+	}
+	mor.objects[obj.Identifier().ID()] = obj
+	return nil
+}
+
+func (mor *MockObjectRepository) Delete(id arch.ObjIdentifier) error {
+	if _, ok := mor.objects[id.ID()]; !ok {
+		return errors.New("object not found in mock repository")
+	}
+	delete(mor.objects, id.ID())
+	for i, ident := range mor.idents {
+		if ident.ID() == id.ID() {
+			mor.idents = append(mor.idents[:i], mor.idents[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
 func (mor *MockObjectRepository) Walk(walker func(obj arch.Object) error) {
 	for _, obj := range mor.objects {
 		if err := walker(obj); err != nil {
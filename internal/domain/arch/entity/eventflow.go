@@ -0,0 +1,248 @@
+package entity
+
+import (
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	eventSuffix   = "Event"
+	commandSuffix = "Command"
+)
+
+var messageMarkers = map[string]valueobject.MessageKind{
+	"Event":       valueobject.MessageKindEvent,
+	"DomainEvent": valueobject.MessageKindEvent,
+	"Command":     valueobject.MessageKindCommand,
+}
+
+type EventFlowDetector struct {
+	objRepo    repository.ObjectRepository
+	relRepo    repository.RelationRepository
+	aggregates []*valueobject.AggregateGroup
+}
+
+func NewEventFlowDetector(objRepo repository.ObjectRepository, relRepo repository.RelationRepository,
+	ags []*valueobject.AggregateGroup) *EventFlowDetector {
+	return &EventFlowDetector{
+		objRepo:    objRepo,
+		relRepo:    relRepo,
+		aggregates: ags,
+	}
+}
+
+func (ed *EventFlowDetector) Flows() []*valueobject.EventFlow {
+	markers := ed.markers()
+
+	flows := make(map[string]*valueobject.EventFlow)
+	ed.objRepo.Walk(func(obj arch.Object) error {
+		cla, ok := obj.(*valueobject.Class)
+		if !ok {
+			return nil
+		}
+		id := cla.Identifier()
+		if kind, ok := messageKind(cla, markers[id.ID()]); ok {
+			flows[id.ID()] = &valueobject.EventFlow{
+				Kind:    kind,
+				Message: cla,
+				Context: ed.context(id.Dir()),
+			}
+		}
+		return nil
+	})
+	if len(flows) == 0 {
+		return nil
+	}
+
+	constructors := make(map[string]*valueobject.EventFlow)
+	var calls []arch.DependenceRelation
+	ed.relRepo.Walk(func(rel arch.Relation) error {
+		switch r := rel.(type) {
+		case arch.UsageRelation:
+			msgId := r.Used().Identifier().ID()
+			f := flows[msgId]
+			if f == nil || ownerID(r.From().Identifier()) == msgId {
+				return nil
+			}
+			ep := &valueobject.EventEndpoint{Function: r.From(), Site: r.Used().Position()}
+			switch r.Type() {
+			case arch.RelationTypeInstantiation:
+				if r.From().Identifier().Name() == "New"+f.Message.Identifier().Name() {
+					constructors[r.From().Identifier().ID()] = f
+					return nil
+				}
+				f.Publishers = appendEndpoint(f.Publishers, ep)
+			case arch.RelationTypeParameter:
+				if _, ok := ed.objRepo.Find(r.From().Identifier()).(*valueobject.InterfaceMethod); ok {
+					return nil
+				}
+				f.Subscribers = appendEndpoint(f.Subscribers, ep)
+			}
+		case arch.DependenceRelation:
+			calls = append(calls, r)
+		}
+		return nil
+	})
+
+	for _, c := range calls {
+		if f := constructors[c.DependsOn().Identifier().ID()]; f != nil {
+			f.Publishers = appendEndpoint(f.Publishers,
+				&valueobject.EventEndpoint{Function: c.From(), Site: c.From().Position()})
+		}
+	}
+
+	var result []*valueobject.EventFlow
+	for _, f := range flows {
+		sortEndpoints(f.Publishers)
+		sortEndpoints(f.Subscribers)
+		result = append(result, f)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Context != result[j].Context {
+			return result[i].Context < result[j].Context
+		}
+		return result[i].Message.Identifier().ID() < result[j].Message.Identifier().ID()
+	})
+
+	return result
+}
+
+func (ed *EventFlowDetector) markers() map[string]valueobject.MessageKind {
+	markers := make(map[string]valueobject.MessageKind)
+	ed.relRepo.Walk(func(rel arch.Relation) error {
+		if impl, ok := rel.(arch.ImplementationRelation); ok {
+			for _, ifc := range impl.Implements() {
+				if kind, ok := messageMarkers[ifc.Identifier().Name()]; ok {
+					markers[impl.From().Identifier().ID()] = kind
+				}
+			}
+		}
+		return nil
+	})
+	return markers
+}
+
+func (ed *EventFlowDetector) context(dir string) string {
+	for _, ag := range ed.aggregates {
+		if dir == ag.Domain() || strings.HasPrefix(dir, ag.Domain()+"/") {
+			return ag.Name()
+		}
+	}
+	return path.Base(dir)
+}
+
+func (ed *EventFlowDetector) buildDiagram(name string, flows []*valueobject.EventFlow) (*Diagram, error) {
+	g, err := NewDiagram(name, arch.PlainDiagram)
+	if err != nil {
+		return nil, err
+	}
+
+	contexts := make(map[string]string)
+	contextKey := func(ctx string) (string, error) {
+		if key, ok := contexts[ctx]; ok {
+			return key, nil
+		}
+		key := fmt.Sprintf("context: %s", ctx)
+		if err := g.AddStringTo(key, g.Name(), arch.RelationTypeAggregationRoot); err != nil {
+			return "", err
+		}
+		contexts[ctx] = key
+		return key, nil
+	}
+
+	addEndpoint := func(ep *valueobject.EventEndpoint) (string, error) {
+		fn := ed.objRepo.Find(ep.Function.Identifier())
+		if fn == nil {
+			fn = ep.Function
+		}
+		id := fn.Identifier().ID()
+		if g.FindNodeByKey(id) != nil {
+			return id, nil
+		}
+		key, err := contextKey(ed.context(fn.Identifier().Dir()))
+		if err != nil {
+			return "", err
+		}
+		return id, g.AddObjTo(fn, key, arch.RelationTypeAggregation)
+	}
+
+	for _, f := range flows {
+		key, err := contextKey(f.Context)
+		if err != nil {
+			return nil, err
+		}
+
+		cla := valueobject.NewDomainClass(f.Message, f.Message.Identifier().Dir(), nil, nil)
+		var msg arch.Object = valueobject.NewDomainEvent(cla)
+		if f.Kind == valueobject.MessageKindCommand {
+			msg = valueobject.NewDomainCommand(cla)
+		}
+		if err := g.AddObjTo(msg, key, arch.RelationTypeAggregation); err != nil {
+			return nil, err
+		}
+		msgId := msg.Identifier().ID()
+
+		for _, ep := range f.Publishers {
+			id, err := addEndpoint(ep)
+			if err != nil {
+				return nil, err
+			}
+			if err := g.AddEdge(id, msgId, arch.RelationTypeDependency,
+				valueobject.NewRelationPos(ep.Site, msg.Position())); err != nil {
+				return nil, err
+			}
+		}
+		for _, ep := range f.Subscribers {
+			id, err := addEndpoint(ep)
+			if err != nil {
+				return nil, err
+			}
+			if err := g.AddEdge(msgId, id, arch.RelationTypeDependency,
+				valueobject.NewRelationPos(msg.Position(), ep.Site)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return g, nil
+}
+
+func messageKind(cla *valueobject.Class, marker valueobject.MessageKind) (valueobject.MessageKind, bool) {
+	switch {
+	case cla.HasRole(arch.DomainRoleDomainEvent):
+		return valueobject.MessageKindEvent, true
+	case cla.HasRole(arch.DomainRoleCommand):
+		return valueobject.MessageKindCommand, true
+	case marker != "":
+		return marker, true
+	}
+
+	name := cla.Identifier().Name()
+	switch {
+	case strings.HasSuffix(name, eventSuffix) && name != eventSuffix:
+		return valueobject.MessageKindEvent, true
+	case strings.HasSuffix(name, commandSuffix) && name != commandSuffix:
+		return valueobject.MessageKindCommand, true
+	}
+	return "", false
+}
+
+func appendEndpoint(eps []*valueobject.EventEndpoint, ep *valueobject.EventEndpoint) []*valueobject.EventEndpoint {
+	for _, e := range eps {
+		if e.Function.Identifier().ID() == ep.Function.Identifier().ID() {
+			return eps
+		}
+	}
+	return append(eps, ep)
+}
+
+func sortEndpoints(eps []*valueobject.EventEndpoint) {
+	sort.Slice(eps, func(i, j int) bool {
+		return eps[i].Function.Identifier().ID() < eps[j].Function.Identifier().ID()
+	})
+}
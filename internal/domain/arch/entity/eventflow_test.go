@@ -0,0 +1,182 @@
+package entity
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"path"
+	"testing"
+)
+
+func newMockFuncObj(dir, name string, line int) *MockObject {
+	return &MockObject{
+		id: &MockObjIdentifier{
+			id:   path.Join(dir, name),
+			name: name,
+			dir:  dir},
+		name:     name,
+		position: &MockPosition{FilenameVal: "service.go", LineVal: line},
+	}
+}
+
+func newMockEventFlowRepos() (*MockObjectRepository, *MockRelationRepository) {
+	objRepo := &MockObjectRepository{
+		objects: make(map[string]arch.Object),
+		idents:  []arch.ObjIdentifier{},
+	}
+	relRepo := &MockRelationRepository{}
+
+	dir := "test/domain/order"
+	placed := newMockClassWithName(dir, "OrderPlacedEvent")
+	paid := newMockClassWithName(dir, "Paid")
+	order := newMockClassWithName(dir, "Order")
+	shipObj := &MockObject{
+		id:       &MockObjIdentifier{id: path.Join(dir, "Ship"), name: "Ship", dir: dir},
+		name:     "Ship",
+		position: &MockPosition{FilenameVal: "ship.go", LineVal: 3},
+		roles:    []arch.DomainRole{arch.DomainRoleCommand},
+	}
+	ship := newMockClass(shipObj, newMockObjectAttribute(1), newMockObjectMethod(1))
+	for _, o := range []arch.Object{placed, paid, order, ship} {
+		_ = objRepo.Insert(o)
+	}
+
+	ctor := newMockFuncObj(dir, "NewOrderPlacedEvent", 10)
+	place := newMockFuncObj(dir, "Order.Place", 20)
+	self := newMockFuncObj(dir, "OrderPlacedEvent.Clone", 30)
+	pay := newMockFuncObj("test/application", "Pay", 40)
+	notify := newMockFuncObj("test/application", "Notify", 50)
+	for _, o := range []arch.Object{ctor, place, self, pay, notify} {
+		_ = objRepo.Insert(o)
+	}
+
+	site := func(line int) arch.Object {
+		return &MockObject{
+			id:       placed.Identifier(),
+			position: &MockPosition{FilenameVal: "site.go", LineVal: line},
+		}
+	}
+	_ = relRepo.Insert(&MockImplementationRelation{
+		from:       paid,
+		implements: []arch.Object{newMockFuncObj("test/domain", "Event", 1)},
+	})
+	_ = relRepo.Insert(&MockUsageRelation{relType: arch.RelationTypeInstantiation, from: ctor, used: site(11)})
+	_ = relRepo.Insert(&MockUsageRelation{relType: arch.RelationTypeInstantiation, from: self, used: site(31)})
+	_ = relRepo.Insert(&MockUsageRelation{relType: arch.RelationTypeParameter, from: notify, used: site(51)})
+	_ = relRepo.Insert(&MockUsageRelation{relType: arch.RelationTypeParameter, from: notify, used: site(52)})
+	_ = relRepo.Insert(&MockUsageRelation{relType: arch.RelationTypeInstantiation, from: pay, used: paid})
+	_ = relRepo.Insert(&MockDependenceRelation{from: place, dependsOn: ctor})
+
+	return objRepo, relRepo
+}
+
+func TestEventFlowDetector_Flows(t *testing.T) {
+	objRepo, relRepo := newMockEventFlowRepos()
+	ed := NewEventFlowDetector(objRepo, relRepo, nil)
+
+	flows := ed.Flows()
+	if len(flows) != 3 {
+		t.Fatalf("Expected 3 flows, but got %d", len(flows))
+	}
+
+	expected := []struct {
+		id          string
+		kind        valueobject.MessageKind
+		publishers  []string
+		subscribers []string
+	}{
+		{"test/domain/order/OrderPlacedEvent", valueobject.MessageKindEvent,
+			[]string{"test/domain/order/Order.Place"}, []string{"test/application/Notify"}},
+		{"test/domain/order/Paid", valueobject.MessageKindEvent,
+			[]string{"test/application/Pay"}, nil},
+		{"test/domain/order/Ship", valueobject.MessageKindCommand, nil, nil},
+	}
+
+	for i, e := range expected {
+		f := flows[i]
+		if f.Message.Identifier().ID() != e.id || f.Kind != e.kind || f.Context != "order" {
+			t.Errorf("Expected flow %s (%s) in context order, but got %s (%s) in %s",
+				e.id, e.kind, f.Message.Identifier().ID(), f.Kind, f.Context)
+		}
+		if len(f.Publishers) != len(e.publishers) {
+			t.Errorf("Expected publishers %v for %s, but got %d", e.publishers, e.id, len(f.Publishers))
+		} else {
+			for j, p := range e.publishers {
+				if f.Publishers[j].Function.Identifier().ID() != p {
+					t.Errorf("Expected publisher %s, but got %s", p, f.Publishers[j].Function.Identifier().ID())
+				}
+			}
+		}
+		if len(f.Subscribers) != len(e.subscribers) {
+			t.Errorf("Expected subscribers %v for %s, but got %d", e.subscribers, e.id, len(f.Subscribers))
+		} else {
+			for j, s := range e.subscribers {
+				if f.Subscribers[j].Function.Identifier().ID() != s {
+					t.Errorf("Expected subscriber %s, but got %s", s, f.Subscribers[j].Function.Identifier().ID())
+				}
+			}
+		}
+	}
+
+	if site := flows[0].Publishers[0].Site; site.Line() != 20 {
+		t.Errorf("Expected publisher site at the constructor call, but got line %d", site.Line())
+	}
+	if site := flows[0].Subscribers[0].Site; site.Line() != 51 {
+		t.Errorf("Expected first subscriber site to be kept, but got line %d", site.Line())
+	}
+}
+
+func TestEventFlowDetector_Context(t *testing.T) {
+	a := valueobject.NewAggregate(nil, "ordering")
+	ed := NewEventFlowDetector(nil, nil, []*valueobject.AggregateGroup{
+		valueobject.NewAggregateGroup(a, "test/domain/order"),
+	})
+
+	if ctx := ed.context("test/domain/order/entity"); ctx != "ordering" {
+		t.Errorf("Expected context ordering, but got %s", ctx)
+	}
+	if ctx := ed.context("test/application"); ctx != "application" {
+		t.Errorf("Expected context application, but got %s", ctx)
+	}
+}
+
+func TestEventFlowDetector_BuildDiagram(t *testing.T) {
+	objRepo, relRepo := newMockEventFlowRepos()
+	ed := NewEventFlowDetector(objRepo, relRepo, nil)
+
+	g, err := ed.buildDiagram("test", ed.Flows())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, key := range []string{"context: order", "context: application", "test/application/Notify"} {
+		if g.FindNodeByKey(key) == nil {
+			t.Errorf("Expected node %s in diagram", key)
+		}
+	}
+
+	var event, command bool
+	for _, o := range g.Objects() {
+		switch o.(type) {
+		case *valueobject.DomainEvent:
+			event = true
+		case *valueobject.DomainCommand:
+			command = true
+		}
+	}
+	if !event || !command {
+		t.Errorf("Expected diagram to contain events and commands, but got event %v, command %v", event, command)
+	}
+
+	var publish, subscribe bool
+	for _, e := range g.Edges() {
+		if e.From() == "test/domain/order/Order.Place" && e.To() == "order/OrderPlacedEvent" {
+			publish = true
+		}
+		if e.From() == "order/OrderPlacedEvent" && e.To() == "test/application/Notify" {
+			subscribe = true
+		}
+	}
+	if !publish || !subscribe {
+		t.Errorf("Expected publisher and subscriber edges, but got publish %v, subscribe %v", publish, subscribe)
+	}
+}
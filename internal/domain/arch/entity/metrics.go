@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"math"
+	"sort"
+	"strings"
+)
+
+type MetricsCalculator struct {
+	objRepo         repository.ObjectRepository
+	relationDigraph *RelationDigraph
+	aggregates      []*valueobject.AggregateGroup
+}
+
+func (mc *MetricsCalculator) Metrics() []*valueobject.Metric {
+	ms := mc.calculate(valueobject.MetricKindPackage, func(id arch.ObjIdentifier) string {
+		return id.Dir()
+	})
+	return append(ms, mc.calculate(valueobject.MetricKindAggregate, mc.aggregateOf)...)
+}
+
+func (mc *MetricsCalculator) aggregateOf(id arch.ObjIdentifier) string {
+	for _, ag := range mc.aggregates {
+		if id.Dir() == ag.Domain() || strings.HasPrefix(id.Dir(), ag.Domain()+"/") {
+			return ag.Name()
+		}
+	}
+	return ""
+}
+
+func (mc *MetricsCalculator) calculate(kind valueobject.MetricKind, unitOf func(arch.ObjIdentifier) string) []*valueobject.Metric {
+	metrics := make(map[string]*valueobject.Metric)
+	metric := func(name string) *valueobject.Metric {
+		if _, ok := metrics[name]; !ok {
+			metrics[name] = &valueobject.Metric{Kind: kind, Name: name}
+		}
+		return metrics[name]
+	}
+
+	mc.objRepo.Walk(func(obj arch.Object) error {
+		unit := unitOf(obj.Identifier())
+		if unit == "" {
+			return nil
+		}
+		m := metric(unit)
+		switch obj.(type) {
+		case *valueobject.Class:
+			m.Classes++
+		case *valueobject.Interface:
+			m.Interfaces++
+		}
+		return nil
+	})
+
+	afferent := make(map[string]map[string]bool)
+	efferent := make(map[string]map[string]bool)
+	for _, n := range mc.relationDigraph.Nodes {
+		from := n.Value.(arch.ObjIdentifier)
+		for _, e := range n.Edges {
+			to := e.To.Value.(arch.ObjIdentifier)
+			fromUnit, toUnit := unitOrDir(unitOf, from), unitOrDir(unitOf, to)
+			if fromUnit == toUnit {
+				continue
+			}
+			if _, ok := metrics[fromUnit]; ok {
+				addUnit(efferent, fromUnit, toUnit)
+			}
+			if _, ok := metrics[toUnit]; ok {
+				addUnit(afferent, toUnit, fromUnit)
+			}
+		}
+	}
+
+	var ms []*valueobject.Metric
+	for name, m := range metrics {
+		m.Afferent = len(afferent[name])
+		m.Efferent = len(efferent[name])
+		if total := m.Afferent + m.Efferent; total > 0 {
+			m.Instability = float64(m.Efferent) / float64(total)
+		}
+		if total := m.Classes + m.Interfaces; total > 0 {
+			m.Abstractness = float64(m.Interfaces) / float64(total)
+		}
+		m.Distance = math.Abs(m.Abstractness + m.Instability - 1)
+		ms = append(ms, m)
+	}
+
+	sort.Slice(ms, func(i, j int) bool {
+		return ms[i].Name < ms[j].Name
+	})
+
+	return ms
+}
+
+func unitOrDir(unitOf func(arch.ObjIdentifier) string, id arch.ObjIdentifier) string {
+	if unit := unitOf(id); unit != "" {
+		return unit
+	}
+	return id.Dir()
+}
+
+func addUnit(units map[string]map[string]bool, unit, other string) {
+	if _, ok := units[unit]; !ok {
+		units[unit] = make(map[string]bool)
+	}
+	units[unit][other] = true
+}
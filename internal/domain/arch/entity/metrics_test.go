@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"math"
+	"testing"
+)
+
+func TestArch_Metrics(t *testing.T) {
+	mainFunc := valueobject.NewFunction(newMockMethodWithName("test/cmd", "main", 1), nil)
+	order := newMockClassWithName("test/internal/domain/order/entity", "order")
+	repo := valueobject.NewInterface(newMockMethodWithName("test/internal/domain/order/repository", "Repository", 2), nil)
+	impl := newMockClassWithName("test/internal/infrastructure/persistence", "Repo")
+	util := valueobject.NewFunction(newMockMethodWithName("test/pkg/util", "Util", 3), nil)
+
+	mockRepo := &MockObjectRepository{
+		objects: make(map[string]arch.Object),
+		idents:  []arch.ObjIdentifier{},
+	}
+	for _, o := range []arch.Object{mainFunc, order, repo, impl, util} {
+		_ = mockRepo.Insert(o)
+	}
+
+	mockRelRepo := &MockRelationRepository{relations: []arch.Relation{
+		&MockDependenceRelation{from: mainFunc, dependsOn: util},
+		&MockAssociationRelation{from: impl, refer: order, associationType: arch.RelationTypeAssociation},
+		&MockImplementationRelation{from: impl, implements: []arch.Object{repo}},
+		&MockAssociationRelation{from: order, refer: util, associationType: arch.RelationTypeAssociation},
+	}}
+
+	a := &Arch{
+		CodeHandler: &valueobject.CodeHandler{
+			ObjRepo: mockRepo,
+			RelRepo: mockRelRepo,
+			Scope:   "test",
+		},
+	}
+
+	ms, err := a.Metrics()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	found := make(map[string]*valueobject.Metric)
+	for _, m := range ms {
+		found[string(m.Kind)+":"+m.Name] = m
+	}
+	if len(found) != 6 {
+		t.Fatalf("Expected 5 package metrics and 1 aggregate metric, but got %d", len(found))
+	}
+
+	infra := found["package:test/internal/infrastructure/persistence"]
+	if infra.Afferent != 0 || infra.Efferent != 2 || infra.Instability != 1 || infra.Distance != 0 {
+		t.Errorf("Unexpected infrastructure metric %+v", infra)
+	}
+
+	repository := found["package:test/internal/domain/order/repository"]
+	if repository.Afferent != 1 || repository.Abstractness != 1 || repository.Instability != 0 || repository.Distance != 0 {
+		t.Errorf("Unexpected repository metric %+v", repository)
+	}
+
+	aggregate := found["aggregate:order"]
+	if aggregate == nil {
+		t.Fatal("Expected aggregate order metric")
+	}
+	if aggregate.Afferent != 1 || aggregate.Efferent != 1 || aggregate.Classes != 1 || aggregate.Interfaces != 1 {
+		t.Errorf("Unexpected aggregate metric %+v", aggregate)
+	}
+	if aggregate.Instability != 0.5 || aggregate.Abstractness != 0.5 || aggregate.Distance != 0 {
+		t.Errorf("Unexpected aggregate ratios %+v", aggregate)
+	}
+
+	entity := found["package:test/internal/domain/order/entity"]
+	if math.Abs(entity.Distance-0.5) > 1e-9 {
+		t.Errorf("Expected entity distance 0.5, but got %f", entity.Distance)
+	}
+}
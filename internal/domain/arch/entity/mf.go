@@ -16,39 +16,172 @@ type MessageFlow struct {
 	objRepo         repository.ObjectRepository
 	relationDigraph *RelationDigraph
 	mainPkgPath     string
+	startFuncs      []string
 	endPkgPath      string
 	modulePath      string
+	search          valueobject.FlowSearch
+	routes          []*valueobject.Route
+	// entries are the routes starting the flows, by the key of their handler
+	entries map[string][]*valueobject.Route
 }
 
 func (mf *MessageFlow) newDirFilter() (*DirFilter, error) {
-	if n := mf.relationDigraph.FindNodeByKey(mf.mainFuncPath()); n != nil {
-		ps := mf.relationDigraph.FindPathsToPrefix(mf.mainFuncPath(), mf.endPkgPath)
+	starts, err := mf.startKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	df := &DirFilter{}
+	if mf.search.Mode == valueobject.FlowModeReach {
+		mf.reach(df, starts)
+	} else {
+		mf.findPaths(df, starts)
+	}
+
+	if len(df.objs) == 0 {
+		return nil, errors.New("no path found")
+	}
+
+	validPkgs := make(map[string]bool)
+	for _, obj := range df.objs {
+		dir := path.Dir(obj.ID())
+		if ok := validPkgs[dir]; !ok {
+			validPkgs[dir] = true
+		}
+	}
+
+	keys := make([]string, 0, len(validPkgs))
+	for key := range validPkgs {
+		keys = append(keys, key)
+	}
+	df.pkgSet = keys
+	return df, nil
+}
 
-		if len(ps) == 0 {
-			return nil, errors.New("no path found")
+func (mf *MessageFlow) findPaths(df *DirFilter, starts []string) {
+	limits := directed.PathLimits{MaxDepth: mf.search.MaxDepth, MaxPaths: mf.search.MaxPaths, MaxSteps: mf.search.MaxSteps}
+	seen := make(map[*directed.Node]bool)
+	linked := make(map[*directed.Node]map[*directed.Node]bool)
+	for _, start := range starts {
+		var ps [][]*directed.Node
+		if mf.search.Mode == valueobject.FlowModeShortest {
+			ps = mf.relationDigraph.ShortestPaths(start, mf.isEnd, limits)
+		} else {
+			ps = mf.relationDigraph.FindPaths(start, mf.isEnd, limits)
 		}
 
-		validPkgs := make(map[string]bool)
-		objs := make([]arch.ObjIdentifier, 0, len(ps))
 		for _, p := range ps {
-			for _, n := range p {
-				dir := path.Dir(n.Key)
-				if ok := validPkgs[dir]; !ok {
-					validPkgs[dir] = true
+			df.addStart(p[0])
+			for i, n := range p {
+				if !seen[n] {
+					seen[n] = true
+					df.objs = append(df.objs, n.Value.(arch.ObjIdentifier))
+				}
+				if i == 0 {
+					continue
+				}
+				if linked[p[i-1]] == nil {
+					linked[p[i-1]] = make(map[*directed.Node]bool)
+				}
+				if !linked[p[i-1]][n] {
+					linked[p[i-1]][n] = true
+					df.edges = append(df.edges, &directed.Edge{From: p[i-1], To: n})
 				}
+			}
+		}
+	}
+}
 
-				objs = append(objs, n.Value.(arch.ObjIdentifier))
+func (mf *MessageFlow) reach(df *DirFilter, starts []string) {
+	seen := make(map[*directed.Node]bool)
+	linked := make(map[*directed.Edge]bool)
+	for _, start := range starts {
+		nodes, edges := mf.relationDigraph.Reachable(start, mf.isEnd, mf.search.MaxDepth)
+		if len(nodes) == 0 {
+			continue
+		}
+		df.addStart(mf.relationDigraph.FindNodeByKey(start))
+		for _, n := range nodes {
+			if !seen[n] {
+				seen[n] = true
+				df.objs = append(df.objs, n.Value.(arch.ObjIdentifier))
+			}
+		}
+		for _, e := range edges {
+			if !linked[e] {
+				linked[e] = true
+				df.edges = append(df.edges, e)
 			}
 		}
+	}
+}
+
+// startKeys returns the functions matching the start patterns, the main func when there is none.
+func (mf *MessageFlow) startKeys() ([]string, error) {
+	if mf.search.Routes {
+		return mf.routeKeys()
+	}
+	if len(mf.startFuncs) == 0 {
+		if n := mf.relationDigraph.FindNodeByKey(mf.mainFuncPath()); n == nil {
+			return nil, errors.New("main func not found")
+		}
+		return []string{mf.mainFuncPath()}, nil
+	}
+
+	return mf.relationDigraph.MatchKeys(mf.startFuncs)
+}
 
-		keys := make([]string, 0, len(validPkgs))
-		for key := range validPkgs {
+// routeKeys returns the handlers of the routes matching the start patterns, of all routes when there is none.
+func (mf *MessageFlow) routeKeys() ([]string, error) {
+	mf.entries = make(map[string][]*valueobject.Route)
+	var keys []string
+	for _, r := range mf.routes {
+		ok, err := matchRoute(r, mf.startFuncs)
+		if err != nil {
+			return nil, err
+		}
+		key := r.Handler.ID()
+		if !ok || mf.relationDigraph.FindNodeByKey(key) == nil {
+			continue
+		}
+		if _, found := mf.entries[key]; !found {
 			keys = append(keys, key)
 		}
-		return &DirFilter{pkgSet: keys, paths: ps, objs: objs}, nil
+		mf.entries[key] = append(mf.entries[key], r)
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no route found")
+	}
+	return keys, nil
+}
+
+func matchRoute(r *valueobject.Route, patterns []string) (bool, error) {
+	if len(patterns) == 0 {
+		return true, nil
+	}
+	for _, p := range patterns {
+		ok, err := path.Match(p, r.Name())
+		if err != nil {
+			return false, fmt.Errorf("invalid route pattern %s: %w", p, err)
+		}
+		if ok {
+			return true, nil
+		}
 	}
+	return false, nil
+}
 
-	return nil, errors.New("main func not found")
+// isEnd tells whether a node is the end function or belongs to the end package,
+// the end may be a glob pattern as well.
+func (mf *MessageFlow) isEnd(n *directed.Node) bool {
+	if strings.ContainsAny(mf.endPkgPath, "*?[") {
+		ok, _ := path.Match(mf.endPkgPath, n.Key)
+		return ok
+	}
+	return n.Key == mf.endPkgPath ||
+		strings.HasPrefix(n.Key, mf.endPkgPath+"/") ||
+		strings.HasPrefix(n.Key, mf.endPkgPath+".")
 }
 
 func (mf *MessageFlow) buildDiagram() (*Diagram, error) {
@@ -72,33 +205,52 @@ func (mf *MessageFlow) buildDiagram() (*Diagram, error) {
 		return nil, err
 	}
 
-	var preIdentifier arch.ObjIdentifier
+	linked := make(map[string]bool)
+	for _, e := range dirFilter.edges {
+		from := e.From.Value.(arch.ObjIdentifier)
+		to := e.To.Value.(arch.ObjIdentifier)
+		if linked[from.ID()+"|"+to.ID()] {
+			continue
+		}
+		linked[from.ID()+"|"+to.ID()] = true
+
+		metas, err := mf.relationDigraph.RelationMetas(from, to)
+		if err != nil {
+			return nil, err
+		}
+		if err := g.AddRelations(from.ID(), to.ID(), metas); err != nil {
+			return nil, err
+		}
+	}
 
-	for _, p := range dirFilter.paths {
-		for _, n := range p {
-			if preIdentifier == nil {
-				preIdentifier = n.Value.(arch.ObjIdentifier)
+	if err := mf.addEntries(g, dirFilter.starts); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// addEntries adds the routes starting the flows, each one calling its handler.
+func (mf *MessageFlow) addEntries(g *Diagram, starts []*directed.Node) error {
+	added := make(map[string]bool)
+	for _, start := range starts {
+		for _, r := range mf.entries[start.Key] {
+			entry := valueobject.NewStringObj(r.Name())
+			if added[entry.Identifier().ID()] {
 				continue
 			}
-			current := n.Value.(arch.ObjIdentifier)
-			metas, err := mf.relationDigraph.RelationMetas(
-				preIdentifier,
-				current,
-			)
-			if err != nil {
-				return nil, err
-			}
+			added[entry.Identifier().ID()] = true
 
-			fromId := preIdentifier.ID()
-			toId := current.ID()
-			if err := g.AddRelations(fromId, toId, metas); err != nil {
-				return nil, err
+			if err := g.AddStringTo(r.Name(), mf.modulePath, arch.RelationTypeAggregationRoot); err != nil {
+				return err
+			}
+			if err := g.AddEdge(entry.Identifier().ID(), start.Key, arch.RelationTypeDependency,
+				valueobject.NewRelationPos(r.Pos, nil)); err != nil {
+				return err
 			}
-			preIdentifier = current
 		}
 	}
-
-	return g, nil
+	return nil
 }
 
 func (mf *MessageFlow) mainFuncPath() string {
@@ -108,8 +260,18 @@ func (mf *MessageFlow) mainFuncPath() string {
 
 type DirFilter struct {
 	pkgSet []string
-	paths  [][]*directed.Node
 	objs   []arch.ObjIdentifier
+	starts []*directed.Node
+	edges  []*directed.Edge
+}
+
+func (sf *DirFilter) addStart(n *directed.Node) {
+	for _, s := range sf.starts {
+		if s == n {
+			return
+		}
+	}
+	sf.starts = append(sf.starts, n)
 }
 
 func (sf *DirFilter) IsValid(dir string) bool {
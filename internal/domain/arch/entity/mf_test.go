@@ -3,6 +3,8 @@ package entity
 import (
 	"github.com/dddplayer/dp/internal/domain/arch"
 	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"github.com/dddplayer/dp/pkg/datastructure/directed"
+	"reflect"
 	"testing"
 )
 
@@ -201,6 +203,109 @@ func TestMessageFlow_NewDirFilter(t *testing.T) {
 	}
 }
 
+func TestMessageFlow_NewDirFilterWithStartFuncs(t *testing.T) {
+	mf := &MessageFlow{
+		relationDigraph: NewRelationDigraph(),
+		mainPkgPath:     "example.com/mainpkg",
+		startFuncs:      []string{"/path/to/ma*"},
+		endPkgPath:      "/path/to/sub/ObjectC",
+	}
+
+	dirFilter, err := mf.newDirFilter()
+	if err != nil {
+		t.Fatalf("Expected no error, but got error: %v", err)
+	}
+	if len(dirFilter.objs) != 2 || len(dirFilter.edges) != 1 {
+		t.Fatalf("Expected one path of 2 nodes, but got %v", dirFilter.edges)
+	}
+	if dirFilter.edges[0].To.Key != "/path/to/sub/ObjectC" {
+		t.Errorf("Expected the path to end at /path/to/sub/ObjectC, but got %s", dirFilter.edges[0].To.Key)
+	}
+
+	mf.startFuncs = []string{"/path/to/sub/ObjectB"}
+	if _, err := mf.newDirFilter(); err == nil {
+		t.Error("Expected an error for the start functions not reaching the end, but got nil")
+	}
+
+	mf.startFuncs = []string{"/other/*"}
+	if _, err := mf.newDirFilter(); err == nil || err.Error() != "no function matches /other/*" {
+		t.Errorf("Expected an error with 'no function matches /other/*' message, but got error: %v", err)
+	}
+
+	mf.startFuncs = []string{"/path/to/[main"}
+	if _, err := mf.newDirFilter(); err == nil {
+		t.Error("Expected an error for an invalid start pattern, but got nil")
+	}
+}
+
+func TestMessageFlow_NewDirFilterModes(t *testing.T) {
+	tests := []struct {
+		search valueobject.FlowSearch
+		edges  int
+	}{
+		{valueobject.FlowSearch{}, 4},
+		{valueobject.FlowSearch{Mode: valueobject.FlowModeAll, MaxPaths: 1}, 2},
+		{valueobject.FlowSearch{Mode: valueobject.FlowModeShortest}, 2},
+		{valueobject.FlowSearch{Mode: valueobject.FlowModeShortest, MaxPaths: 5}, 4},
+		{valueobject.FlowSearch{Mode: valueobject.FlowModeReach}, 4},
+	}
+
+	for _, tt := range tests {
+		mf := &MessageFlow{
+			relationDigraph: newSequenceDigraph(),
+			startFuncs:      []string{"app/Service.Handle"},
+			endPkgPath:      "infra",
+			search:          tt.search,
+		}
+		dirFilter, err := mf.newDirFilter()
+		if err != nil {
+			t.Fatalf("Expected no error for %+v, but got: %v", tt.search, err)
+		}
+		if len(dirFilter.edges) != tt.edges {
+			t.Errorf("Expected %d edges for %+v, but got %d", tt.edges, tt.search, len(dirFilter.edges))
+		}
+		if len(dirFilter.starts) != 1 || dirFilter.starts[0].Key != "app/Service.Handle" {
+			t.Errorf("Expected the start app/Service.Handle for %+v, but got %v", tt.search, dirFilter.starts)
+		}
+	}
+
+	for _, mode := range []valueobject.FlowMode{
+		valueobject.FlowModeAll, valueobject.FlowModeShortest, valueobject.FlowModeReach,
+	} {
+		mf := &MessageFlow{
+			relationDigraph: newSequenceDigraph(),
+			startFuncs:      []string{"app/Service.Handle"},
+			endPkgPath:      "infra",
+			search:          valueobject.FlowSearch{Mode: mode, MaxDepth: 1},
+		}
+		if _, err := mf.newDirFilter(); err == nil || err.Error() != "no path found" {
+			t.Errorf("Expected 'no path found' within 1 call in %s mode, but got: %v", mode, err)
+		}
+	}
+}
+
+func TestMessageFlow_IsEnd(t *testing.T) {
+	tests := []struct {
+		end      string
+		key      string
+		expected bool
+	}{
+		{"/path/to/sub", "/path/to/sub/ObjectB", true},
+		{"/path/to/sub", "/path/to/subway/ObjectB", false},
+		{"/path/to/sub/Service", "/path/to/sub/Service.Do", true},
+		{"/path/to/sub/Service.Do", "/path/to/sub/Service.DoMore", false},
+		{"/path/to/*/Service.*", "/path/to/sub/Service.Do", true},
+		{"/path/to/*/Service.*", "/path/to/sub/Repo.Get", false},
+	}
+
+	for _, tt := range tests {
+		mf := &MessageFlow{endPkgPath: tt.end}
+		if got := mf.isEnd(&directed.Node{Key: tt.key}); got != tt.expected {
+			t.Errorf("isEnd(%s) with end %s = %v, want %v", tt.key, tt.end, got, tt.expected)
+		}
+	}
+}
+
 // 用于比较两字符串切片是否相等的辅助函数
 func equalStringSlices(slice1, slice2 []string) bool {
 	if len(slice1) != len(slice2) {
@@ -249,3 +354,83 @@ func TestBuildDiagram(t *testing.T) {
 		t.Errorf("Expected edges to be %d, but got %d", expectedEdgeCount, len(diagram.Edges()))
 	}
 }
+
+func TestMessageFlow_RouteKeys(t *testing.T) {
+	handle := &MockObjIdentifier{id: "app/Service.Handle", name: "Service.Handle", dir: "app"}
+	routes := []*valueobject.Route{
+		{Method: "GET", Path: "/orders", Handler: handle},
+		{Method: "POST", Path: "/orders", Handler: handle},
+		{Method: "GET", Path: "/missing", Handler: &MockObjIdentifier{id: "app/Missing", name: "Missing", dir: "app"}},
+	}
+
+	tests := []struct {
+		patterns []string
+		keys     []string
+		entries  int
+		err      string
+	}{
+		{nil, []string{"app/Service.Handle"}, 2, ""},
+		{[]string{"POST /*"}, []string{"app/Service.Handle"}, 1, ""},
+		{[]string{"GET /missing"}, nil, 0, "no route found"},
+		{[]string{"[GET"}, nil, 0, "invalid route pattern [GET: syntax error in pattern"},
+	}
+	for _, tt := range tests {
+		mf := &MessageFlow{
+			relationDigraph: newSequenceDigraph(),
+			startFuncs:      tt.patterns,
+			search:          valueobject.FlowSearch{Routes: true},
+			routes:          routes,
+		}
+		keys, err := mf.startKeys()
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("Expected error %q for %v, but got %v", tt.err, tt.patterns, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Expected no error for %v, but got: %v", tt.patterns, err)
+		}
+		if !reflect.DeepEqual(keys, tt.keys) || len(mf.entries["app/Service.Handle"]) != tt.entries {
+			t.Errorf("Expected keys %v with %d entries for %v, but got %v with %d",
+				tt.keys, tt.entries, tt.patterns, keys, len(mf.entries["app/Service.Handle"]))
+		}
+	}
+}
+
+func TestBuildDiagramWithRoutes(t *testing.T) {
+	mockDirectory, objs := newMockMfDirectoryWithObjs()
+	mockRepo := &MockObjectRepository{
+		objects: make(map[string]arch.Object),
+		idents:  []arch.ObjIdentifier{},
+	}
+	for _, mockObj := range objs {
+		_ = mockRepo.Insert(mockObj)
+	}
+
+	mf := &MessageFlow{
+		directory:       mockDirectory,
+		objRepo:         mockRepo,
+		relationDigraph: NewRelationDigraph(),
+		mainPkgPath:     "/path/to",
+		endPkgPath:      "/path/to/sub",
+		modulePath:      "/path/to",
+		search:          valueobject.FlowSearch{Routes: true},
+		routes: []*valueobject.Route{
+			{Method: "GET", Path: "/orders", Handler: &MockObjIdentifier{id: "/path/to/main"}, Pos: &MockPosition{}},
+		},
+	}
+
+	diagram, err := mf.buildDiagram()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	entry := diagram.FindNodeByKey("GET /orders")
+	if entry == nil {
+		t.Fatalf("Expected the route GET /orders in the diagram")
+	}
+	if len(entry.Edges) != 1 || entry.Edges[0].To.Key != "/path/to/main" {
+		t.Errorf("Expected the route to call /path/to/main, but got %v", entry.Edges)
+	}
+}
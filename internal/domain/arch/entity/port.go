@@ -0,0 +1,141 @@
+package entity
+
+import (
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"sort"
+)
+
+const unconnectedPortsKey = "ports without adapter"
+
+type PortMapper struct {
+	directory *Directory
+	objRepo   repository.ObjectRepository
+	relRepo   repository.RelationRepository
+}
+
+func NewPortMapper(d *Directory, objRepo repository.ObjectRepository, relRepo repository.RelationRepository) *PortMapper {
+	return &PortMapper{
+		directory: d,
+		objRepo:   objRepo,
+		relRepo:   relRepo,
+	}
+}
+
+// Ports lists the interfaces of the domain and application layers together with
+// every implementation found in the module.
+func (pm *PortMapper) Ports() []*valueobject.Port {
+	ports := make(map[string]*valueobject.Port)
+	pm.objRepo.Walk(func(obj arch.Object) error {
+		itf, ok := obj.(*valueobject.Interface)
+		if !ok {
+			return nil
+		}
+		switch layer := pm.directory.Layer(itf.Identifier().Dir()); layer {
+		case arch.HexagonDirectoryDomain, arch.HexagonDirectoryApplication:
+			ports[itf.Identifier().ID()] = &valueobject.Port{Interface: itf, Layer: layer}
+		}
+		return nil
+	})
+
+	pm.relRepo.Walk(func(rel arch.Relation) error {
+		impl, ok := rel.(arch.ImplementationRelation)
+		if !ok {
+			return nil
+		}
+		adapter := pm.objRepo.Find(impl.From().Identifier())
+		if adapter == nil {
+			adapter = impl.From()
+		}
+		for _, ifc := range impl.Implements() {
+			p := ports[ifc.Identifier().ID()]
+			if p == nil || hasAdapter(p, adapter) {
+				continue
+			}
+			p.Adapters = append(p.Adapters, &valueobject.Adapter{
+				Object: adapter,
+				Layer:  pm.directory.Layer(adapter.Identifier().Dir()),
+			})
+		}
+		return nil
+	})
+
+	var result []*valueobject.Port
+	for _, p := range ports {
+		sort.Slice(p.Adapters, func(i, j int) bool {
+			return p.Adapters[i].Object.Identifier().ID() < p.Adapters[j].Object.Identifier().ID()
+		})
+		result = append(result, p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Interface.Identifier().ID() < result[j].Interface.Identifier().ID()
+	})
+
+	return result
+}
+
+func (pm *PortMapper) buildDiagram(name string, ports []*valueobject.Port) (*Diagram, error) {
+	g, err := NewDiagram(name, arch.PlainDiagram)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string]bool)
+	addTo := func(key string, obj arch.Object) error {
+		if !groups[key] {
+			if err := g.AddStringTo(key, g.Name(), arch.RelationTypeAggregationRoot); err != nil {
+				return err
+			}
+			groups[key] = true
+		}
+		if g.FindNodeByKey(obj.Identifier().ID()) != nil {
+			return nil
+		}
+		return g.AddObjTo(obj, key, arch.RelationTypeAggregation)
+	}
+
+	for _, p := range ports {
+		key := fmt.Sprintf("ports: %s", layerName(p.Layer))
+		if len(p.Adapters) == 0 {
+			key = unconnectedPortsKey
+		}
+		if err := addTo(key, p.Interface); err != nil {
+			return nil, err
+		}
+
+		portId := p.Interface.Identifier().ID()
+		for _, a := range p.Adapters {
+			if err := addTo(fmt.Sprintf("adapters: %s", layerName(a.Layer)), a.Object); err != nil {
+				return nil, err
+			}
+			adapterId := a.Object.Identifier().ID()
+			if err := g.AddEdge(portId, adapterId, arch.RelationTypeImplementation,
+				valueobject.NewRelationPos(p.Interface.Position(), a.Object.Position())); err != nil {
+				return nil, err
+			}
+			if p.Flagged() {
+				g.Highlight(portId, adapterId)
+			}
+		}
+	}
+
+	return g, nil
+}
+
+func hasAdapter(p *valueobject.Port, obj arch.Object) bool {
+	for _, a := range p.Adapters {
+		if a.Object.Identifier().ID() == obj.Identifier().ID() {
+			return true
+		}
+	}
+	return false
+}
+
+func layerName(layer arch.HexagonDirectory) string {
+	if layer == arch.HexagonDirectoryInvalid {
+		return "other"
+	}
+	return string(layer)
+}
@@ -0,0 +1,120 @@
+package entity
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"testing"
+)
+
+func newMockPortMapper() *PortMapper {
+	domainDir := "test/internal/domain/order"
+	appDir := "test/internal/application"
+	infraDir := "test/internal/infrastructure/persistence"
+	ifDir := "test/internal/interfaces/memory"
+
+	repo := valueobject.NewInterface(newMockMethodWithName(domainDir, "Repository", 3), nil)
+	clock := valueobject.NewInterface(newMockMethodWithName(domainDir, "Clock", 5), nil)
+	notifier := valueobject.NewInterface(newMockMethodWithName(appDir, "Notifier", 7), nil)
+	writer := valueobject.NewInterface(newMockMethodWithName(infraDir, "Writer", 9), nil)
+
+	dbRepo := newMockClassWithName(infraDir, "OrderRepo")
+	memRepo := newMockClassWithName(ifDir, "OrderRepo")
+	mailer := newMockClassWithName(infraDir, "Mailer")
+
+	objRepo := &MockObjectRepository{
+		objects: make(map[string]arch.Object),
+		idents:  []arch.ObjIdentifier{},
+	}
+	for _, o := range []arch.Object{repo, clock, notifier, writer, dbRepo, memRepo, mailer,
+		valueobject.NewFunction(newMockMethodWithName("test/cmd", "main", 1), nil)} {
+		_ = objRepo.Insert(o)
+	}
+
+	relRepo := &MockRelationRepository{}
+	_ = relRepo.Insert(&MockImplementationRelation{from: dbRepo, implements: []arch.Object{repo, writer}})
+	_ = relRepo.Insert(&MockImplementationRelation{from: memRepo, implements: []arch.Object{repo}})
+	_ = relRepo.Insert(&MockImplementationRelation{from: mailer, implements: []arch.Object{notifier, notifier}})
+
+	var paths []string
+	for _, id := range objRepo.All() {
+		paths = append(paths, id.ID())
+	}
+	return NewPortMapper(NewDirectory(paths), objRepo, relRepo)
+}
+
+func TestPortMapper_Ports(t *testing.T) {
+	ports := newMockPortMapper().Ports()
+
+	expected := []struct {
+		id       string
+		layer    arch.HexagonDirectory
+		adapters []string
+	}{
+		{"test/internal/application/Notifier", arch.HexagonDirectoryApplication,
+			[]string{"test/internal/infrastructure/persistence/Mailer"}},
+		{"test/internal/domain/order/Clock", arch.HexagonDirectoryDomain, nil},
+		{"test/internal/domain/order/Repository", arch.HexagonDirectoryDomain,
+			[]string{"test/internal/infrastructure/persistence/OrderRepo", "test/internal/interfaces/memory/OrderRepo"}},
+	}
+	if len(ports) != len(expected) {
+		t.Fatalf("Expected %d ports, but got %d", len(expected), len(ports))
+	}
+
+	for i, e := range expected {
+		p := ports[i]
+		if p.Interface.Identifier().ID() != e.id || p.Layer != e.layer {
+			t.Errorf("Expected port %s in %s, but got %s in %s", e.id, e.layer, p.Interface.Identifier().ID(), p.Layer)
+		}
+		if len(p.Adapters) != len(e.adapters) {
+			t.Errorf("Expected adapters %v for %s, but got %d", e.adapters, e.id, len(p.Adapters))
+			continue
+		}
+		for j, a := range e.adapters {
+			if p.Adapters[j].Object.Identifier().ID() != a {
+				t.Errorf("Expected adapter %s, but got %s", a, p.Adapters[j].Object.Identifier().ID())
+			}
+		}
+		if p.Flagged() != (len(e.adapters) != 1) {
+			t.Errorf("Unexpected flag %v for port %s", p.Flagged(), e.id)
+		}
+	}
+
+	if l := ports[2].Adapters[1].Layer; l != arch.HexagonDirectoryInterfaces {
+		t.Errorf("Expected adapter layer interfaces, but got %s", l)
+	}
+}
+
+func TestPortMapper_BuildDiagram(t *testing.T) {
+	pm := newMockPortMapper()
+	g, err := pm.buildDiagram("test", pm.Ports())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, key := range []string{"ports: domain", "ports: application", unconnectedPortsKey,
+		"adapters: infrastructure", "adapters: interfaces"} {
+		if g.FindNodeByKey(key) == nil {
+			t.Errorf("Expected node %s in diagram", key)
+		}
+	}
+
+	edges := make(map[string]bool)
+	for _, e := range g.Edges() {
+		if e.Type() != arch.RelationTypeImplementation {
+			continue
+		}
+		edges[e.From()+" -> "+e.To()] = true
+	}
+	for _, key := range []string{
+		"test/internal/domain/order/Repository -> test/internal/infrastructure/persistence/OrderRepo",
+		"test/internal/domain/order/Repository -> test/internal/interfaces/memory/OrderRepo",
+		"test/internal/application/Notifier -> test/internal/infrastructure/persistence/Mailer",
+	} {
+		if !edges[key] {
+			t.Errorf("Expected edge %s", key)
+		}
+	}
+	if len(edges) != 3 {
+		t.Errorf("Expected 3 edges, but got %d", len(edges))
+	}
+}
@@ -0,0 +1,158 @@
+package entity
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"github.com/dddplayer/dp/pkg/datastructure/directed"
+	"path"
+	"sort"
+	"strings"
+)
+
+type Sequence struct {
+	name         string
+	participants []arch.Node
+	messages     []arch.Message
+	index        map[string]bool
+}
+
+func NewSequence(name string) *Sequence {
+	return &Sequence{name: name, index: make(map[string]bool)}
+}
+
+func (s *Sequence) Name() string              { return s.name }
+func (s *Sequence) Participants() []arch.Node { return s.participants }
+func (s *Sequence) Messages() []arch.Message  { return s.messages }
+
+func (s *Sequence) addParticipant(p *node) {
+	if s.index[p.id] {
+		return
+	}
+	s.index[p.id] = true
+	s.participants = append(s.participants, p)
+}
+
+func (s *Sequence) addMessage(from, to arch.ObjIdentifier, pos arch.Position) {
+	s.addCall(participant(from), to, pos)
+}
+
+// addEntry lets the route call its handler first.
+func (s *Sequence) addEntry(r *valueobject.Route) {
+	s.addCall(&node{id: r.Name(), name: r.Name(), color: string(arch.ColorGeneral)}, r.Handler, r.Pos)
+}
+
+func (s *Sequence) addCall(fp *node, to arch.ObjIdentifier, pos arch.Position) {
+	tp := participant(to)
+	s.addParticipant(fp)
+	s.addParticipant(tp)
+	s.messages = append(s.messages, &message{from: fp.id, to: tp.id, name: funcName(to), pos: pos})
+}
+
+type message struct {
+	from string
+	to   string
+	name string
+	pos  arch.Position
+}
+
+func (m *message) From() string       { return m.from }
+func (m *message) To() string         { return m.to }
+func (m *message) Name() string       { return m.name }
+func (m *message) Pos() arch.Position { return m.pos }
+
+// buildSequence orders the calls along the message flow paths by their call site,
+// the callee of a call is followed before the next call of its caller.
+func (mf *MessageFlow) buildSequence() (*Sequence, error) {
+	dirFilter, err := mf.newDirFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	calls := make(map[*directed.Node][]*directed.Edge)
+	linked := make(map[*directed.Node]map[*directed.Node]bool)
+	for _, fe := range dirFilter.edges {
+		if linked[fe.From] == nil {
+			linked[fe.From] = make(map[*directed.Node]bool)
+		}
+		if linked[fe.From][fe.To] {
+			continue
+		}
+		linked[fe.From][fe.To] = true
+
+		for _, e := range fe.From.Edges {
+			if e.To == fe.To && e.Type == arch.RelationTypeDependency {
+				calls[e.From] = append(calls[e.From], e)
+			}
+		}
+	}
+	for _, es := range calls {
+		sort.SliceStable(es, func(i, j int) bool {
+			return positionLess(callSite(es[i]), callSite(es[j]))
+		})
+	}
+
+	s := NewSequence(mf.modulePath)
+	walked := make(map[*directed.Node]bool)
+	for _, start := range dirFilter.starts {
+		for _, r := range mf.entries[start.Key] {
+			s.addEntry(r)
+		}
+		s.addParticipant(participant(start.Value.(arch.ObjIdentifier)))
+		walkCalls(s, start, calls, walked)
+	}
+
+	return s, nil
+}
+
+// walkCalls follows the calls of n, a callee already walked is called again but its own
+// calls are not repeated, so each call appears once however many callers share the callee.
+func walkCalls(s *Sequence, n *directed.Node, calls map[*directed.Node][]*directed.Edge, walked map[*directed.Node]bool) {
+	walked[n] = true
+	for _, e := range calls[n] {
+		s.addMessage(n.Value.(arch.ObjIdentifier), e.To.Value.(arch.ObjIdentifier), callSite(e))
+		if !walked[e.To] {
+			walkCalls(s, e.To, calls, walked)
+		}
+	}
+}
+
+// participant is the receiver type of a method, or the package of a function.
+func participant(id arch.ObjIdentifier) *node {
+	if i := strings.Index(id.Name(), "."); i > 0 {
+		typ := id.Name()[:i]
+		return &node{
+			id:    path.Join(id.Dir(), typ),
+			name:  path.Base(id.Dir()) + "." + typ,
+			color: string(arch.ColorClass),
+		}
+	}
+	return &node{id: id.Dir(), name: path.Base(id.Dir()), color: string(arch.ColorFunc)}
+}
+
+func funcName(id arch.ObjIdentifier) string {
+	name := id.Name()
+	if i := strings.Index(name, "."); i > 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+func callSite(e *directed.Edge) arch.Position {
+	if pos, ok := e.Value.(arch.RelationPos); ok {
+		return pos.From()
+	}
+	return nil
+}
+
+func positionLess(a, b arch.Position) bool {
+	if a == nil || b == nil {
+		return a != nil
+	}
+	if a.Filename() != b.Filename() {
+		return a.Filename() < b.Filename()
+	}
+	if a.Line() != b.Line() {
+		return a.Line() < b.Line()
+	}
+	return a.Column() < b.Column()
+}
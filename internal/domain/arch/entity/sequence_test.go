@@ -0,0 +1,183 @@
+package entity
+
+import (
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"github.com/dddplayer/dp/pkg/datastructure/directed"
+	"reflect"
+	"testing"
+)
+
+func newSequenceDigraph() *RelationDigraph {
+	g := &RelationDigraph{Graph: directed.NewDirectedGraph()}
+
+	handle := &MockObjIdentifier{id: "app/Service.Handle", name: "Service.Handle", dir: "app"}
+	validate := &MockObjIdentifier{id: "app/Validate", name: "Validate", dir: "app"}
+	place := &MockObjIdentifier{id: "domain/Order.Place", name: "Order.Place", dir: "domain"}
+	save := &MockObjIdentifier{id: "infra/Repo.Save", name: "Repo.Save", dir: "infra"}
+	for _, id := range []*MockObjIdentifier{handle, validate, place, save} {
+		_ = g.AddObj(id)
+	}
+
+	call := func(from, to *MockObjIdentifier, line int) {
+		_ = g.AddRelation(&MockDependenceRelation{
+			from:      MockObject{id: from, position: &MockPosition{FilenameVal: "f.go", LineVal: line}},
+			dependsOn: MockObject{id: to, position: &MockPosition{}},
+		})
+	}
+	call(handle, place, 20)
+	call(handle, validate, 10)
+	call(place, save, 7)
+	call(validate, save, 3)
+
+	return g
+}
+
+func TestMessageFlow_BuildSequence(t *testing.T) {
+	mf := &MessageFlow{
+		relationDigraph: newSequenceDigraph(),
+		startFuncs:      []string{"app/Service.*"},
+		endPkgPath:      "infra",
+		modulePath:      "example.com/app",
+	}
+
+	s, err := mf.buildSequence()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	if s.Name() != "example.com/app" {
+		t.Errorf("Expected name example.com/app, but got %s", s.Name())
+	}
+
+	var participants []string
+	for _, p := range s.Participants() {
+		participants = append(participants, fmt.Sprintf("%s %s", p.ID(), p.Name()))
+	}
+	expectedParticipants := []string{
+		"app/Service app.Service", "app app", "infra/Repo infra.Repo", "domain/Order domain.Order",
+	}
+	if !reflect.DeepEqual(participants, expectedParticipants) {
+		t.Errorf("Expected participants %v, but got %v", expectedParticipants, participants)
+	}
+
+	var messages []string
+	for _, m := range s.Messages() {
+		messages = append(messages, fmt.Sprintf("%s->%s:%s@%d", m.From(), m.To(), m.Name(), m.Pos().Line()))
+	}
+	expectedMessages := []string{
+		"app/Service->app:Validate@10",
+		"app->infra/Repo:Save@3",
+		"app/Service->domain/Order:Place@20",
+		"domain/Order->infra/Repo:Save@7",
+	}
+	if !reflect.DeepEqual(messages, expectedMessages) {
+		t.Errorf("Expected messages %v, but got %v", expectedMessages, messages)
+	}
+}
+
+func TestMessageFlow_BuildSequenceSharedCallee(t *testing.T) {
+	g := newSequenceDigraph()
+	save := g.FindNodeByKey("infra/Repo.Save").Value.(*MockObjIdentifier)
+	exec := &MockObjIdentifier{id: "db/Conn.Exec", name: "Conn.Exec", dir: "db"}
+	_ = g.AddObj(exec)
+	_ = g.AddRelation(&MockDependenceRelation{
+		from:      MockObject{id: save, position: &MockPosition{FilenameVal: "f.go", LineVal: 30}},
+		dependsOn: MockObject{id: exec, position: &MockPosition{}},
+	})
+
+	mf := &MessageFlow{
+		relationDigraph: g,
+		startFuncs:      []string{"app/Service.*"},
+		endPkgPath:      "db",
+	}
+	s, err := mf.buildSequence()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	var messages []string
+	for _, m := range s.Messages() {
+		messages = append(messages, fmt.Sprintf("%s->%s:%s@%d", m.From(), m.To(), m.Name(), m.Pos().Line()))
+	}
+	expected := []string{
+		"app/Service->app:Validate@10",
+		"app->infra/Repo:Save@3",
+		"infra/Repo->db/Conn:Exec@30",
+		"app/Service->domain/Order:Place@20",
+		"domain/Order->infra/Repo:Save@7",
+	}
+	if !reflect.DeepEqual(messages, expected) {
+		t.Errorf("Expected the calls of the shared Save once %v, but got %v", expected, messages)
+	}
+}
+
+func TestMessageFlow_BuildSequenceNoPath(t *testing.T) {
+	mf := &MessageFlow{
+		relationDigraph: newSequenceDigraph(),
+		startFuncs:      []string{"infra/Repo.Save"},
+		endPkgPath:      "domain",
+	}
+
+	if _, err := mf.buildSequence(); err == nil {
+		t.Error("Expected an error when no path is found, but got nil")
+	}
+}
+
+func TestPositionLess(t *testing.T) {
+	a := &MockPosition{FilenameVal: "a.go", LineVal: 9}
+	b := &MockPosition{FilenameVal: "b.go", LineVal: 1}
+	c := &MockPosition{FilenameVal: "b.go", LineVal: 1, ColumnVal: 4}
+
+	tests := []struct {
+		x, y     arch.Position
+		expected bool
+	}{
+		{a, b, true},
+		{b, a, false},
+		{b, c, true},
+		{a, nil, true},
+		{nil, a, false},
+	}
+	for _, tt := range tests {
+		if got := positionLess(tt.x, tt.y); got != tt.expected {
+			t.Errorf("positionLess(%v, %v) = %v, want %v", tt.x, tt.y, got, tt.expected)
+		}
+	}
+}
+
+func TestMessageFlow_BuildSequenceRoutes(t *testing.T) {
+	handle := &MockObjIdentifier{id: "app/Service.Handle", name: "Service.Handle", dir: "app"}
+	mf := &MessageFlow{
+		relationDigraph: newSequenceDigraph(),
+		startFuncs:      []string{"GET /*"},
+		endPkgPath:      "domain",
+		modulePath:      "example.com/app",
+		search:          valueobject.FlowSearch{Routes: true},
+		routes: []*valueobject.Route{
+			{Method: "GET", Path: "/orders", Handler: handle, Pos: &MockPosition{FilenameVal: "api.go", LineVal: 4}},
+			{Method: "POST", Path: "/orders", Handler: handle},
+		},
+	}
+
+	s, err := mf.buildSequence()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	var messages []string
+	for _, m := range s.Messages() {
+		messages = append(messages, fmt.Sprintf("%s->%s:%s@%d", m.From(), m.To(), m.Name(), m.Pos().Line()))
+	}
+	expected := []string{
+		"GET /orders->app/Service:Handle@4",
+		"app/Service->domain/Order:Place@20",
+	}
+	if !reflect.DeepEqual(messages, expected) {
+		t.Errorf("Expected messages %v, but got %v", expected, messages)
+	}
+	if p := s.Participants()[0]; p.ID() != "GET /orders" || p.Color() != string(arch.ColorGeneral) {
+		t.Errorf("Expected the route as first participant, but got %s %s", p.ID(), p.Color())
+	}
+}
@@ -0,0 +1,211 @@
+package entity
+
+import (
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"github.com/dddplayer/dp/pkg/datastructure/intimacy"
+	"sort"
+)
+
+type AggregateSuggester struct {
+	aggregates      []*valueobject.AggregateGroup
+	relationDigraph *RelationDigraph
+	threshold       float64
+
+	units map[string]*valueobject.SuggestedMember
+	ids   []string
+}
+
+func NewAggregateSuggester(ags []*valueobject.AggregateGroup, g *RelationDigraph, threshold float64) *AggregateSuggester {
+	as := &AggregateSuggester{
+		aggregates:      ags,
+		relationDigraph: g,
+		threshold:       threshold,
+		units:           make(map[string]*valueobject.SuggestedMember),
+	}
+
+	for _, ag := range ags {
+		for _, sg := range ag.SubGroups() {
+			var objs []arch.DomainObj
+			switch g := sg.(type) {
+			case *valueobject.EntityGroup:
+				for _, e := range g.Entities() {
+					objs = append(objs, e)
+				}
+			case *valueobject.VOGroup:
+				for _, vo := range g.ValueObjects() {
+					objs = append(objs, vo)
+				}
+			}
+			for _, o := range objs {
+				id := o.OriginIdentifier().ID()
+				as.units[id] = &valueobject.SuggestedMember{Object: o, Aggregate: ag.Name()}
+				as.ids = append(as.ids, id)
+			}
+		}
+	}
+	sort.Strings(as.ids)
+
+	return as
+}
+
+func (as *AggregateSuggester) Suggest() []*valueobject.SuggestedAggregate {
+	ig := intimacy.NewGraph()
+	linked := make(map[[2]string]bool)
+	var pairs [][2]string
+
+	for _, n := range as.relationDigraph.Nodes {
+		from := as.unitOf(n.Value.(arch.ObjIdentifier))
+		if from == "" {
+			continue
+		}
+		for _, e := range n.Edges {
+			switch e.Type.(arch.RelationType) {
+			case arch.RelationTypeDependency, arch.RelationTypeEmbedding, arch.RelationTypeAssociation,
+				arch.RelationTypeAssociationOneOne, arch.RelationTypeAssociationOneMany:
+			default:
+				continue
+			}
+			to := as.unitOf(e.To.Value.(arch.ObjIdentifier))
+			if to == "" || to == from {
+				continue
+			}
+			_ = ig.IntimacyPlusOne(from, to)
+
+			p := [2]string{from, to}
+			if to < from {
+				p = [2]string{to, from}
+			}
+			if !linked[p] {
+				linked[p] = true
+				pairs = append(pairs, p)
+			}
+		}
+	}
+
+	parent := make(map[string]string)
+	var find func(string) string
+	find = func(id string) string {
+		if p, ok := parent[id]; ok && p != id {
+			parent[id] = find(p)
+			return parent[id]
+		}
+		return id
+	}
+	for _, p := range pairs {
+		if ig.Intimacy(p[0], p[1]) < as.threshold {
+			continue
+		}
+		r0, r1 := find(p[0]), find(p[1])
+		if r0 != r1 {
+			if r1 < r0 {
+				r0, r1 = r1, r0
+			}
+			parent[r1] = r0
+		}
+	}
+
+	clusters := make(map[string][]string)
+	var roots []string
+	for _, id := range as.ids {
+		r := find(id)
+		if _, ok := clusters[r]; !ok {
+			roots = append(roots, r)
+		}
+		clusters[r] = append(clusters[r], id)
+	}
+
+	var suggestions []*valueobject.SuggestedAggregate
+	for _, r := range roots {
+		if len(clusters[r]) < 2 {
+			continue
+		}
+		suggestions = append(suggestions, as.suggestion(clusters[r]))
+	}
+
+	return suggestions
+}
+
+func (as *AggregateSuggester) suggestion(ids []string) *valueobject.SuggestedAggregate {
+	counts := make(map[string]int)
+	for _, id := range ids {
+		counts[as.units[id].Aggregate]++
+	}
+	var name string
+	for ag, c := range counts {
+		if c > counts[name] || (c == counts[name] && ag < name) {
+			name = ag
+		}
+	}
+
+	sa := &valueobject.SuggestedAggregate{Name: name}
+	for _, id := range ids {
+		u := as.units[id]
+		sa.Members = append(sa.Members, &valueobject.SuggestedMember{
+			Object:    u.Object,
+			Aggregate: u.Aggregate,
+			Misplaced: u.Aggregate != name,
+		})
+	}
+	return sa
+}
+
+func (as *AggregateSuggester) unitOf(id arch.ObjIdentifier) string {
+	if _, ok := as.units[id.ID()]; ok {
+		return id.ID()
+	}
+	if owner := ownerID(id); owner != "" {
+		if _, ok := as.units[owner]; ok {
+			return owner
+		}
+	}
+	return ""
+}
+
+func (as *AggregateSuggester) buildDiagram(name string, suggestions []*valueobject.SuggestedAggregate) (*Diagram, error) {
+	g, err := NewDiagram(name, arch.PlainDiagram)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[string]*valueobject.SuggestedMember)
+	clusterOf := make(map[string]int)
+	for i, sa := range suggestions {
+		key := fmt.Sprintf("suggested %d: %s", i+1, sa.Name)
+		if err := g.AddStringTo(key, g.Name(), arch.RelationTypeAggregationRoot); err != nil {
+			return nil, err
+		}
+		for _, m := range sa.Members {
+			if err := g.AddObjTo(m.Object, key, arch.RelationTypeAggregation); err != nil {
+				return nil, err
+			}
+			members[m.Object.Identifier().ID()] = m
+			clusterOf[m.Object.Identifier().ID()] = i
+		}
+	}
+
+	for _, comb := range generateCombinations(g.Objects()) {
+		first := members[comb.First.Identifier().ID()]
+		second := members[comb.Second.Identifier().ID()]
+
+		metas, err := as.relationDigraph.SummaryRelationMetas(
+			first.Object.OriginIdentifier(), second.Object.OriginIdentifier())
+		if err != nil {
+			return nil, err
+		}
+		if len(metas) == 0 {
+			continue
+		}
+
+		fromId, toId := comb.First.Identifier().ID(), comb.Second.Identifier().ID()
+		if err := g.AddRelations(fromId, toId, metas); err != nil {
+			return nil, err
+		}
+		if clusterOf[fromId] == clusterOf[toId] && first.Aggregate != second.Aggregate {
+			g.Highlight(fromId, toId)
+		}
+	}
+
+	return g, nil
+}
@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"github.com/dddplayer/dp/pkg/datastructure/directed"
+	"path"
+	"testing"
+)
+
+func newMockSuggester(t *testing.T) *AggregateSuggester {
+	orderDomain := "test/internal/domain/order"
+	customerDomain := "test/internal/domain/customer"
+
+	order := newMockClassWithName(path.Join(orderDomain, "entity"), "Order")
+	line := newMockClassWithName(path.Join(orderDomain, "entity"), "Line")
+	customer := newMockClassWithName(path.Join(customerDomain, "entity"), "Customer")
+	address := newMockClassWithName(path.Join(customerDomain, "valueobject"), "Address")
+
+	orderAg := valueobject.NewAggregateGroup(&valueobject.Aggregate{Name: "order"}, orderDomain)
+	orderAg.AppendGroups(valueobject.NewEntityGroup(orderDomain, order, line))
+	customerAg := valueobject.NewAggregateGroup(&valueobject.Aggregate{Name: "customer"}, customerDomain)
+	customerAg.AppendGroups(
+		valueobject.NewEntityGroup(customerDomain, customer),
+		valueobject.NewVOGroup(customerDomain, address))
+
+	g := &RelationDigraph{Graph: directed.NewDirectedGraph()}
+	for _, o := range []arch.Object{order, line, customer, address} {
+		if err := g.AddObj(o.Identifier()); err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+	}
+	rels := []arch.Relation{
+		&MockAssociationRelation{from: order, refer: line, associationType: arch.RelationTypeAssociationOneMany},
+		&MockAssociationRelation{from: order, refer: line, associationType: arch.RelationTypeAssociationOneMany},
+		&MockAssociationRelation{from: order, refer: address, associationType: arch.RelationTypeAssociationOneOne},
+		&MockAssociationRelation{from: order, refer: address, associationType: arch.RelationTypeAssociationOneOne},
+		&MockAssociationRelation{from: customer, refer: order, associationType: arch.RelationTypeAssociation},
+	}
+	for _, r := range rels {
+		if err := g.AddRelation(r); err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+	}
+
+	return NewAggregateSuggester([]*valueobject.AggregateGroup{orderAg, customerAg}, g, 1.5)
+}
+
+func TestAggregateSuggester_Suggest(t *testing.T) {
+	suggestions := newMockSuggester(t).Suggest()
+	if len(suggestions) != 1 {
+		t.Fatalf("Expected 1 suggested aggregate, but got %d", len(suggestions))
+	}
+
+	sa := suggestions[0]
+	if sa.Name != "order" {
+		t.Errorf("Expected suggested aggregate order, but got %s", sa.Name)
+	}
+	if len(sa.Members) != 3 {
+		t.Fatalf("Expected 3 members, but got %d", len(sa.Members))
+	}
+	for _, m := range sa.Members {
+		misplaced := m.Object.OriginIdentifier().Name() == "Address"
+		if m.Misplaced != misplaced {
+			t.Errorf("Expected %s misplaced %v, but got %v", m.Object.OriginIdentifier().ID(), misplaced, m.Misplaced)
+		}
+		if m.Object.OriginIdentifier().Name() == "Customer" {
+			t.Error("Expected loosely coupled Customer to stay out of the cluster")
+		}
+	}
+}
+
+func TestAggregateSuggester_buildDiagram(t *testing.T) {
+	as := newMockSuggester(t)
+
+	g, err := as.buildDiagram("test", as.Suggest())
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	var highlighted, relations int
+	for _, e := range g.Edges() {
+		if e.Type() == arch.RelationTypeAggregationRoot {
+			continue
+		}
+		relations++
+		if e.Highlighted() {
+			highlighted++
+		}
+	}
+	if relations != 2 {
+		t.Errorf("Expected 2 relations, but got %d", relations)
+	}
+	if highlighted != 1 {
+		t.Errorf("Expected 1 highlighted relation, but got %d", highlighted)
+	}
+}
@@ -0,0 +1,68 @@
+package factory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"path"
+	"strings"
+)
+
+type layoutFile struct {
+	Layout struct {
+		Domain         string   `json:"domain"`
+		Aggregate      []string `json:"aggregate"`
+		Entity         []string `json:"entity"`
+		ValueObject    []string `json:"valueobject"`
+		Repository     []string `json:"repository"`
+		Factory        []string `json:"factory"`
+		Application    []string `json:"application"`
+		Infrastructure []string `json:"infrastructure"`
+		Interfaces     []string `json:"interfaces"`
+	} `json:"layout"`
+}
+
+func NewLayout(data []byte) (*valueobject.Layout, error) {
+	var lf layoutFile
+	if err := json.Unmarshal(data, &lf); err != nil {
+		return nil, err
+	}
+
+	l := lf.Layout
+	domain := strings.Trim(path.Clean(l.Domain), "/")
+	if l.Domain == "" || domain == "." {
+		return nil, errors.New("layout: domain cannot be empty")
+	}
+
+	roles := map[arch.HexagonDirectory][]string{
+		arch.HexagonDirectoryAggregate:      withDefault(l.Aggregate, path.Join(domain, "*")),
+		arch.HexagonDirectoryEntity:         withDefault(l.Entity, path.Join(domain, "*", string(arch.HexagonDirectoryEntity))),
+		arch.HexagonDirectoryValueObject:    withDefault(l.ValueObject, path.Join(domain, "*", string(arch.HexagonDirectoryValueObject))),
+		arch.HexagonDirectoryRepository:     withDefault(l.Repository, path.Join(domain, "*", string(arch.HexagonDirectoryRepository))),
+		arch.HexagonDirectoryFactory:        withDefault(l.Factory, path.Join(domain, "*", string(arch.HexagonDirectoryFactory))),
+		arch.HexagonDirectoryApplication:    l.Application,
+		arch.HexagonDirectoryInfrastructure: l.Infrastructure,
+		arch.HexagonDirectoryInterfaces:     l.Interfaces,
+	}
+	for role, patterns := range roles {
+		for _, p := range patterns {
+			if _, err := path.Match(p, ""); err != nil {
+				return nil, fmt.Errorf("layout: invalid %s pattern %q: %w", role, p, err)
+			}
+		}
+	}
+
+	return &valueobject.Layout{
+		Domain: domain,
+		Roles:  roles,
+	}, nil
+}
+
+func withDefault(patterns []string, def string) []string {
+	if len(patterns) == 0 {
+		return []string{def}
+	}
+	return patterns
+}
@@ -0,0 +1,54 @@
+package factory
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"testing"
+)
+
+func TestNewLayout(t *testing.T) {
+	data := []byte(`{
+  "layout": {
+    "domain": "internal/core/",
+    "entity": ["internal/core/*/model"],
+    "valueobject": ["internal/core/*/vo"],
+    "repository": ["internal/core/*/repo"],
+    "infrastructure": ["internal/infra"]
+  }
+}`)
+
+	l, err := NewLayout(data)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if l.Domain != "internal/core" {
+		t.Errorf("Expected domain internal/core, but got %s", l.Domain)
+	}
+
+	tests := map[string]arch.HexagonDirectory{
+		"internal/core/order":          arch.HexagonDirectoryAggregate,
+		"internal/core/order/model":    arch.HexagonDirectoryEntity,
+		"internal/core/order/vo":       arch.HexagonDirectoryValueObject,
+		"internal/core/order/repo":     arch.HexagonDirectoryRepository,
+		"internal/core/order/factory":  arch.HexagonDirectoryFactory,
+		"internal/infra":               arch.HexagonDirectoryInfrastructure,
+		"internal/core/order/model/db": arch.HexagonDirectoryInvalid,
+	}
+	for dir, expected := range tests {
+		if res := l.Role(dir); res != expected {
+			t.Errorf("Role(%q) = %s, expected %s", dir, res, expected)
+		}
+	}
+}
+
+func TestNewLayout_Error(t *testing.T) {
+	tests := []string{
+		`{"layout": [`,
+		`{"layout": {"entity": ["model"]}}`,
+		`{"layout": {"domain": "internal/core", "entity": ["[model"]}}`,
+	}
+	for _, data := range tests {
+		if _, err := NewLayout([]byte(data)); err == nil {
+			t.Errorf("Expected error for %s", data)
+		}
+	}
+}
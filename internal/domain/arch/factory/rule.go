@@ -0,0 +1,49 @@
+package factory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+)
+
+type rulesFile struct {
+	Rules []struct {
+		Name   string   `json:"name"`
+		From   string   `json:"from"`
+		Allow  []string `json:"allow"`
+		Forbid []string `json:"forbid"`
+	} `json:"rules"`
+}
+
+func NewRules(data []byte) ([]*valueobject.Rule, error) {
+	var rf rulesFile
+	if err := json.Unmarshal(data, &rf); err != nil {
+		return nil, err
+	}
+	if len(rf.Rules) == 0 {
+		return nil, errors.New("no rules defined")
+	}
+
+	var rules []*valueobject.Rule
+	for i, r := range rf.Rules {
+		if r.From == "" {
+			return nil, fmt.Errorf("rule %d: from cannot be empty", i+1)
+		}
+		if len(r.Allow) == 0 && len(r.Forbid) == 0 {
+			return nil, fmt.Errorf("rule %d: allow or forbid must be specified", i+1)
+		}
+		name := r.Name
+		if name == "" {
+			name = fmt.Sprintf("rule %d", i+1)
+		}
+		rules = append(rules, &valueobject.Rule{
+			Name:   name,
+			From:   r.From,
+			Allow:  r.Allow,
+			Forbid: r.Forbid,
+		})
+	}
+
+	return rules, nil
+}
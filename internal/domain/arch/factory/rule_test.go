@@ -0,0 +1,40 @@
+package factory
+
+import "testing"
+
+func TestNewRules(t *testing.T) {
+	data := []byte(`{
+  "rules": [
+    {"name": "domain independent", "from": "domain", "forbid": ["infrastructure"]},
+    {"from": "interfaces", "allow": ["application"]}
+  ]
+}`)
+
+	rules, err := NewRules(data)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("Expected 2 rules, but got %d", len(rules))
+	}
+	if rules[0].Name != "domain independent" || rules[0].Forbid[0] != "infrastructure" {
+		t.Errorf("Unexpected rule %+v", rules[0])
+	}
+	if rules[1].Name != "rule 2" || rules[1].Allow[0] != "application" {
+		t.Errorf("Unexpected rule %+v", rules[1])
+	}
+}
+
+func TestNewRules_Error(t *testing.T) {
+	tests := []string{
+		`not json`,
+		`{"rules": []}`,
+		`{"rules": [{"forbid": ["infrastructure"]}]}`,
+		`{"rules": [{"from": "domain"}]}`,
+	}
+	for _, data := range tests {
+		if _, err := NewRules([]byte(data)); err == nil {
+			t.Errorf("Expected error for %s", data)
+		}
+	}
+}
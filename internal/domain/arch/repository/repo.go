@@ -7,10 +7,13 @@ type ObjectRepository interface {
 	GetObjects(ids []arch.ObjIdentifier) ([]arch.Object, error)
 	All() []arch.ObjIdentifier
 	Insert(obj arch.Object) error
+	Update(obj arch.Object) error
+	Delete(id arch.ObjIdentifier) error
 	Walk(walker func(obj arch.Object) error)
 }
 
 type RelationRepository interface {
 	Insert(rel arch.Relation) error
+	Delete(match func(rel arch.Relation) bool) int
 	Walk(walker func(rel arch.Relation) error)
 }
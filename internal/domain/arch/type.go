@@ -30,6 +30,22 @@ const (
 	HexagonDirectoryInvalid        HexagonDirectory = "invalid"
 )
 
+type DomainRole string
+
+const (
+	DomainRoleAggregateRoot DomainRole = "aggregate-root"
+	DomainRoleEntity        DomainRole = "entity"
+	DomainRoleValueObject   DomainRole = "valueobject"
+	DomainRoleDomainEvent   DomainRole = "domain-event"
+	DomainRoleCommand       DomainRole = "command"
+	DomainRoleRepository    DomainRole = "repository"
+)
+
+type Annotated interface {
+	Roles() []DomainRole
+	HasRole(role DomainRole) bool
+}
+
 type ObjectWalker func(Object) error
 
 type Object interface {
@@ -71,6 +87,10 @@ const (
 	RelationTypeAbstraction
 	RelationTypeAttribution
 	RelationTypeBehavior
+	RelationTypeInstantiation
+	RelationTypeParameter
+	RelationTypeResult
+	RelationTypeInterfaceEmbedding
 	RelationTypeNone
 )
 
@@ -92,6 +112,7 @@ type Relation interface {
 type DependenceRelation interface {
 	Relation
 	DependsOn() Object
+	Algorithm() string
 }
 
 type CompositionRelation interface {
@@ -116,6 +137,11 @@ type AssociationRelation interface {
 	AssociationType() RelationType
 }
 
+type UsageRelation interface {
+	Relation
+	Used() Object
+}
+
 type DiagramType uint8
 
 const (
@@ -156,6 +182,20 @@ type Edge interface {
 	Count() int
 	Type() RelationType
 	Pos() []RelationPos
+	Highlighted() bool
+}
+
+type Sequence interface {
+	Name() string
+	Participants() []Node
+	Messages() []Message
+}
+
+type Message interface {
+	From() string
+	To() string
+	Name() string
+	Pos() Position
 }
 
 type ObjColor string
@@ -173,6 +213,11 @@ const (
 	ColorClass       ObjColor = "#b4a7d6ff"
 	ColorGeneral     ObjColor = "#f4ccccff"
 	ColorFunc        ObjColor = "#ead1dcff"
+	ColorCommand     ObjColor = "#a4c2f4ff"
+	ColorEvent       ObjColor = "#f6b26bff"
+	ColorAdded       ObjColor = "#b6d7a8ff"
+	ColorRemoved     ObjColor = "#ea9999ff"
+	ColorChanged     ObjColor = "#f9cb9cff"
 )
 
 type Domain interface {
@@ -0,0 +1,20 @@
+package valueobject
+
+import "github.com/dddplayer/dp/internal/domain/arch"
+
+type BoundaryViolationKind string
+
+const (
+	BoundaryViolationReference BoundaryViolationKind = "reference"
+	BoundaryViolationCall      BoundaryViolationKind = "call"
+)
+
+type BoundaryViolation struct {
+	Kind          BoundaryViolationKind
+	From          arch.ObjIdentifier
+	To            arch.ObjIdentifier
+	FromAggregate string
+	ToAggregate   string
+	Type          arch.RelationType
+	Pos           arch.RelationPos
+}
@@ -0,0 +1,41 @@
+package valueobject
+
+import "github.com/dddplayer/dp/internal/domain/arch"
+
+type EntryKind string
+
+const (
+	EntryMain        EntryKind = "main"
+	EntryHandler     EntryKind = "handler"
+	EntryApplication EntryKind = "application"
+)
+
+// Caller is a function of a caller tree, calling its callee at Pos.
+// The target functions are the roots of the trees and have no Pos.
+type Caller struct {
+	Function arch.ObjIdentifier
+	Pos      arch.Position
+	Entry    EntryKind
+	Callers  []*Caller
+	// Repeated callers are expanded earlier in the tree, Truncated ones reach the depth limit.
+	Repeated  bool
+	Truncated bool
+}
+
+// Entries returns the entry points of the tree, each one once.
+func (c *Caller) Entries() []*Caller {
+	var entries []*Caller
+	seen := make(map[string]bool)
+	var walk func(c *Caller)
+	walk = func(c *Caller) {
+		if c.Entry != "" && !seen[c.Function.ID()] {
+			seen[c.Function.ID()] = true
+			entries = append(entries, c)
+		}
+		for _, cc := range c.Callers {
+			walk(cc)
+		}
+	}
+	walk(c)
+	return entries
+}
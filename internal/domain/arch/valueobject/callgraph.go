@@ -0,0 +1,11 @@
+package valueobject
+
+import "github.com/dddplayer/dp/internal/domain/arch"
+
+type CallDiff struct {
+	Base      string
+	Other     string
+	Common    int
+	OnlyBase  []arch.DependenceRelation
+	OnlyOther []arch.DependenceRelation
+}
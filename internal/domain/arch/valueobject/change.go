@@ -0,0 +1,38 @@
+package valueobject
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"path"
+)
+
+type ChangeStatus string
+
+const (
+	ChangeStatusAdded     ChangeStatus = "added"
+	ChangeStatusRemoved   ChangeStatus = "removed"
+	ChangeStatusChanged   ChangeStatus = "changed"
+	ChangeStatusUnchanged ChangeStatus = "unchanged"
+)
+
+// ChangedObj is an object compared between two revisions, identified by its object id.
+type ChangedObj struct {
+	*obj
+	Status ChangeStatus
+}
+
+func NewChangedObj(id string, status ChangeStatus) *ChangedObj {
+	return &ChangedObj{
+		obj: &obj{
+			id:  &ident{name: path.Base(id), pkg: path.Dir(id)},
+			pos: emptyPosition(),
+		},
+		Status: status,
+	}
+}
+
+type ChangedRelation struct {
+	From   string
+	To     string
+	Type   arch.RelationType
+	Status ChangeStatus
+}
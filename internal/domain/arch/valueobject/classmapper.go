@@ -0,0 +1,141 @@
+package valueobject
+
+import (
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"path"
+)
+
+const (
+	StereotypeAggregateRoot = "AggregateRoot"
+	StereotypeEntity        = "Entity"
+	StereotypeValueObject   = "ValueObject"
+	StereotypeInterface     = "Interface"
+)
+
+const (
+	MultiplicityOne  = "1"
+	MultiplicityMany = "*"
+)
+
+// DiagramClass is an element of a table diagram drawn as a class, with the names of its members.
+type DiagramClass struct {
+	ID         string
+	Name       string
+	Color      string
+	Stereotype string
+	Attributes []string
+	Methods    []string
+}
+
+// DiagramClassEdge merges the edges of one type between the members of two classes.
+type DiagramClassEdge struct {
+	From             string
+	To               string
+	Type             arch.RelationType
+	Count            int
+	Highlighted      bool
+	FromMultiplicity string
+	ToMultiplicity   string
+}
+
+// ClassMapper maps the elements of a table diagram to classes, and the edges between their members
+// to edges between the classes, for the renderers drawing class diagrams.
+type ClassMapper struct {
+	owners map[string]string
+}
+
+func NewClassMapper() *ClassMapper {
+	return &ClassMapper{owners: make(map[string]string)}
+}
+
+// Class maps e to a class, which owns the members of e from now on.
+func (cm *ClassMapper) Class(e arch.Element) *DiagramClass {
+	c := &DiagramClass{
+		ID:         e.ID(),
+		Name:       path.Base(e.Name()),
+		Color:      e.Color(),
+		Stereotype: Stereotype(arch.ObjColor(e.Color())),
+		Attributes: []string{},
+		Methods:    []string{},
+	}
+	cm.owners[e.ID()] = c.ID
+
+	children := e.Children()
+	switch len(children) {
+	case 1:
+		for _, n := range children[0] {
+			if arch.ObjColor(n.Color()) == arch.ColorFunc {
+				c.Methods = append(c.Methods, path.Base(n.Name()))
+			} else {
+				c.Attributes = append(c.Attributes, path.Base(n.Name()))
+			}
+			cm.owners[n.ID()] = c.ID
+		}
+	case 2:
+		for _, n := range children[0] {
+			c.Methods = append(c.Methods, n.Name())
+			cm.owners[n.ID()] = c.ID
+		}
+		for _, n := range children[1] {
+			c.Attributes = append(c.Attributes, n.Name())
+			cm.owners[n.ID()] = c.ID
+		}
+	}
+
+	return c
+}
+
+// Edges merges the edges between the classes mapped so far, in the order they first appear.
+// Edges within a class or to an element which is no class are dropped.
+func (cm *ClassMapper) Edges(edges []arch.Edge) []*DiagramClassEdge {
+	merged := make(map[string]*DiagramClassEdge)
+	var result []*DiagramClassEdge
+
+	for _, e := range edges {
+		from, fromOk := cm.owners[e.From()]
+		to, toOk := cm.owners[e.To()]
+		if !fromOk || !toOk || from == to {
+			continue
+		}
+
+		key := fmt.Sprintf("%s-%s-%d", from, to, e.Type())
+		edge, ok := merged[key]
+		if !ok {
+			edge = &DiagramClassEdge{From: from, To: to, Type: e.Type()}
+			edge.FromMultiplicity, edge.ToMultiplicity = Multiplicity(e.Type())
+			merged[key] = edge
+			result = append(result, edge)
+		}
+		edge.Count += e.Count()
+		if e.Highlighted() {
+			edge.Highlighted = true
+		}
+	}
+
+	return result
+}
+
+func Stereotype(c arch.ObjColor) string {
+	switch c {
+	case arch.ColorAggregate:
+		return StereotypeAggregateRoot
+	case arch.ColorEntity:
+		return StereotypeEntity
+	case arch.ColorValueObject:
+		return StereotypeValueObject
+	case arch.ColorInterface:
+		return StereotypeInterface
+	}
+	return ""
+}
+
+func Multiplicity(t arch.RelationType) (string, string) {
+	switch t {
+	case arch.RelationTypeAssociationOneOne:
+		return MultiplicityOne, MultiplicityOne
+	case arch.RelationTypeAssociationOneMany:
+		return MultiplicityOne, MultiplicityMany
+	}
+	return "", ""
+}
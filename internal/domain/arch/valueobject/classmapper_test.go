@@ -0,0 +1,71 @@
+package valueobject
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/archtest"
+	"reflect"
+	"testing"
+)
+
+func TestClassMapper_Class(t *testing.T) {
+	d := archtest.TableDiagram()
+	cm := NewClassMapper()
+
+	order := cm.Class(d.SubDiagramsVal[0].Summary()[0])
+	if order.ID != "order.Order" || order.Name != "Order" || order.Stereotype != StereotypeAggregateRoot {
+		t.Errorf("Unexpected class %+v", order)
+	}
+	if !reflect.DeepEqual(order.Methods, []string{"Pay"}) || !reflect.DeepEqual(order.Attributes, []string{"Items"}) {
+		t.Errorf("Unexpected members %v %v", order.Methods, order.Attributes)
+	}
+
+	item := cm.Class(d.SubDiagramsVal[0].Summary()[1])
+	if len(item.Methods) != 0 || !reflect.DeepEqual(item.Attributes, []string{"Price"}) {
+		t.Errorf("Unexpected members %v %v", item.Methods, item.Attributes)
+	}
+}
+
+func TestClassMapper_Edges(t *testing.T) {
+	d := archtest.TableDiagram()
+	d.EdgesVal[0].(*archtest.DummyEdge).HighlightedVal = true
+	cm := NewClassMapper()
+	for _, e := range d.SubDiagramsVal[0].Summary() {
+		cm.Class(e)
+	}
+
+	// 同一对类之间的同类依赖合并，自身及未知端点忽略
+	edges := cm.Edges(d.Edges())
+	expected := []*DiagramClassEdge{
+		{From: "order.Order", To: "order.Item", Type: arch.RelationTypeAssociationOneMany, Count: 1,
+			Highlighted: true, FromMultiplicity: MultiplicityOne, ToMultiplicity: MultiplicityMany},
+		{From: "order.Order", To: "order.Item", Type: arch.RelationTypeDependency, Count: 3},
+	}
+	if !reflect.DeepEqual(edges, expected) {
+		t.Errorf("Expected edges %+v, but got %+v", expected, edges)
+	}
+
+	if edges := NewClassMapper().Edges(d.Edges()); len(edges) != 0 {
+		t.Errorf("Expected no edges without classes, but got %+v", edges)
+	}
+}
+
+func TestMultiplicity(t *testing.T) {
+	if f, to := Multiplicity(arch.RelationTypeAssociationOneOne); f != "1" || to != "1" {
+		t.Errorf("Unexpected one-one multiplicity %s %s", f, to)
+	}
+	if f, to := Multiplicity(arch.RelationTypeAssociationOneMany); f != "1" || to != "*" {
+		t.Errorf("Unexpected one-many multiplicity %s %s", f, to)
+	}
+	if f, to := Multiplicity(arch.RelationTypeDependency); f != "" || to != "" {
+		t.Errorf("Unexpected dependency multiplicity %s %s", f, to)
+	}
+}
+
+func TestStereotype(t *testing.T) {
+	if s := Stereotype(arch.ColorValueObject); s != StereotypeValueObject {
+		t.Errorf("Expected ValueObject, but got %s", s)
+	}
+	if s := Stereotype(arch.ColorFunc); s != "" {
+		t.Errorf("Expected empty stereotype, but got %s", s)
+	}
+}
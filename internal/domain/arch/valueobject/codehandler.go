@@ -13,6 +13,11 @@ type CodeHandler struct {
 	ObjRepo repository.ObjectRepository
 	RelRepo repository.RelationRepository
 	errors  []error
+	routes  []*Route
+}
+
+func (ch *CodeHandler) Routes() []*Route {
+	return ch.routes
 }
 
 func (ch *CodeHandler) pushError(err error) {
@@ -23,11 +28,13 @@ func (ch *CodeHandler) NodeHandler(node *code.Node) {
 	id := newIdentifier(node.Meta)
 	pos := newPosition(node.Pos)
 
+	roles := newRoles(node.Annotations)
+
 	switch node.Type {
 	case code.TypeGenIdent, code.TypeGenFunc, code.TypeGenArray, code.TypeGenMap:
-		ch.handleGenObj(id, pos)
+		ch.handleGenObj(id, pos, roles)
 	case code.TypeGenStruct:
-		ch.handleClass(id, pos)
+		ch.handleClass(id, pos, roles)
 	case code.TypeGenStructField, code.TypeGenStructEmbeddedField:
 		if node.Parent == nil {
 			ch.pushError(fmt.Errorf("struct field:%s without parent", id.name))
@@ -35,7 +42,7 @@ func (ch *CodeHandler) NodeHandler(node *code.Node) {
 		}
 		ch.handleAttribute(id, pos, newIdentifier(node.Parent.Meta))
 	case code.TypeGenInterface:
-		ch.handleInterface(id, pos)
+		ch.handleInterface(id, pos, roles)
 	case code.TypeGenInterfaceMethod:
 		if node.Parent == nil {
 			ch.pushError(fmt.Errorf("interface method:%s without parent", id.name))
@@ -48,14 +55,27 @@ func (ch *CodeHandler) NodeHandler(node *code.Node) {
 		} else {
 			ch.handleFunc(id, pos, nil, nil)
 		}
+		if node.Mutating {
+			if f, ok := ch.ObjRepo.Find(id).(*Function); ok {
+				f.Mutating = true
+			}
+		}
 	default:
-		ch.handleGenObj(id, pos)
+		ch.handleGenObj(id, pos, roles)
+	}
+}
+
+func newRoles(annotations []code.Annotation) []arch.DomainRole {
+	var roles []arch.DomainRole
+	for _, a := range annotations {
+		roles = append(roles, arch.DomainRole(a))
 	}
+	return roles
 }
 
-func (ch *CodeHandler) handleClass(id *ident, pos *pos) {
+func (ch *CodeHandler) handleClass(id *ident, pos *pos, roles []arch.DomainRole) {
 	c := &Class{
-		obj:     &obj{id: id, pos: pos},
+		obj:     &obj{id: id, pos: pos, roles: roles},
 		attrs:   []*ident{},
 		methods: []*ident{},
 	}
@@ -86,8 +106,8 @@ func (ch *CodeHandler) handleAttribute(id *ident, pos *pos, pid *ident) {
 	}
 }
 
-func (ch *CodeHandler) handleGenObj(id *ident, pos *pos) {
-	genObj := &General{&obj{id: id, pos: pos}}
+func (ch *CodeHandler) handleGenObj(id *ident, pos *pos, roles []arch.DomainRole) {
+	genObj := &General{&obj{id: id, pos: pos, roles: roles}}
 	if err := ch.ObjRepo.Insert(genObj); err != nil {
 		ch.pushError(err)
 	}
@@ -125,11 +145,12 @@ func (ch *CodeHandler) handleFunc(id *ident, pos *pos, pid *ident, parentPos *po
 	}
 }
 
-func (ch *CodeHandler) handleInterface(id *ident, pos *pos) {
+func (ch *CodeHandler) handleInterface(id *ident, pos *pos, roles []arch.DomainRole) {
 	i := &Interface{
 		obj: &obj{
-			id:  id,
-			pos: pos,
+			id:    id,
+			pos:   pos,
+			roles: roles,
 		},
 		methods: []*ident{},
 	}
@@ -156,6 +177,22 @@ func (ch *CodeHandler) handleInterfaceMethod(id *ident, pos *pos, pid *ident) {
 	}
 }
 
+func (ch *CodeHandler) RouteHandler(route *code.Route) {
+	id := newIdentifier(route.Handler.Meta)
+	id.fixTmpName()
+	pos := emptyPosition()
+	if route.Pos != nil {
+		pos = newPosition(route.Pos)
+	}
+	ch.routes = append(ch.routes, &Route{
+		Router:  route.Router,
+		Method:  route.Method,
+		Path:    route.Path,
+		Handler: id,
+		Pos:     pos,
+	})
+}
+
 func (ch *CodeHandler) LinkHandler(link *code.Link) {
 	if strings.Contains(link.From.Meta.Pkg(), ch.Scope) == false ||
 		strings.Contains(link.To.Meta.Pkg(), ch.Scope) == false {
@@ -182,13 +219,23 @@ func (ch *CodeHandler) LinkHandler(link *code.Link) {
 	case code.TypeAny | code.TypeGenInterface:
 		r = NewImplementation(&obj{id: fromId, pos: fromPos}, &obj{id: toId, pos: toPos})
 	case code.TypeFunc | code.TypeFunc:
-		r = NewDependence(&obj{id: fromId, pos: fromPos}, &obj{id: toId, pos: toPos})
+		r = NewCall(&obj{id: fromId, pos: fromPos}, &obj{id: toId, pos: toPos}, string(link.Algorithm))
 	case code.TypeGenStruct | code.TypeGenStructField,
 		code.TypeAny | code.TypeFunc,
 		code.TypeGenInterface | code.TypeGenInterfaceMethod:
 		r = NewComposition(&obj{id: fromId, pos: fromPos}, &obj{id: toId, pos: toPos})
 	case code.TypeGenStruct | code.TypeGenStructEmbeddedField:
 		r = NewEmbedding(&obj{id: fromId, pos: fromPos}, &obj{id: toId, pos: toPos})
+	case code.TypeGenInterface | code.TypeGenInterfaceEmbedded:
+		r = NewInterfaceEmbedding(&obj{id: fromId, pos: fromPos}, &obj{id: toId, pos: toPos})
+	case code.TypeFunc | code.TypeGenInstance:
+		r = NewInstantiation(&obj{id: fromId, pos: fromPos}, &obj{id: toId, pos: toPos})
+	case code.TypeFunc | code.TypeFuncParam,
+		code.TypeGenInterfaceMethod | code.TypeFuncParam:
+		r = NewParameter(&obj{id: fromId, pos: fromPos}, &obj{id: toId, pos: toPos})
+	case code.TypeFunc | code.TypeFuncResult,
+		code.TypeGenInterfaceMethod | code.TypeFuncResult:
+		r = NewResult(&obj{id: fromId, pos: fromPos}, &obj{id: toId, pos: toPos})
 	}
 
 	if err := ch.RelRepo.Insert(r); err != nil {
@@ -20,7 +20,7 @@ func TestDomainModel_HandleClass(t *testing.T) {
 	pos := &pos{filename: "myclass.go", offset: 10, line: 5, column: 15}
 
 	// call handleClass method
-	dm.handleClass(id, pos)
+	dm.handleClass(id, pos, nil)
 
 	// check if the repository was called with the correct object
 	if len(repo.data) != 1 {
@@ -50,7 +50,7 @@ func TestDomainModel_HandleClass(t *testing.T) {
 
 	// call handleClass method
 	repo.OpenErrStatus()
-	dm.handleClass(id, pos)
+	dm.handleClass(id, pos, nil)
 
 	// check if the repository was called with the correct object
 	if len(repo.data) != 1 {
@@ -89,7 +89,7 @@ func TestDomainModel_HandleClass_MissingReceiver(t *testing.T) {
 	_ = repo.Insert(missingReceiver)
 
 	// call handleClass method
-	dm.handleClass(id, pos)
+	dm.handleClass(id, pos, nil)
 
 	if len(dm.errors) != 0 {
 		t.Errorf("Expected 0 errors, but got %v", len(dm.errors))
@@ -192,7 +192,7 @@ func TestDomainModel_HandleGenObj(t *testing.T) {
 	pos := &pos{filename: "myobj.go", offset: 10, line: 5, column: 15}
 
 	// call handleGenObj method
-	dm.handleGenObj(id, pos)
+	dm.handleGenObj(id, pos, nil)
 
 	// check if the repository was called with the correct object
 	if len(repo.data) != 1 {
@@ -221,7 +221,7 @@ func TestDomainModel_HandleGenObj(t *testing.T) {
 	}
 
 	repo.OpenErrStatus()
-	dm.handleGenObj(id, pos)
+	dm.handleGenObj(id, pos, nil)
 	// check if an error was pushed to the errors slice
 	if len(dm.errors) != 1 {
 		t.Errorf("Expected 1 error, but got %v", len(dm.errors))
@@ -389,7 +389,7 @@ func TestDomainModel_HandleInterface(t *testing.T) {
 	pos := &pos{filename: "myinterface.go", offset: 10, line: 5, column: 15}
 
 	// call handleInterface method
-	dm.handleInterface(id, pos)
+	dm.handleInterface(id, pos, nil)
 
 	// check if the repository was called with the correct object
 	if len(repo.data) != 1 {
@@ -419,7 +419,7 @@ func TestDomainModel_HandleInterface(t *testing.T) {
 
 	repo.OpenErrStatus()
 	// call handleInterface method with an error-prone repository
-	dm.handleInterface(id, pos)
+	dm.handleInterface(id, pos, nil)
 
 	// check if an error was pushed to the errors slice
 	if len(dm.errors) != 1 {
@@ -596,7 +596,8 @@ func TestDomainModel_LinkHandler(t *testing.T) {
 				Meta: newDummyMetaWithIdent(toId),
 				Type: code.TypeFunc,
 			},
-			Relation: code.OneOne,
+			Relation:  code.OneOne,
+			Algorithm: code.CallGraphTypeCha,
 		}
 
 		// call LinkHandler method
@@ -616,6 +617,9 @@ func TestDomainModel_LinkHandler(t *testing.T) {
 			t.Errorf("Expected object in repository to have From %v and To %v, but got From %v and To %v",
 				fromId, toId, relation.From().Identifier().Name(), relation.DependsOn().Identifier().Name())
 		}
+		if relation.Algorithm() != string(code.CallGraphTypeCha) {
+			t.Errorf("Expected dependence found by %s, but got %q", code.CallGraphTypeCha, relation.Algorithm())
+		}
 
 		if relation.From().Position().Filename() != fromPos.Filename() ||
 			relation.From().Position().Offset() != fromPos.Offset() ||
@@ -716,6 +720,87 @@ func TestDomainModel_LinkHandler(t *testing.T) {
 			t.Errorf("Expected object in repository to have pos %v, but got %v", fromPos, relation.From().Position())
 		}
 	})
+
+	t.Run("Interface Embedding Link", func(t *testing.T) {
+		repo.Clear()
+
+		fromId := &ident{name: "Finder", pkg: "/test/order"}
+		fromPos := &pos{filename: "finder.go", offset: 10, line: 5, column: 6}
+		toId := &ident{name: "Repository", pkg: "/test/order"}
+		toPos := &pos{filename: "repository.go", offset: 20, line: 3, column: 6}
+
+		dm.LinkHandler(&code.Link{
+			From: &code.Node{
+				Meta: newDummyMetaWithIdent(fromId),
+				Pos:  fromPos,
+				Type: code.TypeGenInterface,
+			},
+			To: &code.Node{
+				Meta: newDummyMetaWithIdent(toId),
+				Pos:  toPos,
+				Type: code.TypeGenInterfaceEmbedded,
+			},
+			Relation: code.OneOne,
+		})
+
+		rel, ok := repo.Find(fromId).(*Embedding)
+		if !ok {
+			t.Fatalf("Expected repository to have Embedding relation %v, but got %T", fromId, repo.Find(fromId))
+		}
+		if rel.Type() != arch.RelationTypeInterfaceEmbedding {
+			t.Errorf("Expected relation type %v, but got %v", arch.RelationTypeInterfaceEmbedding, rel.Type())
+		}
+		if rel.Embedded().Identifier().Name() != toId.name || rel.Embedded().Position().Line() != toPos.Line() {
+			t.Errorf("Expected embedded interface %v at line %d, but got %v at line %d",
+				toId, toPos.Line(), rel.Embedded().Identifier().Name(), rel.Embedded().Position().Line())
+		}
+	})
+
+	t.Run("Usage Link", func(t *testing.T) {
+		for _, tc := range []struct {
+			name     string
+			fromType code.NodeType
+			toType   code.NodeType
+			expected arch.RelationType
+		}{
+			{"publishFunc", code.TypeFunc, code.TypeGenInstance, arch.RelationTypeInstantiation},
+			{"handleFunc", code.TypeFunc, code.TypeFuncParam, arch.RelationTypeParameter},
+			{"newFunc", code.TypeFunc, code.TypeFuncResult, arch.RelationTypeResult},
+			{"saveMethod", code.TypeGenInterfaceMethod, code.TypeFuncParam, arch.RelationTypeParameter},
+			{"findMethod", code.TypeGenInterfaceMethod, code.TypeFuncResult, arch.RelationTypeResult},
+		} {
+			fromId := &ident{name: tc.name, pkg: "/test/myService"}
+			fromPos := &pos{filename: "service.go", offset: 10, line: 5, column: 15}
+			toId := &ident{name: "myEvent", pkg: "/test/myEvent"}
+			toPos := &pos{filename: "service.go", offset: 30, line: 7, column: 9}
+
+			dm.LinkHandler(&code.Link{
+				From: &code.Node{
+					Meta: newDummyMetaWithIdent(fromId),
+					Pos:  fromPos,
+					Type: tc.fromType,
+				},
+				To: &code.Node{
+					Meta: newDummyMetaWithIdent(toId),
+					Pos:  toPos,
+					Type: tc.toType,
+				},
+				Relation: code.OneOne,
+			})
+
+			rel, ok := repo.Find(fromId).(*Usage)
+			if !ok {
+				t.Fatalf("Expected repository to have Usage relation %v, but got %T", fromId, repo.Find(fromId))
+			}
+			if rel.Type() != tc.expected {
+				t.Errorf("Expected relation type %v, but got %v", tc.expected, rel.Type())
+			}
+			if rel.Used().Identifier().Name() != toId.name || rel.Used().Position().Line() != toPos.Line() {
+				t.Errorf("Expected used object %v at line %d, but got %v at line %d",
+					toId, toPos.Line(), rel.Used().Identifier().Name(), rel.Used().Position().Line())
+			}
+		}
+	})
 }
 
 func TestDomainModel_LinkHandler_OutOfScope(t *testing.T) {
@@ -938,3 +1023,78 @@ func TestCodeHandler_pushError(t *testing.T) {
 		t.Errorf("Expected error 2, but got: %v", ch.errors[1])
 	}
 }
+
+func TestCodeHandler_NodeHandlerAnnotations(t *testing.T) {
+	repo := newMockRepository()
+	ch := &CodeHandler{Scope: "Test Model", ObjRepo: repo}
+
+	id := &ident{name: "Money", pkg: "/ddd/order"}
+	pos := &pos{filename: "money.go", offset: 10, line: 5, column: 15}
+	ch.NodeHandler(&code.Node{
+		Meta:        newDummyMetaWithIdent(id),
+		Pos:         pos,
+		Type:        code.TypeGenStruct,
+		Annotations: []code.Annotation{code.AnnotationValueObject},
+	})
+
+	cla, ok := repo.Find(id).(*Class)
+	if !ok {
+		t.Fatalf("Expected a Class in repository, but got %T", repo.Find(id))
+	}
+	if !cla.HasRole(arch.DomainRoleValueObject) || cla.HasRole(arch.DomainRoleEntity) {
+		t.Errorf("Expected only valueobject role, but got %v", cla.Roles())
+	}
+	if roles := NewObj(cla).roles; !reflect.DeepEqual(roles, cla.Roles()) {
+		t.Errorf("Expected NewObj to keep roles %v, but got %v", cla.Roles(), roles)
+	}
+}
+
+func TestCodeHandler_RouteHandler(t *testing.T) {
+	ch := &CodeHandler{Scope: "ddd"}
+
+	ch.RouteHandler(&code.Route{
+		Router:  "chi",
+		Method:  "GET",
+		Path:    "/orders",
+		Handler: &code.Node{Meta: newDummyMetaWithIdent(&ident{name: "Routes$1", pkg: "ddd/api"}), Type: code.TypeFunc},
+		Pos:     &pos{filename: "api.go", offset: 10, line: 5, column: 2},
+	})
+	ch.RouteHandler(&code.Route{
+		Router:  "net/http",
+		Path:    "/",
+		Handler: &code.Node{Meta: newDummyMetaWithIdent(&ident{name: "Index", pkg: "ddd/api"}), Type: code.TypeFunc},
+	})
+
+	routes := ch.Routes()
+	if len(routes) != 2 {
+		t.Fatalf("Expected 2 routes, but got %d", len(routes))
+	}
+	if routes[0].Name() != "GET /orders" || routes[0].Handler.ID() != "ddd/api/Routes" || routes[0].Pos.Line() != 5 {
+		t.Errorf("Expected GET /orders served by ddd/api/Routes at line 5, but got %s served by %s at line %d",
+			routes[0].Name(), routes[0].Handler.ID(), routes[0].Pos.Line())
+	}
+	if routes[1].Name() != "ANY /" || routes[1].Pos.Line() != -1 {
+		t.Errorf("Expected ANY / without position, but got %s at line %d", routes[1].Name(), routes[1].Pos.Line())
+	}
+}
+
+func TestCodeHandler_NodeHandlerMutating(t *testing.T) {
+	repo := newMockRepository()
+	ch := &CodeHandler{Scope: "ddd", ObjRepo: repo}
+
+	id := &ident{name: "Line.Cancel", pkg: "ddd/order"}
+	ch.NodeHandler(&code.Node{
+		Meta:     newDummyMetaWithIdent(id),
+		Pos:      &pos{filename: "line.go", line: 3},
+		Type:     code.TypeFunc,
+		Mutating: true,
+	})
+
+	f, ok := repo.Find(id).(*Function)
+	if !ok {
+		t.Fatalf("Expected a Function in repository, but got %T", repo.Find(id))
+	}
+	if !f.Mutating {
+		t.Errorf("Expected the method marked as mutating")
+	}
+}
@@ -0,0 +1,15 @@
+package valueobject
+
+import "github.com/dddplayer/dp/internal/domain/arch"
+
+type ComponentLink struct {
+	From arch.DomainObj
+	To   arch.DomainObj
+	Type arch.RelationType
+	Pos  arch.RelationPos
+}
+
+type RepositoryFinding struct {
+	Aggregate    string
+	Repositories []arch.ObjIdentifier
+}
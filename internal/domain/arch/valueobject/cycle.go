@@ -0,0 +1,23 @@
+package valueobject
+
+import "github.com/dddplayer/dp/internal/domain/arch"
+
+type CycleLevel string
+
+const (
+	CycleLevelPackage CycleLevel = "package"
+	CycleLevelObject  CycleLevel = "object"
+)
+
+type Cycle struct {
+	Level   CycleLevel
+	Members []string
+	Edges   []*CycleEdge
+}
+
+type CycleEdge struct {
+	From arch.ObjIdentifier
+	To   arch.ObjIdentifier
+	Type arch.RelationType
+	Pos  arch.RelationPos
+}
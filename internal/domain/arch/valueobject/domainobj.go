@@ -71,6 +71,24 @@ type Aggregate struct {
 	Name string
 }
 
+type Repository struct {
+	*domainObj
+	Methods []*DomainFunction
+}
+
+type Factory struct {
+	*domainObj
+	Methods []*DomainFunction
+}
+
+type DomainEvent struct {
+	*DomainClass
+}
+
+type DomainCommand struct {
+	*DomainClass
+}
+
 func NewDomainClass(cla *Class, d string, attrs []*DomainAttr, methods []*DomainFunction) *DomainClass {
 	return &DomainClass{
 		domainObj: &domainObj{
@@ -137,3 +155,15 @@ func NewValueObject(cla *DomainClass) *ValueObject {
 		DomainClass: cla,
 	}
 }
+
+func NewDomainEvent(cla *DomainClass) *DomainEvent {
+	return &DomainEvent{
+		DomainClass: cla,
+	}
+}
+
+func NewDomainCommand(cla *DomainClass) *DomainCommand {
+	return &DomainCommand{
+		DomainClass: cla,
+	}
+}
@@ -92,6 +92,22 @@ func (r *mockObjRepository) Insert(obj arch.Object) error {
 	return nil
 }
 
+func (r *mockObjRepository) Update(obj arch.Object) error {
+	if _, ok := r.data[obj.Identifier()]; !ok {
+		return fmt.Errorf("object %s not found", obj.Identifier().ID())
+	}
+	r.data[obj.Identifier()] = obj
+	return nil
+}
+
+func (r *mockObjRepository) Delete(id arch.ObjIdentifier) error {
+	if _, ok := r.data[id]; !ok {
+		return fmt.Errorf("object %s not found", id.ID())
+	}
+	delete(r.data, id)
+	return nil
+}
+
 func (r *mockObjRepository) Walk(cb func(obj arch.Object) error) {
 	for _, obj := range r.data {
 		if err := cb(obj); err != nil {
@@ -147,6 +163,18 @@ func (r *mockRelationRepository) Insert(rel arch.Relation) error {
 	return nil
 }
 
+func (r *mockRelationRepository) Delete(match func(rel arch.Relation) bool) int {
+	var kept []arch.Relation
+	for _, rel := range r.relations {
+		if !match(rel) {
+			kept = append(kept, rel)
+		}
+	}
+	deleted := len(r.relations) - len(kept)
+	r.relations = kept
+	return deleted
+}
+
 func (r *mockRelationRepository) Walk(walker func(rel arch.Relation) error) {
 	for _, rel := range r.relations {
 		err := walker(rel)
@@ -0,0 +1,23 @@
+package valueobject
+
+import "github.com/dddplayer/dp/internal/domain/arch"
+
+type MessageKind string
+
+const (
+	MessageKindEvent   MessageKind = "event"
+	MessageKindCommand MessageKind = "command"
+)
+
+type EventFlow struct {
+	Kind        MessageKind
+	Message     *Class
+	Context     string
+	Publishers  []*EventEndpoint
+	Subscribers []*EventEndpoint
+}
+
+type EventEndpoint struct {
+	Function arch.Object
+	Site     arch.Position
+}
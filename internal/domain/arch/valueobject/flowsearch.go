@@ -0,0 +1,23 @@
+package valueobject
+
+type FlowMode string
+
+const (
+	FlowModeAll      FlowMode = "all"
+	FlowModeShortest FlowMode = "shortest"
+	FlowModeReach    FlowMode = "reach"
+)
+
+// FlowSearch tells how message flow paths are searched from each start function.
+// All enumerates the paths, Shortest keeps the MaxPaths shortest ones, at least one,
+// and Reach keeps the nodes and relations lying on any path without enumerating them.
+// MaxSteps bounds the nodes All expands, so it stops even when no path is found.
+// Zero limits are no limit. With Routes the flows start from the http routes instead,
+// the start patterns then match the route names like "GET /orders/*".
+type FlowSearch struct {
+	Mode     FlowMode
+	MaxDepth int
+	MaxPaths int
+	MaxSteps int
+	Routes   bool
+}
@@ -294,6 +294,65 @@ func (eg *VOGroup) ValueObjects() ValueObjects {
 	return es
 }
 
+type RepositoryGroup struct {
+	*domainGroup
+}
+
+func NewRepositoryGroup(domain string, objs ...arch.Object) *RepositoryGroup {
+	return &RepositoryGroup{
+		domainGroup: &domainGroup{
+			domain: domain,
+			group: &group{
+				name:      string(RepositoryComponent),
+				subGroups: []Group{},
+				objs:      objs,
+			},
+		},
+	}
+}
+
+func (rg *RepositoryGroup) Repositories() []*Repository {
+	var rs []*Repository
+	for _, i := range rg.DomainInterfaces() {
+		rs = append(rs, &Repository{domainObj: i.domainObj, Methods: i.Methods})
+	}
+	for _, cla := range rg.DomainClasses() {
+		rs = append(rs, &Repository{domainObj: cla.domainObj, Methods: cla.Methods})
+	}
+	return rs
+}
+
+type FactoryGroup struct {
+	*domainGroup
+}
+
+func NewFactoryGroup(domain string, objs ...arch.Object) *FactoryGroup {
+	return &FactoryGroup{
+		domainGroup: &domainGroup{
+			domain: domain,
+			group: &group{
+				name:      string(FactoryComponent),
+				subGroups: []Group{},
+				objs:      objs,
+			},
+		},
+	}
+}
+
+func (fg *FactoryGroup) Factories() []*Factory {
+	var fs []*Factory
+	for _, i := range fg.DomainInterfaces() {
+		fs = append(fs, &Factory{domainObj: i.domainObj, Methods: i.Methods})
+	}
+	for _, cla := range fg.DomainClasses() {
+		fs = append(fs, &Factory{domainObj: cla.domainObj, Methods: cla.Methods})
+	}
+	for _, f := range fg.DomainFunctions() {
+		fs = append(fs, &Factory{domainObj: f.domainObj})
+	}
+	return fs
+}
+
 type AggregateGroup struct {
 	*domainGroup
 }
@@ -319,17 +378,14 @@ func (agg *AggregateGroup) Aggregate() (*Aggregate, error) {
 	for _, o := range agg.objs {
 		if a, ok := o.(*Aggregate); ok {
 			if a.Entity == nil {
-				for _, sg := range agg.subGroups {
-					if g, ok := sg.(*EntityGroup); ok {
-						entities := g.Entities()
-						for _, e := range entities {
-							if strings.ToLower(e.Identifier().Name()) == strings.ToLower(a.Name) {
-								a.Entity = e
-								break
-							}
-						}
-					}
-				}
+				a.Entity = agg.rootEntity(func(e *Entity) bool {
+					return e.HasRole(arch.DomainRoleAggregateRoot)
+				})
+			}
+			if a.Entity == nil {
+				a.Entity = agg.rootEntity(func(e *Entity) bool {
+					return strings.ToLower(e.Identifier().Name()) == strings.ToLower(a.Name)
+				})
 			}
 
 			return a, nil
@@ -338,6 +394,19 @@ func (agg *AggregateGroup) Aggregate() (*Aggregate, error) {
 	return nil, fmt.Errorf("aggregate %s not found", agg.Name())
 }
 
+func (agg *AggregateGroup) rootEntity(match func(e *Entity) bool) *Entity {
+	for _, sg := range agg.subGroups {
+		if g, ok := sg.(*EntityGroup); ok {
+			for _, e := range g.Entities() {
+				if match(e) {
+					return e
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func (agg *AggregateGroup) IsValid() bool {
 	a, err := agg.Aggregate()
 	if err != nil {
@@ -381,6 +381,40 @@ func TestVOMethods(t *testing.T) {
 	// Add more validation...
 }
 
+func TestRepositoryGroupMethods(t *testing.T) {
+	domain := "example.com"
+	saveObj := &obj{id: &ident{name: "OrderRepository.Save", pkg: "example.com/order"}, pos: &pos{}}
+	itf := NewInterface(&obj{id: &ident{name: "OrderRepository", pkg: "example.com/order"}, pos: &pos{}},
+		[]arch.Object{saveObj})
+	rg := NewRepositoryGroup(domain, itf, &InterfaceMethod{obj: saveObj})
+
+	if rg.Name() != string(RepositoryComponent) {
+		t.Errorf("Expected group name %s, but got %s", RepositoryComponent, rg.Name())
+	}
+	rs := rg.Repositories()
+	if len(rs) != 1 {
+		t.Fatalf("Expected 1 repository, but got %d", len(rs))
+	}
+	if rs[0].OriginIdentifier().Name() != "OrderRepository" || len(rs[0].Methods) != 1 {
+		t.Errorf("Expected OrderRepository with 1 method, but got %s with %d",
+			rs[0].OriginIdentifier().Name(), len(rs[0].Methods))
+	}
+}
+
+func TestFactoryGroupMethods(t *testing.T) {
+	domain := "example.com"
+	newOrder := &Function{obj: &obj{id: &ident{name: "NewOrder", pkg: "example.com/order"}, pos: &pos{}}}
+	fg := NewFactoryGroup(domain, newOrder)
+
+	if fg.Name() != string(FactoryComponent) {
+		t.Errorf("Expected group name %s, but got %s", FactoryComponent, fg.Name())
+	}
+	fs := fg.Factories()
+	if len(fs) != 1 || fs[0].OriginIdentifier().Name() != "NewOrder" {
+		t.Errorf("Expected NewOrder factory, but got %v", fs)
+	}
+}
+
 func TestAggregateGroupMethods(t *testing.T) {
 	// Create a mock Aggregate
 	aggregate := &Aggregate{Name: "TestAggregate"}
@@ -0,0 +1,43 @@
+package valueobject
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"path"
+)
+
+// Layout maps the directories of a project to hexagon roles.
+// Its paths are relative to the Module path, or to the root of the analysed code if Module is empty.
+type Layout struct {
+	Module string
+	Domain string
+	Roles  map[arch.HexagonDirectory][]string
+}
+
+func (l *Layout) Role(dir string) arch.HexagonDirectory {
+	for _, role := range layoutRoles {
+		if l.Match(role, dir) {
+			return role
+		}
+	}
+	return arch.HexagonDirectoryInvalid
+}
+
+func (l *Layout) Match(role arch.HexagonDirectory, dir string) bool {
+	for _, pattern := range l.Roles[role] {
+		if ok, _ := path.Match(pattern, dir); ok {
+			return true
+		}
+	}
+	return false
+}
+
+var layoutRoles = []arch.HexagonDirectory{
+	arch.HexagonDirectoryEntity,
+	arch.HexagonDirectoryValueObject,
+	arch.HexagonDirectoryRepository,
+	arch.HexagonDirectoryFactory,
+	arch.HexagonDirectoryAggregate,
+	arch.HexagonDirectoryApplication,
+	arch.HexagonDirectoryInfrastructure,
+	arch.HexagonDirectoryInterfaces,
+}
@@ -0,0 +1,20 @@
+package valueobject
+
+type MetricKind string
+
+const (
+	MetricKindPackage   MetricKind = "package"
+	MetricKindAggregate MetricKind = "aggregate"
+)
+
+type Metric struct {
+	Kind         MetricKind
+	Name         string
+	Afferent     int
+	Efferent     int
+	Classes      int
+	Interfaces   int
+	Instability  float64
+	Abstractness float64
+	Distance     float64
+}
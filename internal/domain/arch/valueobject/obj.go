@@ -3,12 +3,22 @@ package valueobject
 import "github.com/dddplayer/dp/internal/domain/arch"
 
 type obj struct {
-	id  *ident
-	pos *pos
+	id    *ident
+	pos   *pos
+	roles []arch.DomainRole
 }
 
 func (o *obj) Identifier() arch.ObjIdentifier { return o.id }
 func (o *obj) Position() arch.Position        { return o.pos }
+func (o *obj) Roles() []arch.DomainRole       { return o.roles }
+func (o *obj) HasRole(role arch.DomainRole) bool {
+	for _, r := range o.roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
 
 type General struct {
 	*obj
@@ -28,6 +38,8 @@ type Class struct {
 type Function struct {
 	*obj
 	Receiver *ident
+	// Mutating methods assign to the fields of their pointer receiver.
+	Mutating bool
 }
 
 type Attr struct {
@@ -85,7 +97,12 @@ func NewClass(o arch.Object, as []arch.ObjIdentifier, ms []arch.ObjIdentifier) *
 }
 
 func NewObj(o arch.Object) *obj {
+	var roles []arch.DomainRole
+	if a, ok := o.(arch.Annotated); ok {
+		roles = a.Roles()
+	}
 	return &obj{
+		roles: roles,
 		id: &ident{
 			name: o.Identifier().Name(),
 			pkg:  o.Identifier().Dir(),
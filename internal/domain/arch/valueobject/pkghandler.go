@@ -0,0 +1,62 @@
+package valueobject
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/code"
+)
+
+// PackageHandler merges the code of some packages into repositories which already hold
+// the rest of the module, so only the changed packages need to be handled again.
+type PackageHandler struct {
+	*CodeHandler
+	Pkgs map[string]bool
+}
+
+func NewPackageHandler(ch *CodeHandler, pkgs []string) *PackageHandler {
+	ph := &PackageHandler{CodeHandler: ch, Pkgs: make(map[string]bool)}
+	for _, p := range pkgs {
+		ph.Pkgs[p] = true
+	}
+	return ph
+}
+
+// Purge removes the objects of the packages and the relations starting from them,
+// together with the implementations of their interfaces.
+func (ph *PackageHandler) Purge() error {
+	for _, id := range ph.ObjRepo.All() {
+		if ph.Pkgs[id.Dir()] {
+			if err := ph.ObjRepo.Delete(id); err != nil {
+				return err
+			}
+		}
+	}
+
+	ph.RelRepo.Delete(func(rel arch.Relation) bool {
+		if ph.Pkgs[rel.From().Identifier().Dir()] {
+			return true
+		}
+		if impl, ok := rel.(arch.ImplementationRelation); ok {
+			for _, i := range impl.Implements() {
+				if ph.Pkgs[i.Identifier().Dir()] {
+					return true
+				}
+			}
+		}
+		return false
+	})
+
+	return nil
+}
+
+func (ph *PackageHandler) NodeHandler(node *code.Node) {
+	if ph.Pkgs[node.Meta.Pkg()] {
+		ph.CodeHandler.NodeHandler(node)
+	}
+}
+
+func (ph *PackageHandler) LinkHandler(link *code.Link) {
+	if ph.Pkgs[link.From.Meta.Pkg()] ||
+		link.To.Type == code.TypeGenInterface && ph.Pkgs[link.To.Meta.Pkg()] {
+		ph.CodeHandler.LinkHandler(link)
+	}
+}
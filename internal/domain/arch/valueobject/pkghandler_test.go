@@ -0,0 +1,55 @@
+package valueobject
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/code"
+	"testing"
+)
+
+func TestPackageHandler(t *testing.T) {
+	objRepo := newMockRepository()
+	relRepo := newMockRelationRepository()
+	ph := NewPackageHandler(&CodeHandler{Scope: "/test", ObjRepo: objRepo, RelRepo: relRepo}, []string{"/test/order"})
+
+	order := &ident{name: "Order", pkg: "/test/order"}
+	repo := &ident{name: "Repository", pkg: "/test/order"}
+	user := &ident{name: "User", pkg: "/test/user"}
+	store := &ident{name: "Store", pkg: "/test/user"}
+	p := &pos{filename: "a.go", line: 1}
+
+	_ = objRepo.Insert(&Class{obj: &obj{id: order, pos: p}})
+	_ = objRepo.Insert(&Interface{obj: &obj{id: repo, pos: p}})
+	_ = objRepo.Insert(&Class{obj: &obj{id: user, pos: p}})
+	_ = objRepo.Insert(&Interface{obj: &obj{id: store, pos: p}})
+	_ = relRepo.Insert(NewImplementation(&obj{id: order, pos: p}, &obj{id: store, pos: p}))
+	_ = relRepo.Insert(NewImplementation(&obj{id: user, pos: p}, &obj{id: repo, pos: p}))
+	_ = relRepo.Insert(NewComposition(&obj{id: store, pos: p}, &obj{id: user, pos: p}))
+
+	if err := ph.Purge(); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(objRepo.data) != 2 || objRepo.Find(order) != nil || objRepo.Find(repo) != nil {
+		t.Errorf("Expected only the objects of /test/user left, but got %v", objRepo.data)
+	}
+	if len(relRepo.relations) != 1 || relRepo.relations[0].Type() != arch.RelationTypeComposition {
+		t.Errorf("Expected only the composition left, but got %v", relRepo.relations)
+	}
+
+	ph.NodeHandler(&code.Node{Meta: newDummyMetaWithIdent(user), Pos: p, Type: code.TypeGenStruct})
+	ph.NodeHandler(&code.Node{Meta: newDummyMetaWithIdent(order), Pos: p, Type: code.TypeGenStruct})
+	if objRepo.Find(order) == nil || len(objRepo.data) != 3 {
+		t.Errorf("Expected only Order to be handled, but got %v", objRepo.data)
+	}
+
+	ph.LinkHandler(&code.Link{
+		From: &code.Node{Meta: newDummyMetaWithIdent(user), Pos: p, Type: code.TypeAny},
+		To:   &code.Node{Meta: newDummyMetaWithIdent(repo), Pos: p, Type: code.TypeGenInterface},
+	})
+	ph.LinkHandler(&code.Link{
+		From: &code.Node{Meta: newDummyMetaWithIdent(store), Pos: p, Type: code.TypeGenInterface},
+		To:   &code.Node{Meta: newDummyMetaWithIdent(&ident{name: "Store.Get", pkg: "/test/user"}), Pos: p, Type: code.TypeGenInterfaceMethod},
+	})
+	if len(relRepo.relations) != 2 || relRepo.relations[1].Type() != arch.RelationTypeImplementation {
+		t.Errorf("Expected only the implementation of Repository to be handled, but got %v", relRepo.relations)
+	}
+}
@@ -0,0 +1,18 @@
+package valueobject
+
+import "github.com/dddplayer/dp/internal/domain/arch"
+
+type Port struct {
+	Interface *Interface
+	Layer     arch.HexagonDirectory
+	Adapters  []*Adapter
+}
+
+func (p *Port) Flagged() bool {
+	return len(p.Adapters) != 1
+}
+
+type Adapter struct {
+	Object arch.Object
+	Layer  arch.HexagonDirectory
+}
@@ -15,12 +15,17 @@ func (r *relation) From() arch.Object       { return r.from }
 
 type Dependence struct {
 	*relation
+	algorithm string
 }
 
 func (d *Dependence) DependsOn() arch.Object {
 	return d.to
 }
 
+func (d *Dependence) Algorithm() string {
+	return d.algorithm
+}
+
 func NewDependence(from, to *obj) arch.Relation {
 	return &Dependence{
 		relation: &relation{
@@ -31,6 +36,17 @@ func NewDependence(from, to *obj) arch.Relation {
 	}
 }
 
+func NewCall(from, to *obj, algorithm string) arch.Relation {
+	return &Dependence{
+		relation: &relation{
+			from:    from,
+			to:      to,
+			relType: arch.RelationTypeDependency,
+		},
+		algorithm: algorithm,
+	}
+}
+
 type Composition struct {
 	*relation
 }
@@ -67,6 +83,16 @@ func NewEmbedding(from, to *obj) arch.Relation {
 	}
 }
 
+func NewInterfaceEmbedding(from, to *obj) arch.Relation {
+	return &Embedding{
+		relation: &relation{
+			from:    from,
+			to:      to,
+			relType: arch.RelationTypeInterfaceEmbedding,
+		},
+	}
+}
+
 type Implementation struct {
 	*relation
 	to []arch.Object
@@ -115,3 +141,41 @@ func NewAssociation(from, to *obj, relationship arch.RelationType) arch.Relation
 		ship: relationship,
 	}
 }
+
+type Usage struct {
+	*relation
+}
+
+func (u *Usage) Used() arch.Object {
+	return u.to
+}
+
+func NewInstantiation(from, to *obj) arch.Relation {
+	return &Usage{
+		relation: &relation{
+			from:    from,
+			to:      to,
+			relType: arch.RelationTypeInstantiation,
+		},
+	}
+}
+
+func NewParameter(from, to *obj) arch.Relation {
+	return &Usage{
+		relation: &relation{
+			from:    from,
+			to:      to,
+			relType: arch.RelationTypeParameter,
+		},
+	}
+}
+
+func NewResult(from, to *obj) arch.Relation {
+	return &Usage{
+		relation: &relation{
+			from:    from,
+			to:      to,
+			relType: arch.RelationTypeResult,
+		},
+	}
+}
@@ -109,6 +109,30 @@ func TestNewDependence(t *testing.T) {
 	}
 }
 
+func TestNewCall(t *testing.T) {
+	fromObj := &obj{
+		id:  &ident{name: "caller", pkg: "package1"},
+		pos: &pos{filename: "file1.txt", offset: 100, line: 5, column: 10},
+	}
+	toObj := &obj{
+		id:  &ident{name: "callee", pkg: "package2"},
+		pos: &pos{filename: "file2.txt", offset: 200, line: 8, column: 15},
+	}
+
+	call := NewCall(fromObj, toObj, "pointer").(*Dependence)
+
+	if call.Type() != arch.RelationTypeDependency || call.From() != fromObj || call.DependsOn() != toObj {
+		t.Errorf("For NewCall:\nExpected: (%d, %v, %v)\nGot: (%d, %v, %v)",
+			arch.RelationTypeDependency, fromObj, toObj, call.Type(), call.From(), call.DependsOn())
+	}
+	if call.Algorithm() != "pointer" {
+		t.Errorf("Expected algorithm pointer, but got %q", call.Algorithm())
+	}
+	if NewDependence(fromObj, toObj).(*Dependence).Algorithm() != "" {
+		t.Error("Expected plain dependence to have no algorithm")
+	}
+}
+
 func TestCompositionMethods(t *testing.T) {
 	parentObj := &obj{
 		id:  &ident{name: "parentObj", pkg: "package1"},
@@ -219,6 +243,27 @@ func TestNewEmbedding(t *testing.T) {
 	}
 }
 
+func TestNewInterfaceEmbedding(t *testing.T) {
+	fromObj := &obj{
+		id:  &ident{name: "Finder", pkg: "package1"},
+		pos: &pos{filename: "file1.txt", offset: 100, line: 5, column: 10},
+	}
+	toObj := &obj{
+		id:  &ident{name: "Repository", pkg: "package1"},
+		pos: &pos{filename: "file1.txt", offset: 200, line: 8, column: 15},
+	}
+
+	embedding, ok := NewInterfaceEmbedding(fromObj, toObj).(arch.EmbeddingRelation)
+	if !ok {
+		t.Fatal("Expected NewInterfaceEmbedding to return an EmbeddingRelation")
+	}
+
+	if embedding.Type() != arch.RelationTypeInterfaceEmbedding || embedding.From() != fromObj || embedding.Embedded() != toObj {
+		t.Errorf("For NewInterfaceEmbedding:\nExpected: (%d, %v, %v)\nGot: (%d, %v, %v)",
+			arch.RelationTypeInterfaceEmbedding, fromObj, toObj, embedding.Type(), embedding.From(), embedding.Embedded())
+	}
+}
+
 func TestImplementationMethods(t *testing.T) {
 	fromObj := &obj{
 		id:  &ident{name: "fromObj", pkg: "package1"},
@@ -360,3 +405,33 @@ func TestNewAssociation(t *testing.T) {
 			expectedType, expectedFrom, expectedAssociationType, actualType, actualFrom, actualAssociationType)
 	}
 }
+
+func TestNewUsage(t *testing.T) {
+	fromObj := &obj{
+		id:  &ident{name: "fromFunc", pkg: "package1"},
+		pos: &pos{filename: "file1.txt", offset: 100, line: 5, column: 10},
+	}
+	toObj := &obj{
+		id:  &ident{name: "toEvent", pkg: "package2"},
+		pos: &pos{filename: "file2.txt", offset: 200, line: 8, column: 15},
+	}
+
+	testCases := []struct {
+		name         string
+		usage        *Usage
+		expectedType arch.RelationType
+	}{
+		{"Instantiation", NewInstantiation(fromObj, toObj).(*Usage), arch.RelationTypeInstantiation},
+		{"Parameter", NewParameter(fromObj, toObj).(*Usage), arch.RelationTypeParameter},
+		{"Result", NewResult(fromObj, toObj).(*Usage), arch.RelationTypeResult},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.usage.Type() != tc.expectedType || tc.usage.From() != fromObj || tc.usage.Used() != toObj {
+				t.Errorf("Expected: (%d, %v, %v)\nGot: (%d, %v, %v)",
+					tc.expectedType, fromObj, toObj, tc.usage.Type(), tc.usage.From(), tc.usage.Used())
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package valueobject
+
+import "github.com/dddplayer/dp/internal/domain/arch"
+
+// Route is an http route registered at Pos and served by the Handler function.
+type Route struct {
+	Router  string
+	Method  string
+	Path    string
+	Handler arch.ObjIdentifier
+	Pos     arch.Position
+}
+
+// Name is the method and path of the route, like "GET /orders", ANY when any method matches.
+func (r *Route) Name() string {
+	method := r.Method
+	if method == "" {
+		method = "ANY"
+	}
+	return method + " " + r.Path
+}
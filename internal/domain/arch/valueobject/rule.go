@@ -0,0 +1,18 @@
+package valueobject
+
+import "github.com/dddplayer/dp/internal/domain/arch"
+
+type Rule struct {
+	Name   string
+	From   string
+	Allow  []string
+	Forbid []string
+}
+
+type Violation struct {
+	Rule *Rule
+	From arch.ObjIdentifier
+	To   arch.ObjIdentifier
+	Type arch.RelationType
+	Pos  arch.RelationPos
+}
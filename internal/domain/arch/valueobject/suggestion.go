@@ -0,0 +1,14 @@
+package valueobject
+
+import "github.com/dddplayer/dp/internal/domain/arch"
+
+type SuggestedAggregate struct {
+	Name    string
+	Members []*SuggestedMember
+}
+
+type SuggestedMember struct {
+	Object    arch.DomainObj
+	Aggregate string
+	Misplaced bool
+}
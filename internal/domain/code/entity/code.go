@@ -1,21 +1,34 @@
 package entity
 
-import "github.com/dddplayer/dp/internal/domain/code"
+import (
+	"context"
+	"errors"
+	"github.com/dddplayer/dp/internal/domain/code"
+)
 
 type Code struct {
 	lan code.Language
+	ctx context.Context
 }
 
 func NewCode(mainPkgPath, domain string) (*Code, error) {
-	g, err := newGo(mainPkgPath, domain)
+	return NewCodeContext(context.Background(), mainPkgPath, domain)
+}
+
+// NewCodeContext loads the code like NewCode, stopping the analysis once ctx is done.
+func NewCodeContext(ctx context.Context, mainPkgPath, domain string) (*Code, error) {
+	g, err := newGo(ctx, mainPkgPath, domain)
 	if err != nil {
 		return nil, err
 	}
-	return &Code{lan: g}, nil
+	return &Code{lan: g, ctx: ctx}, nil
 }
 
-func newGo(path, domain string) (code.Language, error) {
-	p := &Go{Path: path, DomainPkgPath: domain}
+func newGo(ctx context.Context, path, domain string) (code.Language, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	p := &Go{Path: path, DomainPkgPath: domain, Context: ctx}
 	if err := p.Load(); err != nil {
 		return nil, err
 	}
@@ -26,22 +39,61 @@ func (c *Code) MainPkgPath() string {
 	return c.lan.MainPkgPath()
 }
 
-func (c *Code) VisitFast(handler code.Handler) error {
-	c.lan.VisitFile(handler.NodeHandler, handler.LinkHandler)
-	c.lan.InterfaceImplements(handler.LinkHandler)
-	if err := c.lan.CallGraph(handler.LinkHandler, code.CallGraphFastMode); err != nil {
+func (c *Code) Packages() []*code.Package {
+	return c.lan.Packages()
+}
+
+// Reload loads again the given packages and the packages importing them, after their files changed.
+func (c *Code) Reload(pkgs []string) error {
+	if err := c.err(); err != nil {
 		return err
 	}
+	r, ok := c.lan.(code.Reloader)
+	if !ok {
+		return errors.New("reload not supported")
+	}
+	return r.Reload(pkgs)
+}
 
-	return nil
+func (c *Code) VisitFast(handler code.Handler) error {
+	return c.Visit(handler, code.CallGraphTypeStatic)
 }
 
 func (c *Code) VisitDeep(handler code.Handler) error {
+	return c.Visit(handler, code.CallGraphTypePointer)
+}
+
+// Visit reports the code to handler, with the call edges of every given call graph algorithm.
+func (c *Code) Visit(handler code.Handler, algos ...code.CallGraphType) error {
+	if err := c.err(); err != nil {
+		return err
+	}
 	c.lan.VisitFile(handler.NodeHandler, handler.LinkHandler)
-	c.lan.InterfaceImplements(handler.LinkHandler)
-	if err := c.lan.CallGraph(handler.LinkHandler, code.CallGraphDeepMode); err != nil {
+	if err := c.err(); err != nil {
 		return err
 	}
+	c.lan.InterfaceImplements(handler.LinkHandler)
+	for _, algo := range algos {
+		if err := c.err(); err != nil {
+			return err
+		}
+		if err := c.lan.CallGraph(handler.LinkHandler, algo); err != nil {
+			return err
+		}
+	}
+	if rh, ok := handler.(code.RouteHandler); ok {
+		if err := c.err(); err != nil {
+			return err
+		}
+		c.lan.Routes(rh.RouteHandler)
+	}
 
 	return nil
 }
+
+func (c *Code) err() error {
+	if c.ctx == nil {
+		return nil
+	}
+	return c.ctx.Err()
+}
@@ -1,21 +1,19 @@
 package entity
 
 import (
+	"context"
 	"fmt"
 	"github.com/dddplayer/dp/internal/domain/code"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
 func TestVisit(t *testing.T) {
 	// create a temporary directory to hold the test package
-	tempDir, err := ioutil.TempDir(".", "testpkg")
-	if err != nil {
-		t.Fatalf("failed to create temp dir: %v", err)
-	}
-	defer os.RemoveAll(tempDir)
+	tempDir := newTestModule(t)
 
 	// create a test package in the temporary directory
 	if err := createPkgTestPackage(tempDir); err != nil {
@@ -30,7 +28,7 @@ func TestVisit(t *testing.T) {
 	ch := &MockCodeHandler{}
 
 	// call the VisitFast function with the test package
-	c, err := NewCode(tempDir, "testpkg")
+	c, err := NewCode(tempDir, testModule)
 	if err != nil {
 		t.Fatalf("NewCode failed with error: %v", err)
 	}
@@ -49,11 +47,7 @@ func TestVisit(t *testing.T) {
 
 func TestVisitDeep(t *testing.T) {
 	// create a temporary directory to hold the test package
-	tempDir, err := ioutil.TempDir(".", "testpkg")
-	if err != nil {
-		t.Fatalf("failed to create temp dir: %v", err)
-	}
-	defer os.RemoveAll(tempDir)
+	tempDir := newTestModule(t)
 
 	// create a test package in the temporary directory
 	if err := createPkgTestPackage(tempDir); err != nil {
@@ -68,7 +62,7 @@ func TestVisitDeep(t *testing.T) {
 	ch := &MockCodeHandler{}
 
 	// call the VisitFast function with the test package
-	c, err := NewCode(tempDir, "testpkg")
+	c, err := NewCode(tempDir, testModule)
 	if err != nil {
 		t.Fatalf("NewCode failed with error: %v", err)
 	}
@@ -85,6 +79,18 @@ func TestVisitDeep(t *testing.T) {
 	}
 }
 
+// testModule is the module path of the test packages.
+const testModule = "example.com/testpkg"
+
+// newTestModule creates a temporary module for the test packages, outside the source tree.
+func newTestModule(t *testing.T) string {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module "+testModule+"\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
 // createTestPackage creates a test package in the specified directory
 func createPkgTestPackage(dir string) error {
 	// create some test files in the package directory
@@ -122,7 +128,7 @@ func TestNewGo_Error(t *testing.T) {
 	path := "github.com/example/mypackage"
 	domain := "example.com"
 
-	_, err := newGo(path, domain)
+	_, err := newGo(context.Background(), path, domain)
 
 	if err == nil {
 		t.Errorf("Expected an error, got no error")
@@ -139,3 +145,103 @@ func TestNewCode_Error(t *testing.T) {
 		t.Errorf("Expected an error, got no error")
 	}
 }
+
+type mockLanguage struct {
+	visited []string
+}
+
+func (l *mockLanguage) VisitFile(nodeCB code.NodeCB, linkCB code.LinkCB) {
+	l.visited = append(l.visited, "file")
+}
+func (l *mockLanguage) InterfaceImplements(linkCB code.LinkCB) {
+	l.visited = append(l.visited, "interface")
+}
+func (l *mockLanguage) CallGraph(linkCB code.LinkCB, algo code.CallGraphType) error {
+	l.visited = append(l.visited, string(algo))
+	return nil
+}
+func (l *mockLanguage) Routes(routeCB code.RouteCB) {
+	l.visited = append(l.visited, "routes")
+}
+func (l *mockLanguage) MainPkgPath() string       { return "main" }
+func (l *mockLanguage) Packages() []*code.Package { return nil }
+
+func TestCode_VisitCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	lan := &mockLanguage{}
+	c := &Code{lan: lan, ctx: ctx}
+
+	if err := c.Visit(&MockCodeHandler{}, code.CallGraphTypeStatic); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(lan.visited) != 3 {
+		t.Errorf("Expected files, interfaces and call graph visited, but got %v", lan.visited)
+	}
+
+	cancel()
+	lan.visited = nil
+	if err := c.Visit(&MockCodeHandler{}, code.CallGraphTypeStatic); err != context.Canceled {
+		t.Errorf("Expected %v, but got %v", context.Canceled, err)
+	}
+	if len(lan.visited) != 0 {
+		t.Errorf("Expected nothing visited after cancel, but got %v", lan.visited)
+	}
+
+	if _, err := NewCodeContext(ctx, "github.com/example/mypackage", "example.com"); err != context.Canceled {
+		t.Errorf("Expected %v, but got %v", context.Canceled, err)
+	}
+}
+
+type MockRouteHandler struct {
+	MockCodeHandler
+	routes []*code.Route
+}
+
+func (ch *MockRouteHandler) RouteHandler(route *code.Route) {
+	ch.routes = append(ch.routes, route)
+}
+
+func TestCode_VisitRoutes(t *testing.T) {
+	lan := &mockLanguage{}
+	c := &Code{lan: lan, ctx: context.Background()}
+
+	if err := c.Visit(&MockRouteHandler{}, code.CallGraphTypeStatic); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{"file", "interface", "static", "routes"}
+	if !reflect.DeepEqual(lan.visited, expected) {
+		t.Errorf("Expected %v visited, but got %v", expected, lan.visited)
+	}
+}
+
+func TestCode_Reload(t *testing.T) {
+	c := &Code{lan: &mockLanguage{}, ctx: context.Background()}
+	if err := c.Reload([]string{"main"}); err == nil {
+		t.Errorf("Expected an error for a language which can't reload packages")
+	}
+
+	tempDir := newTestModule(t)
+	if err := createPkgTestPackage(tempDir); err != nil {
+		t.Fatalf("failed to create test package: %v", err)
+	}
+
+	c, err := NewCode(tempDir, testModule)
+	if err != nil {
+		t.Fatalf("NewCode failed with error: %v", err)
+	}
+	initFile := filepath.Join(tempDir, "main.go")
+	if err := ioutil.WriteFile(initFile, []byte("package main\n\nfunc main(){Func1(); Func2()}\n"), 0644); err != nil {
+		t.Fatalf("failed to update test package: %v", err)
+	}
+	if err := c.Reload([]string{c.MainPkgPath()}); err != nil {
+		t.Fatalf("Reload failed with error: %v", err)
+	}
+
+	ch := &MockCodeHandler{}
+	if err := c.VisitFast(ch); err != nil {
+		t.Fatalf("VisitFast failed with error: %v", err)
+	}
+	if len(ch.links) != 2 {
+		t.Errorf("Expected the calls of the reloaded main, but got %d links", len(ch.links))
+	}
+}
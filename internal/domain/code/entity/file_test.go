@@ -1,13 +1,11 @@
 package entity
 
 import (
-	"fmt"
 	"go/ast"
 	"go/build"
 	"go/parser"
 	"golang.org/x/tools/go/packages"
 	"io/ioutil"
-	"os"
 	"path/filepath"
 	"testing"
 )
@@ -20,18 +18,14 @@ func Test_findFile_Error(t *testing.T) {
 }
 
 func Test_findFile(t *testing.T) {
-	testDir, err := tmpTestDir()
-	defer os.RemoveAll(testDir) // 删除临时目录
-	if err != nil {
-		t.Fatalf("failed to create tempory test dir: %v", err)
-	}
+	testDir := tmpTestDir(t)
 
 	pkg, err := packages.Load(&packages.Config{
 		Mode:       packages.LoadAllSyntax,
 		Tests:      false,
-		Dir:        "",
+		Dir:        testDir,
 		BuildFlags: build.Default.BuildTags,
-	}, testDir)
+	}, ".")
 	if err != nil {
 		t.Fatalf("failed to load package: %v", err)
 	}
@@ -83,13 +77,9 @@ func Test_findFile(t *testing.T) {
 	}
 }
 
-func tmpTestDir() (string, error) {
+func tmpTestDir(t *testing.T) string {
 	// 创建临时目录
-	tmpDir, err := ioutil.TempDir(".", "test")
-	if err != nil {
-		fmt.Printf("Failed to create temporary directory: %v", err)
-		return "", err
-	}
+	tmpDir := newTestModule(t)
 
 	// 创建测试文件
 	testFile := filepath.Join(tmpDir, "main.go")
@@ -107,9 +97,8 @@ func main() {
 }
 `)
 	if err := ioutil.WriteFile(testFile, testContent, 0644); err != nil {
-		fmt.Printf("Failed to create test file: %v", err)
-		return "", err
+		t.Fatalf("Failed to create test file: %v", err)
 	}
 
-	return tmpDir, nil
+	return tmpDir
 }
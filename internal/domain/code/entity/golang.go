@@ -1,13 +1,13 @@
 package entity
 
 import (
+	"context"
 	"fmt"
 	"github.com/dddplayer/dp/internal/domain/code"
 	"github.com/dddplayer/dp/internal/domain/code/valueobject"
 	"go/ast"
 	"go/build"
 	"go/types"
-	"golang.org/x/exp/slices"
 	"golang.org/x/tools/go/callgraph"
 	"golang.org/x/tools/go/callgraph/cha"
 	"golang.org/x/tools/go/callgraph/rta"
@@ -16,26 +16,24 @@ import (
 	"golang.org/x/tools/go/pointer"
 	"golang.org/x/tools/go/ssa"
 	"golang.org/x/tools/go/ssa/ssautil"
+	"os"
+	"sort"
 	"strings"
 )
 
 type Go struct {
-	Path          string
-	DomainPkgPath string
-	Initial       []*packages.Package
-	mainPkgPath   string
-	prog          *ssa.Program
+	Path            string
+	DomainPkgPath   string
+	Context         context.Context
+	Initial         []*packages.Package
+	RouteExtractors []RouteExtractor
+	mainPkgPath     string
+	prog            *ssa.Program
 }
 
 func (golang *Go) Load() error {
-	cfg := &packages.Config{
-		Mode:       packages.LoadAllSyntax,
-		Tests:      false,
-		Dir:        "",
-		BuildFlags: build.Default.BuildTags,
-	}
-
-	initial, err := packages.Load(cfg, golang.Path)
+	cfg, pattern := golang.config(packages.LoadAllSyntax)
+	initial, err := packages.Load(cfg, pattern)
 	if err != nil {
 		return err
 	}
@@ -54,10 +52,48 @@ func (golang *Go) Load() error {
 	return nil
 }
 
+// config returns the config loading the code with mode, and the pattern of its main package.
+func (golang *Go) config(mode packages.LoadMode) (*packages.Config, string) {
+	cfg := &packages.Config{
+		Mode:       mode,
+		Tests:      false,
+		Dir:        "",
+		BuildFlags: build.Default.BuildTags,
+		Context:    golang.Context,
+	}
+
+	pattern := golang.Path
+	if fi, err := os.Stat(golang.Path); err == nil && fi.IsDir() {
+		// load a directory from its own module, which may not be the working one
+		cfg.Dir = golang.Path
+		pattern = "."
+	}
+
+	return cfg, pattern
+}
+
 func (golang *Go) MainPkgPath() string {
 	return golang.mainPkgPath
 }
 
+func (golang *Go) Packages() []*code.Package {
+	var pkgs []*code.Package
+	packages.Visit(golang.Initial, nil, func(pkg *packages.Package) {
+		if !strings.Contains(pkg.String(), golang.DomainPkgPath) {
+			return
+		}
+		p := &code.Package{Path: pkg.ID, Files: pkg.GoFiles}
+		for _, imp := range pkg.Imports {
+			if strings.Contains(imp.String(), golang.DomainPkgPath) {
+				p.Imports = append(p.Imports, imp.ID)
+			}
+		}
+		sort.Strings(p.Imports)
+		pkgs = append(pkgs, p)
+	})
+	return pkgs
+}
+
 func (golang *Go) buildProg() {
 	prog, _ := ssautil.AllPackages(golang.Initial, 0)
 	prog.Build()
@@ -80,10 +116,11 @@ func (golang *Go) VisitFile(nodeCB code.NodeCB, linkCB code.LinkCB) {
 							case *ast.TypeSpec:
 								typeSpec := spec.(*ast.TypeSpec)
 								node := &code.Node{
-									Meta:   valueobject.NewMeta(pkg.ID, typeSpec.Name.Name),
-									Pos:    declPos,
-									Parent: nil,
-									Type:   code.TypeGenIdent,
+									Meta:        valueobject.NewMeta(pkg.ID, typeSpec.Name.Name),
+									Pos:         declPos,
+									Parent:      nil,
+									Type:        code.TypeGenIdent,
+									Annotations: typeAnnotations(genDecl, typeSpec),
 								}
 
 								switch typeSpec.Type.(type) {
@@ -187,6 +224,9 @@ func (golang *Go) VisitFile(nodeCB code.NodeCB, linkCB code.LinkCB) {
 												To:       methodNode,
 												Relation: code.OneOne,
 											})
+											if ft, ok := method.Type.(*ast.FuncType); ok {
+												golang.visitSignature(pkg, ft, methodNode, linkCB)
+											}
 										}
 									}
 								}
@@ -244,6 +284,7 @@ func (golang *Go) VisitFile(nodeCB code.NodeCB, linkCB code.LinkCB) {
 							}
 						}
 
+						funcNode.Mutating = mutatesReceiver(pkg, funcDecl)
 						nodeCB(funcNode)
 						if funcNode.Parent != nil {
 							linkCB(&code.Link{
@@ -252,6 +293,7 @@ func (golang *Go) VisitFile(nodeCB code.NodeCB, linkCB code.LinkCB) {
 								Relation: code.OneOne,
 							})
 						}
+						golang.visitFuncUsages(pkg, funcDecl, funcNode, linkCB)
 					}
 				}
 			}
@@ -284,26 +326,7 @@ func (golang *Go) InterfaceImplements(linkCB code.LinkCB) {
 		}
 	}
 
-	implMap := map[string][]*types.Named{}
-	for _, i := range namedInterface {
-		implMap[i.Obj().Name()] = []*types.Named{}
-	}
-
-	addImpl := func(iName string, obj *types.Named) {
-		impls := implMap[iName]
-		impls = append(impls, obj)
-		implMap[iName] = impls
-	}
-
-	for _, o := range namedObj {
-		for _, i := range namedInterface {
-			if types.AssignableTo(o, i) {
-				addImpl(i.Obj().Name(), o)
-			} else if pU := types.NewPointer(o); types.AssignableTo(pU, i) {
-				addImpl(i.Obj().Name(), o)
-			}
-		}
-	}
+	implMap := implementations(namedInterface, namedObj, golang.instances(namedMap))
 
 	for _, i := range namedInterface {
 		iNode := &code.Node{
@@ -311,8 +334,7 @@ func (golang *Go) InterfaceImplements(linkCB code.LinkCB) {
 			Pos:  valueobject.SsaPosition(namedMap[i], i.Obj()),
 			Type: code.TypeGenInterface,
 		}
-		impls := implMap[i.Obj().Name()]
-		for _, impl := range impls {
+		for _, impl := range implMap[i.Obj()] {
 			linkCB(&code.Link{
 				From: &code.Node{
 					Meta: valueobject.NewMeta(impl.Obj().Pkg().Path(), impl.Obj().Name()),
@@ -323,7 +345,235 @@ func (golang *Go) InterfaceImplements(linkCB code.LinkCB) {
 				Relation: code.OneOne,
 			})
 		}
+		for _, embedded := range embeddedInterfaces(i) {
+			if _, ok := namedMap[embedded]; !ok {
+				continue
+			}
+			linkCB(&code.Link{
+				From: iNode,
+				To: &code.Node{
+					Meta: valueobject.NewMeta(embedded.Obj().Pkg().Path(), embedded.Obj().Name()),
+					Pos:  valueobject.SsaPosition(namedMap[embedded], embedded.Obj()),
+					Type: code.TypeGenInterfaceEmbedded,
+				},
+				Relation: code.OneOne,
+			})
+		}
+	}
+}
+
+// instances collects the instantiations of the generic types in namedMap, keyed by their origin.
+func (golang *Go) instances(namedMap map[*types.Named]*ssa.Package) map[*types.Named][]*types.Named {
+	insts := map[*types.Named][]*types.Named{}
+	packages.Visit(golang.Initial, nil, func(pkg *packages.Package) {
+		if pkg.TypesInfo == nil {
+			return
+		}
+		for _, inst := range pkg.TypesInfo.Instances {
+			named, ok := inst.Type.(*types.Named)
+			if !ok || named.Origin() == named {
+				continue
+			}
+			if _, ok := namedMap[named.Origin()]; ok {
+				insts[named.Origin()] = append(insts[named.Origin()], named)
+			}
+		}
+	})
+	return insts
+}
+
+// implementations matches objects to interfaces by type identity, so interfaces sharing a name
+// in different packages keep their own implementers. Generic types are also matched through
+// their instantiations.
+func implementations(interfaces, objs []*types.Named,
+	insts map[*types.Named][]*types.Named) map[*types.TypeName][]*types.Named {
+	implMap := map[*types.TypeName][]*types.Named{}
+	for _, i := range interfaces {
+		targets := append([]*types.Named{i}, insts[i]...)
+		for _, o := range objs {
+			candidates := append([]*types.Named{o}, insts[o]...)
+			if anyImplements(candidates, targets) {
+				implMap[i.Obj()] = append(implMap[i.Obj()], o)
+			}
+		}
 	}
+	return implMap
+}
+
+func anyImplements(objs, interfaces []*types.Named) bool {
+	for _, o := range objs {
+		for _, i := range interfaces {
+			if implements(o, i) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func implements(o, i *types.Named) bool {
+	if isGeneric(o) && isGeneric(i) && o.TypeParams().Len() == i.TypeParams().Len() {
+		args := make([]types.Type, i.TypeParams().Len())
+		for k := range args {
+			args[k] = i.TypeParams().At(k)
+		}
+		inst, err := types.Instantiate(nil, o, args, false)
+		if err != nil {
+			return false
+		}
+		o = inst.(*types.Named)
+	} else if isGeneric(i) {
+		return false
+	}
+
+	return types.AssignableTo(o, i) || types.AssignableTo(types.NewPointer(o), i)
+}
+
+func isGeneric(n *types.Named) bool {
+	return n.TypeParams().Len() > 0 && n.TypeArgs().Len() == 0
+}
+
+func embeddedInterfaces(i *types.Named) []*types.Named {
+	itf, ok := i.Underlying().(*types.Interface)
+	if !ok {
+		return nil
+	}
+	var embedded []*types.Named
+	for k := 0; k < itf.NumEmbeddeds(); k++ {
+		if named, ok := itf.EmbeddedType(k).(*types.Named); ok {
+			embedded = append(embedded, named.Origin())
+		}
+	}
+	return embedded
+}
+
+// mutatesReceiver tells whether a method with a pointer receiver assigns to what its receiver points to.
+func mutatesReceiver(pkg *packages.Package, funcDecl *ast.FuncDecl) bool {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 || funcDecl.Body == nil {
+		return false
+	}
+	field := funcDecl.Recv.List[0]
+	if _, ok := field.Type.(*ast.StarExpr); !ok || len(field.Names) == 0 {
+		return false
+	}
+	recv := pkg.TypesInfo.Defs[field.Names[0]]
+	if recv == nil {
+		return false
+	}
+
+	// the receiver itself may be rebound, only writes through it mutate
+	writesThrough := func(expr ast.Expr) bool {
+		through := false
+		for {
+			switch e := expr.(type) {
+			case *ast.ParenExpr:
+				expr = e.X
+				continue
+			case *ast.SelectorExpr:
+				expr, through = e.X, true
+				continue
+			case *ast.IndexExpr:
+				expr, through = e.X, true
+				continue
+			case *ast.StarExpr:
+				expr, through = e.X, true
+				continue
+			case *ast.Ident:
+				return through && pkg.TypesInfo.Uses[e] == recv
+			}
+			return false
+		}
+	}
+
+	mutating := false
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		if mutating {
+			return false
+		}
+		switch stmt := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range stmt.Lhs {
+				if writesThrough(lhs) {
+					mutating = true
+				}
+			}
+		case *ast.IncDecStmt:
+			mutating = writesThrough(stmt.X)
+		}
+		return !mutating
+	})
+	return mutating
+}
+
+func (golang *Go) visitFuncUsages(pkg *packages.Package, funcDecl *ast.FuncDecl, funcNode *code.Node, linkCB code.LinkCB) {
+	golang.visitSignature(pkg, funcDecl.Type, funcNode, linkCB)
+	if funcDecl.Body != nil {
+		ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+			if lit, ok := n.(*ast.CompositeLit); ok {
+				golang.usage(pkg, namedType(pkg.TypesInfo.TypeOf(lit)), lit, funcNode, code.TypeGenInstance, linkCB)
+			}
+			return true
+		})
+	}
+}
+
+func (golang *Go) visitSignature(pkg *packages.Package, ft *ast.FuncType, funcNode *code.Node, linkCB code.LinkCB) {
+	visit := func(fields *ast.FieldList, nodeType code.NodeType) {
+		if fields == nil {
+			return
+		}
+		for _, field := range fields.List {
+			named := namedType(elemType(pkg.TypesInfo.TypeOf(field.Type)))
+			golang.usage(pkg, named, field.Type, funcNode, nodeType, linkCB)
+		}
+	}
+
+	visit(ft.Params, code.TypeFuncParam)
+	visit(ft.Results, code.TypeFuncResult)
+}
+
+func (golang *Go) usage(pkg *packages.Package, named *types.Named, expr ast.Expr,
+	funcNode *code.Node, nodeType code.NodeType, linkCB code.LinkCB) {
+	if named == nil || named.Obj().Pkg() == nil ||
+		!strings.Contains(named.Obj().Pkg().Path(), golang.DomainPkgPath) {
+		return
+	}
+	linkCB(&code.Link{
+		From: funcNode,
+		To: &code.Node{
+			Meta: valueobject.NewMeta(named.Obj().Pkg().Path(), named.Obj().Name()),
+			Pos:  valueobject.AstPosition(pkg, expr),
+			Type: nodeType,
+		},
+		Relation: code.OneOne,
+	})
+}
+
+func elemType(t types.Type) types.Type {
+	switch tt := t.(type) {
+	case *types.Slice:
+		return elemType(tt.Elem())
+	case *types.Array:
+		return elemType(tt.Elem())
+	case *types.Map:
+		return elemType(tt.Elem())
+	}
+	return t
+}
+
+func namedType(t types.Type) *types.Named {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	named, _ := t.(*types.Named)
+	return named
+}
+
+func typeAnnotations(genDecl *ast.GenDecl, typeSpec *ast.TypeSpec) []code.Annotation {
+	if genDecl.Lparen.IsValid() {
+		return valueobject.Annotations(typeSpec.Doc)
+	}
+	return valueobject.Annotations(genDecl.Doc, typeSpec.Doc)
 }
 
 func mainPackages(pkgs []*ssa.Package) ([]*ssa.Package, error) {
@@ -348,6 +598,7 @@ func (golang *Go) callGraph(algo code.CallGraphType) (*callgraph.Graph, error) {
 	case code.CallGraphTypeCha: // Cha, class hierarchy analysis
 		graph = cha.CallGraph(golang.prog)
 	case code.CallGraphTypeRta: // Rta,rapid type analysis
+		golang.prog.Build()
 		mains, err := mainPackages(golang.prog.AllPackages())
 		if err != nil {
 			return nil, err
@@ -358,6 +609,7 @@ func (golang *Go) callGraph(algo code.CallGraphType) (*callgraph.Graph, error) {
 		}
 		graph = rta.Analyze(roots, true).CallGraph
 	case code.CallGraphTypePointer: // Pointer
+		golang.prog.Build()
 		mains, err := mainPackages(golang.prog.AllPackages())
 		if err != nil {
 			return nil, err
@@ -378,82 +630,37 @@ func (golang *Go) callGraph(algo code.CallGraphType) (*callgraph.Graph, error) {
 	return graph, nil
 }
 
-func (golang *Go) CallGraph(linkCB code.LinkCB, mode code.CallGraphMode) error {
-	var algo code.CallGraphType
-	switch mode {
-	case code.CallGraphFastMode:
-		algo = code.CallGraphTypeStatic
-	case code.CallGraphDeepMode:
-		algo = code.CallGraphTypePointer
-	default:
-		algo = code.CallGraphTypeStatic
-	}
-
+func (golang *Go) CallGraph(cb code.LinkCB, algo code.CallGraphType) error {
 	callGraph, err := golang.callGraph(algo)
 	if err != nil {
 		return err
 	}
 
-	extendEdges := make(map[string]*callgraph.Edge)
-	var extended []string
+	linkCB := func(link *code.Link) {
+		link.Algorithm = algo
+		cb(link)
+	}
 
 	callGraph.DeleteSyntheticNodes()
 	err = callgraph.GraphVisitEdges(callGraph, func(edge *callgraph.Edge) error {
-		caller := edge.Caller
-		callee := edge.Callee
-
-		if strings.Contains(caller.String(), golang.DomainPkgPath) &&
-			strings.Contains(callee.String(), golang.DomainPkgPath) {
-			golang.handleDomainCallGraphEdge(edge, linkCB)
-
-		} else if strings.Contains(caller.String(), golang.DomainPkgPath) &&
-			strings.Contains(callee.String(), "(*net/http.ServeMux).HandleFunc") {
-			for _, v := range edge.Site.Common().Args {
-				if v.Type().String() == "func(w net/http.ResponseWriter, req *net/http.Request)" {
-					if va, ok := v.(*ssa.MakeClosure); ok {
-						closureName := va.Fn.Name()
-						// 去掉 $bound 部分
-						parts := strings.Split(closureName, "$")
-						if len(parts) > 0 {
-							closureName = parts[0]
-						}
-						extendEdges[closureName] = edge
-					}
-				}
-			}
-		}
-
+		golang.handleDomainCallGraphEdge(edge, linkCB)
 		return nil
 	})
+	if err != nil {
+		return err
+	}
 
-	if len(extendEdges) > 0 {
-		err = callgraph.GraphVisitEdges(callGraph, func(edge *callgraph.Edge) error {
-			caller := edge.Caller
-			callee := edge.Callee
-
-			if strings.Contains(caller.String(), golang.DomainPkgPath) &&
-				strings.Contains(callee.String(), golang.DomainPkgPath) {
-				for k, e := range extendEdges {
-					if strings.Contains(caller.String(), k) {
-						if slices.Contains(extended, k) {
-							continue
-						}
-						extended = append(extended, k)
-
-						golang.handleDomainCallGraphEdge(&callgraph.Edge{
-							Caller: e.Caller,
-							Site:   e.Site,
-							Callee: caller,
-						}, linkCB)
-					}
-				}
-			}
-
-			return nil
-		})
+	// routers call the handlers through their own code, so the function registering
+	// a route calls its handler as far as the domain is concerned
+	for _, r := range golang.routes() {
+		golang.handleDomainCallGraphEdge(&callgraph.Edge{
+			Caller: &callgraph.Node{Func: r.caller},
+			Site:   r.site,
+			Callee: &callgraph.Node{Func: r.handler},
+		}, linkCB)
 	}
 
-	return err
+	return nil
 }
 
 func (golang *Go) handleDomainCallGraphEdge(edge *callgraph.Edge, linkCB code.LinkCB) {
@@ -493,21 +700,25 @@ func ignore(funcName string) bool {
 }
 
 func (golang *Go) functionNode(node *callgraph.Node) *code.Node {
-	pkgPath := node.Func.Pkg.Pkg.Path()
-	funcName := node.Func.Name()
+	return golang.funcNode(node.Func)
+}
+
+func (golang *Go) funcNode(fn *ssa.Function) *code.Node {
+	pkgPath := fn.Pkg.Pkg.Path()
+	funcName := fn.Name()
 	objName := funcName
 
 	n := &code.Node{
 		Meta:   valueobject.NewMeta(pkgPath, funcName),
-		Pos:    valueobject.SsaFuncPosition(node.Func.Pkg, node.Func),
+		Pos:    valueobject.SsaFuncPosition(fn.Pkg, fn),
 		Type:   code.TypeFunc,
 		Parent: nil,
 	}
 
-	recv := node.Func.Signature.Recv()
-	if strings.Contains(funcName, "$") && node.Func.Parent() != nil {
+	recv := fn.Signature.Recv()
+	if strings.Contains(funcName, "$") && fn.Parent() != nil {
 		count := strings.Count(funcName, "$")
-		p := node.Func.Parent()
+		p := fn.Parent()
 		for i := 1; i < count; i++ {
 			if p != nil {
 				p = p.Parent()
@@ -3,9 +3,12 @@ package entity
 import (
 	"fmt"
 	"github.com/dddplayer/dp/internal/domain/code"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
 	"golang.org/x/exp/slices"
 	"io/ioutil"
-	"os"
 	"path/filepath"
 	"testing"
 
@@ -16,15 +19,11 @@ import (
 
 func TestMainPackages(t *testing.T) {
 	// 创建临时目录
-	tempDir, err := ioutil.TempDir(".", "test_main_packages")
-	if err != nil {
-		t.Fatalf("Failed to create temporary directory: %v", err)
-	}
-	defer os.RemoveAll(tempDir)
+	tempDir := newTestModule(t)
 
 	// 创建真实的Go文件
 	goFile := fmt.Sprintf("package main\n\nfunc main() {\n}\n")
-	err = ioutil.WriteFile(tempDir+"/main.go", []byte(goFile), 0644)
+	err := ioutil.WriteFile(tempDir+"/main.go", []byte(goFile), 0644)
 	if err != nil {
 		t.Fatalf("Failed to write Go file: %v", err)
 	}
@@ -32,8 +31,9 @@ func TestMainPackages(t *testing.T) {
 	// 使用packages.Load方法加载包
 	cfg := &packages.Config{
 		Mode: packages.LoadSyntax,
+		Dir:  tempDir,
 	}
-	pkgs, err := packages.Load(cfg, tempDir)
+	pkgs, err := packages.Load(cfg, ".")
 	if err != nil {
 		t.Fatalf("Failed to load packages: %v", err)
 	}
@@ -64,11 +64,7 @@ func TestMainPackages(t *testing.T) {
 
 func TestPkg_VisitFile(t *testing.T) {
 	// 创建临时文件夹
-	tmpDir, err := ioutil.TempDir(".", "example")
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer os.RemoveAll(tmpDir)
+	tmpDir := newTestModule(t)
 
 	// 写入临时文件
 	sourceCode := `
@@ -166,7 +162,7 @@ func main() {
 	// 构造测试对象
 	pkg := &Go{
 		Path:          "example",
-		DomainPkgPath: "example",
+		DomainPkgPath: testModule,
 		Initial:       pkgs,
 		mainPkgPath:   "example",
 	}
@@ -210,11 +206,7 @@ func main() {
 }
 
 func TestPkg_CallGraph(t *testing.T) {
-	tmpdir, err := ioutil.TempDir(".", "example")
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer os.RemoveAll(tmpdir)
+	tmpdir := newTestModule(t)
 
 	// create a test package with two functions calling each other
 	src := `package main
@@ -253,14 +245,14 @@ func main() {
 	})
 }
 `
-	err = ioutil.WriteFile(filepath.Join(tmpdir, "main.go"), []byte(src), 0644)
+	err := ioutil.WriteFile(filepath.Join(tmpdir, "main.go"), []byte(src), 0644)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	// load the test package
 	p := &Go{Path: tmpdir,
-		DomainPkgPath: "example",
+		DomainPkgPath: testModule,
 		mainPkgPath:   "example"}
 	if err := p.Load(); err != nil {
 		t.Fatal(err)
@@ -271,7 +263,7 @@ func main() {
 	linkCB := func(link *code.Link) {
 		links = append(links, link)
 	}
-	if err := p.CallGraph(linkCB, code.CallGraphFastMode); err != nil {
+	if err := p.CallGraph(linkCB, code.CallGraphTypeStatic); err != nil {
 		t.Fatal(err)
 	}
 
@@ -291,11 +283,7 @@ func main() {
 
 func TestPkg_InterfaceImplements(t *testing.T) {
 	// create a temporary directory for test files
-	tmpDir, err := ioutil.TempDir(".", "test")
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer os.RemoveAll(tmpDir)
+	tmpDir := newTestModule(t)
 
 	// 写入临时文件
 	sourceCode := `
@@ -344,7 +332,7 @@ func main() {
 	// load the test package
 	p := &Go{
 		Path:          tmpDir,
-		DomainPkgPath: "test",
+		DomainPkgPath: testModule,
 	}
 	if err := p.Load(); err != nil {
 		t.Fatal(err)
@@ -372,11 +360,7 @@ func main() {
 
 func TestCallGraph(t *testing.T) {
 	// create a temporary directory for test files
-	tmpDir, err := ioutil.TempDir(".", "test")
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer os.RemoveAll(tmpDir)
+	tmpDir := newTestModule(t)
 
 	// 写入临时文件
 	sourceCode := `
@@ -425,7 +409,7 @@ func main() {
 	// load the test package
 	goInstance := &Go{
 		Path:          tmpDir,
-		DomainPkgPath: "test",
+		DomainPkgPath: testModule,
 	}
 	if err := goInstance.Load(); err != nil {
 		t.Fatal(err)
@@ -461,3 +445,187 @@ func main() {
 		}
 	}
 }
+
+func checkNamedTypes(t *testing.T, path, src string) (itfs, objs []*types.Named, insts map[*types.Named][]*types.Named) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path+".go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := &types.Info{Instances: map[*ast.Ident]types.Instance{}}
+	pkg, err := (&types.Config{}).Check(path, fset, []*ast.File{f}, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range pkg.Scope().Names() {
+		if named, ok := pkg.Scope().Lookup(name).Type().(*types.Named); ok {
+			if types.IsInterface(named) {
+				itfs = append(itfs, named)
+			} else {
+				objs = append(objs, named)
+			}
+		}
+	}
+	insts = map[*types.Named][]*types.Named{}
+	for _, inst := range info.Instances {
+		if named, ok := inst.Type.(*types.Named); ok && named.Origin() != named {
+			insts[named.Origin()] = append(insts[named.Origin()], named)
+		}
+	}
+	return
+}
+
+func TestImplementations(t *testing.T) {
+	orderItfs, orderObjs, orderInsts := checkNamedTypes(t, "order", `
+package order
+
+type Order struct{}
+
+type Repository interface {
+	Save(o *Order) error
+}
+
+type Store[T any] interface {
+	Get(id string) T
+}
+
+type Finder interface {
+	Repository
+	Store[Order]
+}
+
+type orderRepo struct{}
+
+func (r *orderRepo) Save(o *Order) error { return nil }
+
+type orderStore struct{}
+
+func (s orderStore) Get(id string) Order { return Order{} }
+
+type cache[T any] struct{}
+
+func (c *cache[T]) Get(id string) T { var t T; return t }
+
+var _ Store[Order] = orderStore{}
+`)
+	paymentItfs, paymentObjs, _ := checkNamedTypes(t, "payment", `
+package payment
+
+type Repository interface {
+	Pay(amount int) error
+}
+
+type paymentRepo struct{}
+
+func (r paymentRepo) Pay(amount int) error { return nil }
+`)
+
+	implMap := implementations(append(orderItfs, paymentItfs...), append(orderObjs, paymentObjs...), orderInsts)
+
+	expected := map[string][]string{
+		"order.Repository":   {"order.orderRepo"},
+		"order.Store":        {"order.cache", "order.orderStore"},
+		"payment.Repository": {"payment.paymentRepo"},
+	}
+	actual := map[string][]string{}
+	for itf, impls := range implMap {
+		key := itf.Pkg().Path() + "." + itf.Name()
+		for _, impl := range impls {
+			actual[key] = append(actual[key], impl.Obj().Pkg().Path()+"."+impl.Obj().Name())
+		}
+		slices.Sort(actual[key])
+	}
+	for key, impls := range expected {
+		if !slices.Equal(actual[key], impls) {
+			t.Errorf("expected %s to be implemented by %v, got %v", key, impls, actual[key])
+		}
+	}
+	if len(actual) != len(expected) {
+		t.Errorf("expected %d implemented interfaces, got %v", len(expected), actual)
+	}
+
+	for _, itf := range orderItfs {
+		if itf.Obj().Name() != "Finder" {
+			continue
+		}
+		var names []string
+		for _, e := range embeddedInterfaces(itf) {
+			names = append(names, e.Obj().Name())
+		}
+		slices.Sort(names)
+		if !slices.Equal(names, []string{"Repository", "Store"}) {
+			t.Errorf("expected Finder to embed Repository and Store, got %v", names)
+		}
+	}
+}
+
+func TestPkg_Packages(t *testing.T) {
+	entity := &packages.Package{ID: "example.com/app/domain/entity", PkgPath: "example.com/app/domain/entity",
+		GoFiles: []string{"/app/domain/entity/order.go"}, Imports: map[string]*packages.Package{}}
+	fmtPkg := &packages.Package{ID: "fmt", PkgPath: "fmt", Imports: map[string]*packages.Package{}}
+	app := &packages.Package{ID: "example.com/app/domain/app", PkgPath: "example.com/app/domain/app",
+		GoFiles: []string{"/app/domain/app/service.go"},
+		Imports: map[string]*packages.Package{"fmt": fmtPkg, entity.PkgPath: entity}}
+	golang := &Go{DomainPkgPath: "example.com/app/domain", Initial: []*packages.Package{app}}
+
+	pkgs := golang.Packages()
+	if len(pkgs) != 2 {
+		t.Fatalf("expected 2 domain packages, got %d", len(pkgs))
+	}
+	byPath := map[string]*code.Package{}
+	for _, p := range pkgs {
+		byPath[p.Path] = p
+	}
+	if p := byPath[app.ID]; p == nil || !slices.Equal(p.Imports, []string{entity.ID}) ||
+		!slices.Equal(p.Files, app.GoFiles) {
+		t.Errorf("expected %s to import only %s, got %+v", app.ID, entity.ID, p)
+	}
+	if p := byPath[entity.ID]; p == nil || len(p.Imports) != 0 {
+		t.Errorf("expected %s without domain imports, got %+v", entity.ID, p)
+	}
+}
+
+func TestMutatesReceiver(t *testing.T) {
+	src := `package order
+type Line struct {
+	qty   int
+	tags  map[string]bool
+	total *int
+}
+func (l *Line) Cancel()             { l.qty = 0 }
+func (l *Line) Add()                { l.qty++ }
+func (l *Line) Tag(s string)        { (l.tags)[s] = true }
+func (l *Line) Reset()              { *l = Line{} }
+func (l *Line) Qty() int            { return l.qty }
+func (l *Line) Rebind(o *Line)      { l = o }
+func (l *Line) Local()              { x := 1; x++; _ = x }
+func (l Line) Copy()                { l.qty = 1 }
+func (l *Line) Other(o *Line)       { o.qty = 1 }
+func (_ *Line) Anonymous()          {}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "order.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := &types.Info{Defs: make(map[*ast.Ident]types.Object), Uses: make(map[*ast.Ident]types.Object)}
+	if _, err := (&types.Config{}).Check("order", fset, []*ast.File{f}, info); err != nil {
+		t.Fatal(err)
+	}
+	pkg := &packages.Package{TypesInfo: info}
+
+	expected := map[string]bool{
+		"Cancel": true, "Add": true, "Tag": true, "Reset": true,
+		"Rebind": false, "Qty": false, "Local": false, "Copy": false, "Other": false, "Anonymous": false,
+	}
+	for _, decl := range f.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		if got := mutatesReceiver(pkg, fd); got != expected[fd.Name.Name] {
+			t.Errorf("mutatesReceiver(%s) = %v, want %v", fd.Name.Name, got, expected[fd.Name.Name])
+		}
+	}
+}
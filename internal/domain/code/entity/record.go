@@ -0,0 +1,139 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/code"
+	"github.com/dddplayer/dp/internal/domain/code/valueobject"
+	"go/build"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Record keeps everything a visit reports to its handler, so that it can be
+// replayed later without loading the code again.
+type Record struct {
+	Key         string               `json:"key"`
+	MainPkgPath string               `json:"mainPkgPath"`
+	Events      []*valueobject.Event `json:"events"`
+}
+
+func NewRecord(key, mainPkgPath string) *Record {
+	return &Record{
+		Key:         key,
+		MainPkgPath: mainPkgPath,
+		Events:      []*valueobject.Event{},
+	}
+}
+
+func (r *Record) NodeHandler(node *code.Node) {
+	r.Events = append(r.Events, &valueobject.Event{Node: valueobject.NewNodeRecord(node)})
+}
+
+func (r *Record) LinkHandler(link *code.Link) {
+	r.Events = append(r.Events, &valueobject.Event{Link: valueobject.NewLinkRecord(link)})
+}
+
+func (r *Record) RouteHandler(route *code.Route) {
+	r.Events = append(r.Events, &valueobject.Event{Route: valueobject.NewRouteRecord(route)})
+}
+
+func (r *Record) Replay(handler code.Handler) {
+	rh, _ := handler.(code.RouteHandler)
+	for _, e := range r.Events {
+		switch {
+		case e.Node != nil:
+			handler.NodeHandler(e.Node.Node())
+		case e.Link != nil:
+			handler.LinkHandler(e.Link.Link())
+		case e.Route != nil && rh != nil:
+			rh.RouteHandler(e.Route.Route())
+		}
+	}
+}
+
+// recordVersion changes whenever a visit reports more than before, invalidating the older records.
+const recordVersion = 3
+
+// Fingerprint hashes the go sources, go.mod and go.sum of the module containing path,
+// together with the record version, go version and build tags used for the analysis.
+func Fingerprint(path string) (string, error) {
+	root, err := ModuleRoot(path)
+	if err != nil {
+		return "", err
+	}
+
+	var files []string
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != root && (strings.HasPrefix(d.Name(), ".") || strings.HasPrefix(d.Name(), "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(d.Name(), ".go") || d.Name() == "go.mod" || d.Name() == "go.sum" {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%d\x00%s\x00%s\x00", recordVersion, runtime.Version(), strings.Join(build.Default.BuildTags, ","))
+	for _, f := range files {
+		rel, err := filepath.Rel(root, f)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\x00", filepath.ToSlash(rel))
+		if err := hashFile(h, f); err != nil {
+			return "", err
+		}
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+func hashFile(w io.Writer, filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fh := sha256.New()
+	if _, err := io.Copy(fh, f); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%x\x00", fh.Sum(nil))
+	return err
+}
+
+// ModuleRoot returns the directory of the go.mod file path belongs to.
+func ModuleRoot(path string) (string, error) {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("cannot find go.mod file")
+		}
+		dir = parent
+	}
+}
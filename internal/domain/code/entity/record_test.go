@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"github.com/dddplayer/dp/internal/domain/code"
+	"github.com/dddplayer/dp/internal/domain/code/valueobject"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecord_Replay(t *testing.T) {
+	r := NewRecord("key", "test/cmd")
+	r.NodeHandler(&code.Node{Meta: valueobject.NewMeta("test/order", "Order"), Type: code.TypeGenStruct})
+	r.LinkHandler(&code.Link{
+		From:      &code.Node{Meta: valueobject.NewMeta("test/order", "Place"), Type: code.TypeFunc},
+		To:        &code.Node{Meta: valueobject.NewMeta("test/order", "Pay"), Type: code.TypeFunc},
+		Relation:  code.OneOne,
+		Algorithm: code.CallGraphTypeStatic,
+	})
+	r.NodeHandler(&code.Node{Meta: valueobject.NewMeta("test/order", "Pay"), Type: code.TypeFunc})
+
+	ch := &MockCodeHandler{}
+	r.Replay(ch)
+
+	if len(ch.nodes) != 2 || len(ch.links) != 1 {
+		t.Fatalf("Expected 2 nodes and 1 link, but got %d and %d", len(ch.nodes), len(ch.links))
+	}
+	if ch.nodes[0].Meta.Name() != "Order" || ch.nodes[1].Meta.Name() != "Pay" {
+		t.Errorf("Expected nodes replayed in order, but got %s, %s", ch.nodes[0].Meta.Name(), ch.nodes[1].Meta.Name())
+	}
+	if ch.links[0].Algorithm != code.CallGraphTypeStatic {
+		t.Errorf("Expected link algorithm %s, but got %s", code.CallGraphTypeStatic, ch.links[0].Algorithm)
+	}
+}
+
+func TestRecord_ReplayRoutes(t *testing.T) {
+	r := NewRecord("key", "test/cmd")
+	r.RouteHandler(&code.Route{
+		Router:  "gin",
+		Method:  "GET",
+		Path:    "/orders",
+		Handler: &code.Node{Meta: valueobject.NewMetaWithParent("test/api", "List", "Server"), Type: code.TypeFunc},
+		Pos:     valueobject.NewPosition("api.go", 10, 2, 3),
+	})
+
+	r.Replay(&MockCodeHandler{})
+
+	rh := &MockRouteHandler{}
+	r.Replay(rh)
+	if len(rh.routes) != 1 {
+		t.Fatalf("Expected 1 route, but got %d", len(rh.routes))
+	}
+	route := rh.routes[0]
+	if route.Method != "GET" || route.Path != "/orders" || route.Router != "gin" {
+		t.Errorf("Expected GET /orders of gin, but got %s %s of %s", route.Method, route.Path, route.Router)
+	}
+	if route.Handler.Meta.Parent() != "Server" || route.Pos.Line() != 2 {
+		t.Errorf("Expected handler Server.List at line 2, but got %s.%s at line %d",
+			route.Handler.Meta.Parent(), route.Handler.Meta.Name(), route.Pos.Line())
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	root := t.TempDir()
+	write := func(name, content string) {
+		p := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("go.mod", "module example.com/shop\n")
+	write("cmd/main.go", "package main\n\nfunc main() {}\n")
+
+	fingerprint := func() string {
+		k, err := Fingerprint(filepath.Join(root, "cmd"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return k
+	}
+
+	k1 := fingerprint()
+	write("README.md", "docs")
+	write(".cache/x.go", "package x")
+	if k2 := fingerprint(); k2 != k1 {
+		t.Error("Expected fingerprint to ignore non go files and hidden folders")
+	}
+
+	write("cmd/main.go", "package main\n\nfunc main() { println() }\n")
+	if k3 := fingerprint(); k3 == k1 {
+		t.Error("Expected fingerprint to change with the sources")
+	}
+
+	if _, err := Fingerprint(os.TempDir()); err == nil {
+		t.Error("Expected error outside of a module")
+	}
+}
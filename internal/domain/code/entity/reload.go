@@ -0,0 +1,170 @@
+package entity
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/types"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+	"sort"
+)
+
+// Reload loads again the given packages and every package importing them, keeping the other
+// packages as they were loaded. Only the reloaded packages are built, so the static and cha call
+// graphs and the routes only cover them, while the rta and pointer analyses build the rest first.
+func (golang *Go) Reload(pkgs []string) error {
+	loaded := make(map[string]*packages.Package)
+	importers := make(map[string][]string)
+	packages.Visit(golang.Initial, nil, func(p *packages.Package) {
+		loaded[p.ID] = p
+		for _, imp := range p.Imports {
+			importers[imp.ID] = append(importers[imp.ID], p.ID)
+		}
+	})
+
+	reload := make(map[string]bool)
+	queue := append([]string(nil), pkgs...)
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if reload[p] {
+			continue
+		}
+		reload[p] = true
+		queue = append(queue, importers[p]...)
+	}
+	var patterns []string
+	for p := range reload {
+		patterns = append(patterns, p)
+	}
+	sort.Strings(patterns)
+
+	cfg, _ := golang.config(packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedImports)
+	listed, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return err
+	}
+	if packages.PrintErrors(listed) > 0 {
+		return fmt.Errorf("packages contain errors")
+	}
+
+	return golang.reload(listed, loaded)
+}
+
+// reload type checks the listed packages against the loaded ones, and replaces them in the program.
+func (golang *Go) reload(listed []*packages.Package, loaded map[string]*packages.Package) error {
+	fset := golang.prog.Fset
+	sizes := types.SizesFor("gc", build.Default.GOARCH)
+
+	pending := make(map[string]*packages.Package)
+	for _, lp := range listed {
+		pending[lp.ID] = lp
+	}
+	checked := make(map[string]*packages.Package)
+
+	var check func(lp *packages.Package) (*packages.Package, error)
+	check = func(lp *packages.Package) (*packages.Package, error) {
+		if p, ok := checked[lp.ID]; ok {
+			return p, nil
+		}
+
+		p := &packages.Package{
+			ID:              lp.ID,
+			Name:            lp.Name,
+			PkgPath:         lp.PkgPath,
+			GoFiles:         lp.GoFiles,
+			CompiledGoFiles: lp.CompiledGoFiles,
+			Fset:            fset,
+			Imports:         make(map[string]*packages.Package),
+		}
+		for path, imp := range lp.Imports {
+			var dep *packages.Package
+			var err error
+			if next, ok := pending[imp.ID]; ok {
+				dep, err = check(next)
+			} else if dep, ok = loaded[imp.ID]; !ok {
+				err = fmt.Errorf("package %s not loaded", imp.ID)
+			}
+			if err != nil {
+				return nil, err
+			}
+			p.Imports[path] = dep
+		}
+
+		for _, f := range lp.CompiledGoFiles {
+			file, err := parser.ParseFile(fset, f, nil, parser.ParseComments)
+			if err != nil {
+				return nil, err
+			}
+			p.Syntax = append(p.Syntax, file)
+		}
+		p.TypesInfo = &types.Info{
+			Types:      make(map[ast.Expr]types.TypeAndValue),
+			Defs:       make(map[*ast.Ident]types.Object),
+			Uses:       make(map[*ast.Ident]types.Object),
+			Implicits:  make(map[ast.Node]types.Object),
+			Instances:  make(map[*ast.Ident]types.Instance),
+			Scopes:     make(map[ast.Node]*types.Scope),
+			Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		}
+		conf := types.Config{
+			Importer: importerFunc(func(path string) (*types.Package, error) {
+				if path == "unsafe" {
+					return types.Unsafe, nil
+				}
+				if imp, ok := p.Imports[path]; ok && imp.Types != nil {
+					return imp.Types, nil
+				}
+				return nil, fmt.Errorf("package %s not loaded", path)
+			}),
+			Sizes: sizes,
+		}
+		pkg, err := conf.Check(lp.PkgPath, fset, p.Syntax, p.TypesInfo)
+		if err != nil {
+			return nil, err
+		}
+		p.Types = pkg
+		p.TypesSizes = sizes
+
+		checked[lp.ID] = p
+		return p, nil
+	}
+
+	for _, lp := range listed {
+		if _, err := check(lp); err != nil {
+			return err
+		}
+	}
+
+	initial := make([]*packages.Package, len(golang.Initial))
+	for i, p := range golang.Initial {
+		if c, ok := checked[p.ID]; ok {
+			p = c
+		}
+		initial[i] = p
+	}
+
+	prog := ssa.NewProgram(fset, 0)
+	var built []*ssa.Package
+	packages.Visit(initial, nil, func(p *packages.Package) {
+		if p.Types != nil && !p.IllTyped {
+			sp := prog.CreatePackage(p.Types, p.Syntax, p.TypesInfo, true)
+			if _, ok := checked[p.ID]; ok {
+				built = append(built, sp)
+			}
+		}
+	})
+	for _, sp := range built {
+		sp.Build()
+	}
+
+	golang.Initial = initial
+	golang.prog = prog
+	return nil
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
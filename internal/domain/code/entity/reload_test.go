@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"github.com/dddplayer/dp/internal/domain/code"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa/ssautil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeReloadFile(t *testing.T, dir, pkg, src string) string {
+	f := filepath.Join(dir, pkg, pkg+".go")
+	if err := os.MkdirAll(filepath.Dir(f), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(f, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func loadReloadPackage(t *testing.T, fset *token.FileSet, id, file string, imports ...*packages.Package) *packages.Package {
+	f, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &packages.Package{
+		ID:              id,
+		Name:            f.Name.Name,
+		PkgPath:         id,
+		GoFiles:         []string{file},
+		CompiledGoFiles: []string{file},
+		Fset:            fset,
+		Syntax:          []*ast.File{f},
+		Imports:         make(map[string]*packages.Package),
+		TypesInfo: &types.Info{
+			Types:      make(map[ast.Expr]types.TypeAndValue),
+			Defs:       make(map[*ast.Ident]types.Object),
+			Uses:       make(map[*ast.Ident]types.Object),
+			Implicits:  make(map[ast.Node]types.Object),
+			Scopes:     make(map[ast.Node]*types.Scope),
+			Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		},
+	}
+	for _, imp := range imports {
+		p.Imports[imp.PkgPath] = imp
+	}
+	conf := types.Config{Importer: importerFunc(func(path string) (*types.Package, error) {
+		return p.Imports[path].Types, nil
+	})}
+	if p.Types, err = conf.Check(id, fset, p.Syntax, p.TypesInfo); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestGo_Reload(t *testing.T) {
+	dir := t.TempDir()
+	fset := token.NewFileSet()
+
+	aFile := writeReloadFile(t, dir, "a", "package a\n\nfunc A() {}\n")
+	bFile := writeReloadFile(t, dir, "b", "package b\n\nimport \"example.com/a\"\n\nfunc B() { a.A() }\n")
+	cFile := writeReloadFile(t, dir, "c", "package c\n\nfunc C() { C() }\n")
+
+	a := loadReloadPackage(t, fset, "example.com/a", aFile)
+	b := loadReloadPackage(t, fset, "example.com/b", bFile, a)
+	c := loadReloadPackage(t, fset, "example.com/c", cFile)
+
+	golang := &Go{DomainPkgPath: "example.com", Initial: []*packages.Package{b, c}}
+	golang.buildProg()
+
+	writeReloadFile(t, dir, "a", "package a\n\nfunc A() {}\n\nfunc A2() {}\n")
+	writeReloadFile(t, dir, "b", "package b\n\nimport \"example.com/a\"\n\nfunc B() { a.A2() }\n")
+	listed := []*packages.Package{
+		{ID: a.ID, Name: a.Name, PkgPath: a.PkgPath, CompiledGoFiles: a.CompiledGoFiles},
+		{ID: b.ID, Name: b.Name, PkgPath: b.PkgPath, CompiledGoFiles: b.CompiledGoFiles,
+			Imports: map[string]*packages.Package{a.PkgPath: {ID: a.ID}}},
+	}
+	loaded := map[string]*packages.Package{a.ID: a, b.ID: b, c.ID: c}
+	if err := golang.reload(listed, loaded); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	if golang.Initial[0] == b || golang.Initial[1] != c {
+		t.Errorf("Expected b to be reloaded and c to be kept")
+	}
+
+	var calls []string
+	if err := golang.CallGraph(func(link *code.Link) {
+		calls = append(calls, link.From.Meta.Name()+"->"+link.To.Meta.Name())
+	}, code.CallGraphTypeStatic); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if len(calls) != 1 || calls[0] != "B->A2" {
+		t.Errorf("Expected only the call of the reloaded B, but got %v", calls)
+	}
+
+	for fn := range ssautil.AllFunctions(golang.prog) {
+		if fn.Name() == "C" && len(fn.Blocks) > 0 {
+			t.Errorf("Expected the kept package c not to be built again")
+		}
+	}
+
+	listed[1].Imports = map[string]*packages.Package{"example.com/d": {ID: "example.com/d"}}
+	if err := golang.reload(listed, loaded); err == nil {
+		t.Errorf("Expected an error for an import which is not loaded")
+	}
+}
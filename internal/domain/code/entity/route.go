@@ -0,0 +1,455 @@
+package entity
+
+import (
+	"github.com/dddplayer/dp/internal/domain/code"
+	"github.com/dddplayer/dp/internal/domain/code/valueobject"
+	"go/constant"
+	"go/types"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// RouteCall is a call found in the domain which may register an http route.
+type RouteCall struct {
+	Pkg      string      // import path of the package declaring the called function
+	Type     string      // receiver type name of a method, empty for a package function
+	Name     string      // name of the called function
+	Receiver ssa.Value   // receiver of a method
+	Args     []ssa.Value // arguments without the receiver
+	Site     ssa.CallInstruction
+}
+
+func (rc *RouteCall) key() string {
+	if rc.Type == "" {
+		return rc.Name
+	}
+	return rc.Type + "." + rc.Name
+}
+
+// RouteSpec is a route registration, with the handler value passed to the router.
+type RouteSpec struct {
+	Method  string
+	Path    string
+	Handler ssa.Value
+}
+
+// RouteExtractor recognises the route registrations of an http router.
+// Go uses DefaultRouteExtractors unless its RouteExtractors are given.
+type RouteExtractor interface {
+	Router() string
+	Extract(call *RouteCall) (RouteSpec, bool)
+}
+
+func DefaultRouteExtractors() []RouteExtractor {
+	return []RouteExtractor{netHTTPRouter(), chiRouter(), gorillaRouter(), ginRouter(), echoRouter()}
+}
+
+type route struct {
+	router  string
+	method  string
+	path    string
+	caller  *ssa.Function
+	site    ssa.CallInstruction
+	handler *ssa.Function
+}
+
+func (golang *Go) Routes(routeCB code.RouteCB) {
+	for _, r := range golang.routes() {
+		routeCB(&code.Route{
+			Router:  r.router,
+			Method:  r.method,
+			Path:    r.path,
+			Handler: golang.funcNode(r.handler),
+			Pos:     valueobject.SsaInstructionPosition(r.caller.Pkg, r.site),
+		})
+	}
+}
+
+// routes returns the routes registered by the domain functions, in the order of their registrations.
+func (golang *Go) routes() []*route {
+	extractors := golang.RouteExtractors
+	if len(extractors) == 0 {
+		extractors = DefaultRouteExtractors()
+	}
+
+	var routes []*route
+	for fn := range ssautil.AllFunctions(golang.prog) {
+		if fn.Pkg == nil || !strings.Contains(fn.Pkg.Pkg.Path(), golang.DomainPkgPath) {
+			continue
+		}
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				site, ok := instr.(ssa.CallInstruction)
+				if !ok {
+					continue
+				}
+				call := newRouteCall(site)
+				if call == nil {
+					continue
+				}
+				if r := golang.extractRoute(extractors, call); r != nil {
+					r.caller = fn
+					routes = append(routes, r)
+				}
+			}
+		}
+	}
+
+	fset := golang.prog.Fset
+	sort.Slice(routes, func(i, j int) bool {
+		pi, pj := fset.Position(routes[i].site.Pos()), fset.Position(routes[j].site.Pos())
+		if pi.Filename != pj.Filename {
+			return pi.Filename < pj.Filename
+		}
+		return pi.Offset < pj.Offset
+	})
+	return routes
+}
+
+func (golang *Go) extractRoute(extractors []RouteExtractor, call *RouteCall) *route {
+	for _, e := range extractors {
+		spec, ok := e.Extract(call)
+		if !ok {
+			continue
+		}
+		handler := golang.handlerFunc(spec.Handler)
+		if handler == nil || handler.Pkg == nil {
+			return nil
+		}
+
+		method, path := spec.Method, spec.Path
+		// net/http patterns may start with the method, like "GET /orders/{id}"
+		if i := strings.Index(path, " "); method == "" && i > 0 {
+			method, path = path[:i], strings.TrimSpace(path[i+1:])
+		}
+		return &route{
+			router:  e.Router(),
+			method:  strings.ToUpper(method),
+			path:    path,
+			site:    call.Site,
+			handler: handler,
+		}
+	}
+	return nil
+}
+
+// handlerFunc resolves the function serving the requests from the handler value passed to a router.
+func (golang *Go) handlerFunc(v ssa.Value) *ssa.Function {
+	switch h := v.(type) {
+	case *ssa.Function:
+		return golang.sourceFunc(h)
+	case *ssa.MakeClosure:
+		if fn, ok := h.Fn.(*ssa.Function); ok {
+			return golang.sourceFunc(fn)
+		}
+	case *ssa.ChangeType:
+		return golang.handlerFunc(h.X)
+	case *ssa.Convert:
+		return golang.handlerFunc(h.X)
+	case *ssa.MakeInterface:
+		if fn := golang.handlerFunc(h.X); fn != nil {
+			return fn
+		}
+		if types.IsInterface(h.X.Type()) {
+			return nil
+		}
+		if sel := golang.prog.MethodSets.MethodSet(h.X.Type()).Lookup(nil, "ServeHTTP"); sel != nil {
+			return golang.prog.MethodValue(sel)
+		}
+	}
+	return nil
+}
+
+// sourceFunc returns the declared method behind a bound method value or a method expression.
+func (golang *Go) sourceFunc(fn *ssa.Function) *ssa.Function {
+	if fn.Synthetic == "" {
+		return fn
+	}
+	if obj, ok := fn.Object().(*types.Func); ok {
+		if f := golang.prog.FuncValue(obj); f != nil {
+			return f
+		}
+	}
+	return fn
+}
+
+func newRouteCall(site ssa.CallInstruction) *RouteCall {
+	common := site.Common()
+	if common.IsInvoke() {
+		m := common.Method
+		if m.Pkg() == nil {
+			return nil
+		}
+		rc := &RouteCall{Pkg: m.Pkg().Path(), Name: m.Name(), Receiver: common.Value, Args: common.Args, Site: site}
+		if named := namedType(common.Value.Type()); named != nil {
+			rc.Type = named.Obj().Name()
+		}
+		return rc
+	}
+
+	fn := common.StaticCallee()
+	if fn == nil {
+		return nil
+	}
+	obj, ok := fn.Object().(*types.Func)
+	if !ok || obj.Pkg() == nil {
+		return nil
+	}
+	rc := &RouteCall{Pkg: obj.Pkg().Path(), Name: obj.Name(), Args: common.Args, Site: site}
+	if recv := fn.Signature.Recv(); recv != nil && len(common.Args) > 0 {
+		if named := namedType(recv.Type()); named != nil {
+			rc.Type = named.Obj().Name()
+		}
+		rc.Receiver, rc.Args = common.Args[0], common.Args[1:]
+	}
+	return rc
+}
+
+// routerFunc tells which arguments of a router function give the parts of a route.
+type routerFunc struct {
+	method     string // fixed http method, empty when given by an argument or the path pattern
+	methodArg  int    // index of the method argument, -1 when there is none
+	pathArg    int
+	handlerArg int // index of the handler argument, -1 for the last of the variadic handlers
+}
+
+// router extracts the routes registered through the functions of some packages.
+type router struct {
+	name   string
+	pkgs   []string
+	funcs  map[string]routerFunc // keyed by Type.Name, or Name for a package function
+	groups map[string]bool       // functions creating a group of routes under the path prefix of their first argument
+	// methods names the method restricting the http methods of the route returned by a registration
+	methods string
+}
+
+func (r *router) Router() string {
+	return r.name
+}
+
+func (r *router) Extract(call *RouteCall) (RouteSpec, bool) {
+	if !r.owns(call.Pkg) {
+		return RouteSpec{}, false
+	}
+	f, ok := r.funcs[call.key()]
+	if !ok || f.pathArg >= len(call.Args) || f.handlerArg >= len(call.Args) || len(call.Args) == 0 {
+		return RouteSpec{}, false
+	}
+	p, ok := constString(call.Args[f.pathArg])
+	if !ok {
+		return RouteSpec{}, false
+	}
+
+	spec := RouteSpec{Method: f.method, Path: joinRoute(r.prefix(call.Receiver), p)}
+	if f.methodArg >= 0 {
+		spec.Method, _ = constString(call.Args[f.methodArg])
+	}
+	if spec.Method == "" && r.methods != "" {
+		spec.Method = r.chainedMethods(call.Site)
+	}
+	if f.handlerArg >= 0 {
+		spec.Handler = call.Args[f.handlerArg]
+	} else if hs := variadicValues(call.Args[len(call.Args)-1]); len(hs) > 0 {
+		spec.Handler = hs[len(hs)-1]
+	}
+	return spec, spec.Handler != nil
+}
+
+var majorVersion = regexp.MustCompile(`/v[0-9]+$`)
+
+func (r *router) owns(pkg string) bool {
+	pkg = majorVersion.ReplaceAllString(pkg, "")
+	for _, p := range r.pkgs {
+		if p == pkg {
+			return true
+		}
+	}
+	return false
+}
+
+// prefix is the path prefix of the groups the receiver was created by.
+func (r *router) prefix(v ssa.Value) string {
+	switch x := v.(type) {
+	case *ssa.FieldAddr:
+		return r.prefix(x.X)
+	case *ssa.Call:
+		c := newRouteCall(x)
+		if c == nil || !r.owns(c.Pkg) || !r.groups[c.key()] || len(c.Args) == 0 {
+			return ""
+		}
+		if p, ok := constString(c.Args[0]); ok {
+			return joinRoute(r.prefix(c.Receiver), p)
+		}
+	}
+	return ""
+}
+
+func (r *router) chainedMethods(site ssa.CallInstruction) string {
+	v := site.Value()
+	if v == nil || v.Referrers() == nil {
+		return ""
+	}
+	var methods []string
+	for _, instr := range *v.Referrers() {
+		call, ok := instr.(*ssa.Call)
+		if !ok {
+			continue
+		}
+		c := newRouteCall(call)
+		if c == nil || c.Name != r.methods || c.Receiver != v || len(c.Args) == 0 {
+			continue
+		}
+		for _, arg := range variadicValues(c.Args[0]) {
+			if m, ok := constString(arg); ok {
+				methods = append(methods, strings.ToUpper(m))
+			}
+		}
+	}
+	return strings.Join(methods, ",")
+}
+
+func joinRoute(prefix, p string) string {
+	if prefix == "" {
+		return p
+	}
+	if p == "" {
+		return prefix
+	}
+	return strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(p, "/")
+}
+
+func constString(v ssa.Value) (string, bool) {
+	if c, ok := v.(*ssa.Const); ok && c.Value != nil && c.Value.Kind() == constant.String {
+		return constant.StringVal(c.Value), true
+	}
+	return "", false
+}
+
+// variadicValues returns the values stored into the slice built for a variadic parameter.
+func variadicValues(v ssa.Value) []ssa.Value {
+	s, ok := v.(*ssa.Slice)
+	if !ok {
+		return nil
+	}
+	alloc, ok := s.X.(*ssa.Alloc)
+	if !ok {
+		return nil
+	}
+
+	values := make(map[int64]ssa.Value)
+	var last int64 = -1
+	for _, instr := range *alloc.Referrers() {
+		ia, ok := instr.(*ssa.IndexAddr)
+		if !ok {
+			continue
+		}
+		idx, ok := ia.Index.(*ssa.Const)
+		if !ok {
+			continue
+		}
+		i := idx.Int64()
+		for _, ref := range *ia.Referrers() {
+			if st, ok := ref.(*ssa.Store); ok && st.Addr == ia {
+				values[i] = st.Val
+				if i > last {
+					last = i
+				}
+			}
+		}
+	}
+
+	var vs []ssa.Value
+	for i := int64(0); i <= last; i++ {
+		if val, ok := values[i]; ok {
+			vs = append(vs, val)
+		}
+	}
+	return vs
+}
+
+var httpMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "CONNECT", "OPTIONS", "TRACE"}
+
+// verbFuncs are the functions named after the http methods, by name in upper case or in title case.
+func verbFuncs(upper bool, pathArg, handlerArg int) map[string]routerFunc {
+	fs := make(map[string]routerFunc)
+	for _, m := range httpMethods {
+		name := m
+		if !upper {
+			name = m[:1] + strings.ToLower(m[1:])
+		}
+		fs[name] = routerFunc{method: m, methodArg: -1, pathArg: pathArg, handlerArg: handlerArg}
+	}
+	return fs
+}
+
+// methodsOf declares the functions fs on every type.
+func methodsOf(typeNames []string, fs ...map[string]routerFunc) map[string]routerFunc {
+	methods := make(map[string]routerFunc)
+	for _, t := range typeNames {
+		for _, f := range fs {
+			for name, rf := range f {
+				methods[t+"."+name] = rf
+			}
+		}
+	}
+	return methods
+}
+
+func netHTTPRouter() *router {
+	handle := routerFunc{methodArg: -1, pathArg: 0, handlerArg: 1}
+	funcs := methodsOf([]string{"ServeMux"}, map[string]routerFunc{"Handle": handle, "HandleFunc": handle})
+	funcs["Handle"], funcs["HandleFunc"] = handle, handle
+	return &router{name: "net/http", pkgs: []string{"net/http"}, funcs: funcs}
+}
+
+func chiRouter() *router {
+	return &router{
+		name: "chi",
+		pkgs: []string{"github.com/go-chi/chi"},
+		funcs: methodsOf([]string{"Mux", "Router"}, verbFuncs(false, 0, 1), map[string]routerFunc{
+			"Handle":     {methodArg: -1, pathArg: 0, handlerArg: 1},
+			"HandleFunc": {methodArg: -1, pathArg: 0, handlerArg: 1},
+			"Method":     {methodArg: 0, pathArg: 1, handlerArg: 2},
+			"MethodFunc": {methodArg: 0, pathArg: 1, handlerArg: 2},
+		}),
+	}
+}
+
+func gorillaRouter() *router {
+	return &router{
+		name: "gorilla/mux",
+		pkgs: []string{"github.com/gorilla/mux"},
+		funcs: methodsOf([]string{"Router"}, map[string]routerFunc{
+			"Handle":     {methodArg: -1, pathArg: 0, handlerArg: 1},
+			"HandleFunc": {methodArg: -1, pathArg: 0, handlerArg: 1},
+		}),
+		methods: "Methods",
+	}
+}
+
+func ginRouter() *router {
+	return &router{
+		name: "gin",
+		pkgs: []string{"github.com/gin-gonic/gin"},
+		funcs: methodsOf([]string{"RouterGroup", "IRoutes", "IRouter"}, verbFuncs(true, 0, -1), map[string]routerFunc{
+			"Any":    {methodArg: -1, pathArg: 0, handlerArg: -1},
+			"Handle": {methodArg: 0, pathArg: 1, handlerArg: -1},
+		}),
+		groups: map[string]bool{"RouterGroup.Group": true, "IRouter.Group": true},
+	}
+}
+
+func echoRouter() *router {
+	return &router{
+		name: "echo",
+		pkgs: []string{"github.com/labstack/echo"},
+		funcs: methodsOf([]string{"Echo", "Group"}, verbFuncs(true, 0, 1), map[string]routerFunc{
+			"Any": {methodArg: -1, pathArg: 0, handlerArg: 1},
+			"Add": {methodArg: 0, pathArg: 1, handlerArg: 2},
+		}),
+		groups: map[string]bool{"Echo.Group": true, "Group.Group": true},
+	}
+}
@@ -0,0 +1,194 @@
+package entity
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"golang.org/x/tools/go/ssa"
+	"reflect"
+	"testing"
+)
+
+var routerSources = [][2]string{
+	{"net/http", `package http
+type ResponseWriter interface{ Write([]byte) (int, error) }
+type Request struct{}
+type Handler interface{ ServeHTTP(ResponseWriter, *Request) }
+type HandlerFunc func(ResponseWriter, *Request)
+func (f HandlerFunc) ServeHTTP(w ResponseWriter, r *Request) { f(w, r) }
+type ServeMux struct{}
+func NewServeMux() *ServeMux { return &ServeMux{} }
+func (mux *ServeMux) Handle(pattern string, handler Handler) {}
+func (mux *ServeMux) HandleFunc(pattern string, handler func(ResponseWriter, *Request)) {}
+func Handle(pattern string, handler Handler) {}
+`},
+	{"github.com/go-chi/chi/v5", `package chi
+import "net/http"
+type Router interface {
+	Get(pattern string, h http.HandlerFunc)
+	Method(method, pattern string, h http.Handler)
+}
+type Mux struct{}
+func NewRouter() *Mux { return &Mux{} }
+func (mx *Mux) Get(pattern string, h http.HandlerFunc) {}
+func (mx *Mux) Method(method, pattern string, h http.Handler) {}
+`},
+	{"github.com/gorilla/mux", `package mux
+import "net/http"
+type Route struct{}
+func (r *Route) Methods(methods ...string) *Route { return r }
+type Router struct{}
+func NewRouter() *Router { return &Router{} }
+func (r *Router) HandleFunc(path string, f func(http.ResponseWriter, *http.Request)) *Route { return &Route{} }
+`},
+	{"github.com/gin-gonic/gin", `package gin
+type Context struct{}
+type HandlerFunc func(*Context)
+type RouterGroup struct{}
+func (group *RouterGroup) Group(relativePath string, handlers ...HandlerFunc) *RouterGroup { return group }
+func (group *RouterGroup) GET(relativePath string, handlers ...HandlerFunc) {}
+func (group *RouterGroup) Handle(httpMethod, relativePath string, handlers ...HandlerFunc) {}
+type Engine struct{ RouterGroup }
+func Default() *Engine { return &Engine{} }
+`},
+	{"github.com/labstack/echo/v4", `package echo
+type Context interface{ String(code int, s string) error }
+type HandlerFunc func(Context) error
+type MiddlewareFunc func(HandlerFunc) HandlerFunc
+type Group struct{}
+func (g *Group) POST(path string, h HandlerFunc, m ...MiddlewareFunc) {}
+type Echo struct{}
+func New() *Echo { return &Echo{} }
+func (e *Echo) Group(prefix string, m ...MiddlewareFunc) *Group { return &Group{} }
+func (e *Echo) GET(path string, h HandlerFunc, m ...MiddlewareFunc) {}
+`},
+	{"example.com/app/api", `package api
+import (
+	"net/http"
+	"github.com/gin-gonic/gin"
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/mux"
+	"github.com/labstack/echo/v4"
+)
+type Server struct{}
+func (s *Server) ListOrders(w http.ResponseWriter, r *http.Request) {}
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {}
+func (s *Server) GetOrder(c *gin.Context) {}
+func (s *Server) PlaceOrder(c echo.Context) error { return nil }
+func auth(c *gin.Context) {}
+func Health(w http.ResponseWriter, r *http.Request) {}
+
+func Routes(s *Server) {
+	m := http.NewServeMux()
+	m.HandleFunc("GET /orders", s.ListOrders)
+	http.Handle("/server", s)
+
+	var cr chi.Router = chi.NewRouter()
+	cr.Get("/health", Health)
+	chi.NewRouter().Method("delete", "/orders", http.HandlerFunc(s.ListOrders))
+
+	mux.NewRouter().HandleFunc("/orders", s.ListOrders).Methods("GET", "HEAD")
+
+	g := gin.Default()
+	v1 := g.Group("/v1")
+	v1.GET("/orders/:id", auth, s.GetOrder)
+	g.Handle("PUT", "/ping", func(c *gin.Context) {})
+
+	e := echo.New()
+	e.Group("/api").POST("/orders", s.PlaceOrder)
+}
+`},
+}
+
+func newRouterProgram(t *testing.T) *ssa.Program {
+	fset := token.NewFileSet()
+	prog := ssa.NewProgram(fset, ssa.BuilderMode(0))
+	pkgs := make(map[string]*types.Package)
+
+	for _, src := range routerSources {
+		f, err := parser.ParseFile(fset, src[0]+".go", src[1], 0)
+		if err != nil {
+			t.Fatalf("parse %s: %v", src[0], err)
+		}
+		info := &types.Info{
+			Types:      make(map[ast.Expr]types.TypeAndValue),
+			Defs:       make(map[*ast.Ident]types.Object),
+			Uses:       make(map[*ast.Ident]types.Object),
+			Implicits:  make(map[ast.Node]types.Object),
+			Scopes:     make(map[ast.Node]*types.Scope),
+			Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		}
+		conf := types.Config{Importer: importerFunc(func(path string) (*types.Package, error) {
+			if p, ok := pkgs[path]; ok {
+				return p, nil
+			}
+			return nil, fmt.Errorf("package %s not found", path)
+		})}
+		pkg, err := conf.Check(src[0], fset, []*ast.File{f}, info)
+		if err != nil {
+			t.Fatalf("check %s: %v", src[0], err)
+		}
+		pkgs[src[0]] = pkg
+		prog.CreatePackage(pkg, []*ast.File{f}, info, true)
+	}
+	prog.Build()
+
+	return prog
+}
+
+func TestGo_Routes(t *testing.T) {
+	golang := &Go{DomainPkgPath: "example.com/app", prog: newRouterProgram(t)}
+
+	var routes []string
+	for _, r := range golang.routes() {
+		h := golang.funcNode(r.handler)
+		routes = append(routes, fmt.Sprintf("%s %s %s -> %s.%s", r.router, r.method, r.path, h.Meta.Parent(), h.Meta.Name()))
+	}
+
+	expected := []string{
+		"net/http GET /orders -> Server.ListOrders",
+		"net/http  /server -> Server.ServeHTTP",
+		"chi GET /health -> .Health",
+		"chi DELETE /orders -> Server.ListOrders",
+		"gorilla/mux GET,HEAD /orders -> Server.ListOrders",
+		"gin GET /v1/orders/:id -> Server.GetOrder",
+		"gin PUT /ping -> .Routes$1",
+		"echo POST /api/orders -> Server.PlaceOrder",
+	}
+	if !reflect.DeepEqual(routes, expected) {
+		t.Errorf("Expected routes\n%v\nbut got\n%v", expected, routes)
+	}
+}
+
+func TestRouter_Owns(t *testing.T) {
+	r := chiRouter()
+	tests := map[string]bool{
+		"github.com/go-chi/chi":       true,
+		"github.com/go-chi/chi/v5":    true,
+		"github.com/go-chi/chi/v5/mw": false,
+		"github.com/go-chi/render":    false,
+	}
+	for pkg, expected := range tests {
+		if got := r.owns(pkg); got != expected {
+			t.Errorf("owns(%s) = %v, want %v", pkg, got, expected)
+		}
+	}
+}
+
+func TestJoinRoute(t *testing.T) {
+	tests := []struct {
+		prefix, path, expected string
+	}{
+		{"", "/orders", "/orders"},
+		{"/v1", "", "/v1"},
+		{"/v1/", "/orders", "/v1/orders"},
+		{"/v1", "orders", "/v1/orders"},
+	}
+	for _, tt := range tests {
+		if got := joinRoute(tt.prefix, tt.path); got != tt.expected {
+			t.Errorf("joinRoute(%q, %q) = %q, want %q", tt.prefix, tt.path, got, tt.expected)
+		}
+	}
+}
@@ -8,9 +8,10 @@ const (
 )
 
 type Link struct {
-	From     *Node
-	To       *Node
-	Relation RelationShip
+	From      *Node
+	To        *Node
+	Relation  RelationShip
+	Algorithm CallGraphType
 }
 
 type NodeType int
@@ -28,44 +29,86 @@ const (
 	TypeFunc
 	TypeAny
 	TypeNone
+	TypeGenInstance
+	TypeFuncParam
+	TypeFuncResult
+	TypeGenInterfaceEmbedded
+)
+
+type Annotation string
+
+const (
+	AnnotationAggregateRoot Annotation = "aggregate-root"
+	AnnotationEntity        Annotation = "entity"
+	AnnotationValueObject   Annotation = "valueobject"
+	AnnotationDomainEvent   Annotation = "domain-event"
+	AnnotationCommand       Annotation = "command"
+	AnnotationRepository    Annotation = "repository"
 )
 
 type Node struct {
-	Meta   MetaInfo
-	Pos    Position
-	Parent *Node
-	Type   NodeType
+	Meta        MetaInfo
+	Pos         Position
+	Parent      *Node
+	Type        NodeType
+	Annotations []Annotation
+	// Mutating methods have a pointer receiver and assign to the fields it points to.
+	Mutating bool
+}
+
+// Route is a handler function registered on an http router for a method and path pattern,
+// an empty method stands for any method.
+type Route struct {
+	Router  string
+	Method  string
+	Path    string
+	Handler *Node
+	Pos     Position
 }
 
 type NodeCB func(node *Node)
 type LinkCB func(link *Link)
+type RouteCB func(route *Route)
 
 type Handler interface {
 	NodeHandler(node *Node)
 	LinkHandler(link *Link)
 }
 
+// RouteHandler is implemented by the handlers which also want the http routes of the code.
+type RouteHandler interface {
+	RouteHandler(route *Route)
+}
+
 type CallGraphType string
 
 const (
 	CallGraphTypeStatic  CallGraphType = "static"
-	CallGraphTypeCha                   = "cha"
-	CallGraphTypeRta                   = "rta"
-	CallGraphTypePointer               = "pointer"
-)
-
-type CallGraphMode uint8
-
-const (
-	CallGraphFastMode CallGraphMode = iota + 1
-	CallGraphDeepMode
+	CallGraphTypeCha     CallGraphType = "cha"
+	CallGraphTypeRta     CallGraphType = "rta"
+	CallGraphTypePointer CallGraphType = "pointer"
 )
 
 type Language interface {
 	VisitFile(nodeCB NodeCB, linkCB LinkCB)
 	InterfaceImplements(linkCB LinkCB)
-	CallGraph(linkCB LinkCB, mode CallGraphMode) error
+	CallGraph(linkCB LinkCB, algo CallGraphType) error
+	Routes(routeCB RouteCB)
 	MainPkgPath() string
+	Packages() []*Package
+}
+
+// Package is a loaded package of the domain, with the domain packages it imports.
+type Package struct {
+	Path    string
+	Files   []string
+	Imports []string
+}
+
+// Reloader is implemented by the languages which can load again some packages of the code
+// without loading the rest of it.
+type Reloader interface {
+	Reload(pkgs []string) error
 }
 
 type MetaInfo interface {
@@ -0,0 +1,38 @@
+package valueobject
+
+import (
+	"github.com/dddplayer/dp/internal/domain/code"
+	"go/ast"
+	"golang.org/x/exp/slices"
+	"strings"
+)
+
+const annotationPrefix = "//dp:"
+
+var knownAnnotations = []code.Annotation{
+	code.AnnotationAggregateRoot,
+	code.AnnotationEntity,
+	code.AnnotationValueObject,
+	code.AnnotationDomainEvent,
+	code.AnnotationCommand,
+	code.AnnotationRepository,
+}
+
+func Annotations(groups ...*ast.CommentGroup) []code.Annotation {
+	var as []code.Annotation
+	for _, g := range groups {
+		if g == nil {
+			continue
+		}
+		for _, c := range g.List {
+			if !strings.HasPrefix(c.Text, annotationPrefix) {
+				continue
+			}
+			a := code.Annotation(strings.TrimSpace(strings.TrimPrefix(c.Text, annotationPrefix)))
+			if slices.Contains(knownAnnotations, a) && !slices.Contains(as, a) {
+				as = append(as, a)
+			}
+		}
+	}
+	return as
+}
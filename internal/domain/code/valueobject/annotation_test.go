@@ -0,0 +1,31 @@
+package valueobject
+
+import (
+	"github.com/dddplayer/dp/internal/domain/code"
+	"go/ast"
+	"reflect"
+	"testing"
+)
+
+func TestAnnotations(t *testing.T) {
+	declDoc := &ast.CommentGroup{List: []*ast.Comment{
+		{Text: "// Order is the aggregate root."},
+		{Text: "//dp:aggregate-root"},
+		{Text: "//dp:unknown"},
+		{Text: "// dp:entity"},
+	}}
+	specDoc := &ast.CommentGroup{List: []*ast.Comment{
+		{Text: "//dp:entity "},
+		{Text: "//dp:aggregate-root"},
+	}}
+
+	as := Annotations(declDoc, nil, specDoc)
+	expected := []code.Annotation{code.AnnotationAggregateRoot, code.AnnotationEntity}
+	if !reflect.DeepEqual(as, expected) {
+		t.Errorf("Expected annotations %v, but got %v", expected, as)
+	}
+
+	if as := Annotations(nil); len(as) != 0 {
+		t.Errorf("Expected no annotations, but got %v", as)
+	}
+}
@@ -0,0 +1,134 @@
+package valueobject
+
+import "github.com/dddplayer/dp/internal/domain/code"
+
+// Event is a serializable node, link or route reported while visiting the code.
+type Event struct {
+	Node  *NodeRecord  `json:"node,omitempty"`
+	Link  *LinkRecord  `json:"link,omitempty"`
+	Route *RouteRecord `json:"route,omitempty"`
+}
+
+type PosRecord struct {
+	Filename string `json:"filename"`
+	Offset   int    `json:"offset"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+}
+
+type NodeRecord struct {
+	Pkg         string            `json:"pkg"`
+	Name        string            `json:"name"`
+	ParentName  string            `json:"parentName,omitempty"`
+	Pos         *PosRecord        `json:"pos,omitempty"`
+	Parent      *NodeRecord       `json:"parent,omitempty"`
+	Type        code.NodeType     `json:"type,omitempty"`
+	Annotations []code.Annotation `json:"annotations,omitempty"`
+	Mutating    bool              `json:"mutating,omitempty"`
+}
+
+type LinkRecord struct {
+	From      *NodeRecord        `json:"from"`
+	To        *NodeRecord        `json:"to"`
+	Relation  code.RelationShip  `json:"relation"`
+	Algorithm code.CallGraphType `json:"algorithm,omitempty"`
+}
+
+type RouteRecord struct {
+	Router  string      `json:"router"`
+	Method  string      `json:"method,omitempty"`
+	Path    string      `json:"path"`
+	Handler *NodeRecord `json:"handler"`
+	Pos     *PosRecord  `json:"pos,omitempty"`
+}
+
+func NewNodeRecord(n *code.Node) *NodeRecord {
+	if n == nil {
+		return nil
+	}
+	r := &NodeRecord{
+		Parent:      NewNodeRecord(n.Parent),
+		Type:        n.Type,
+		Annotations: n.Annotations,
+		Mutating:    n.Mutating,
+	}
+	if n.Meta != nil {
+		r.Pkg, r.Name, r.ParentName = n.Meta.Pkg(), n.Meta.Name(), n.Meta.Parent()
+	}
+	r.Pos = newPosRecord(n.Pos)
+	return r
+}
+
+func newPosRecord(p code.Position) *PosRecord {
+	if p == nil {
+		return nil
+	}
+	return &PosRecord{
+		Filename: p.Filename(),
+		Offset:   p.Offset(),
+		Line:     p.Line(),
+		Column:   p.Column(),
+	}
+}
+
+func (r *PosRecord) position() code.Position {
+	if r == nil {
+		return nil
+	}
+	return NewPosition(r.Filename, r.Offset, r.Line, r.Column)
+}
+
+func (r *NodeRecord) Node() *code.Node {
+	if r == nil {
+		return nil
+	}
+	n := &code.Node{
+		Meta:        NewMetaWithParent(r.Pkg, r.Name, r.ParentName),
+		Parent:      r.Parent.Node(),
+		Type:        r.Type,
+		Annotations: r.Annotations,
+		Mutating:    r.Mutating,
+	}
+	if r.Pos != nil {
+		n.Pos = r.Pos.position()
+	}
+	return n
+}
+
+func NewLinkRecord(l *code.Link) *LinkRecord {
+	return &LinkRecord{
+		From:      NewNodeRecord(l.From),
+		To:        NewNodeRecord(l.To),
+		Relation:  l.Relation,
+		Algorithm: l.Algorithm,
+	}
+}
+
+func (r *LinkRecord) Link() *code.Link {
+	return &code.Link{
+		From:      r.From.Node(),
+		To:        r.To.Node(),
+		Relation:  r.Relation,
+		Algorithm: r.Algorithm,
+	}
+}
+
+func NewRouteRecord(r *code.Route) *RouteRecord {
+	return &RouteRecord{
+		Router:  r.Router,
+		Method:  r.Method,
+		Path:    r.Path,
+		Handler: NewNodeRecord(r.Handler),
+		Pos:     newPosRecord(r.Pos),
+	}
+}
+
+func (r *RouteRecord) Route() *code.Route {
+	return &code.Route{
+		Router:  r.Router,
+		Method:  r.Method,
+		Path:    r.Path,
+		Handler: r.Handler.Node(),
+		Pos:     r.Pos.position(),
+	}
+}
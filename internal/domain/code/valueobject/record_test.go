@@ -0,0 +1,55 @@
+package valueobject
+
+import (
+	"github.com/dddplayer/dp/internal/domain/code"
+	"testing"
+)
+
+func TestNodeRecord(t *testing.T) {
+	n := &code.Node{
+		Meta:        NewMetaWithParent("test/order", "Place", "Order"),
+		Pos:         NewPosition("order.go", 30, 4, 2),
+		Parent:      &code.Node{Meta: NewMeta("test/order", "Order")},
+		Type:        code.TypeFunc,
+		Annotations: []code.Annotation{code.AnnotationEntity},
+		Mutating:    true,
+	}
+
+	res := NewNodeRecord(n).Node()
+	if res.Meta.Pkg() != "test/order" || res.Meta.Name() != "Place" || res.Meta.Parent() != "Order" {
+		t.Errorf("Unexpected meta %s %s %s", res.Meta.Pkg(), res.Meta.Name(), res.Meta.Parent())
+	}
+	if res.Pos.Filename() != "order.go" || res.Pos.Offset() != 30 || res.Pos.Line() != 4 || res.Pos.Column() != 2 {
+		t.Errorf("Unexpected position %+v", res.Pos)
+	}
+	if res.Parent == nil || res.Parent.Meta.Name() != "Order" || res.Parent.Pos != nil {
+		t.Errorf("Unexpected parent %+v", res.Parent)
+	}
+	if res.Type != code.TypeFunc || len(res.Annotations) != 1 || res.Annotations[0] != code.AnnotationEntity {
+		t.Errorf("Unexpected type %v or annotations %v", res.Type, res.Annotations)
+	}
+	if !res.Mutating {
+		t.Error("Expected the mutating flag kept")
+	}
+
+	if NewNodeRecord(nil).Node() != nil {
+		t.Error("Expected nil node to stay nil")
+	}
+}
+
+func TestLinkRecord(t *testing.T) {
+	l := &code.Link{
+		From:      &code.Node{Meta: NewMeta("test/order", "Place"), Type: code.TypeFunc},
+		To:        &code.Node{Meta: NewMeta("test/order", "Pay"), Type: code.TypeFunc},
+		Relation:  code.OneOne,
+		Algorithm: code.CallGraphTypeCha,
+	}
+
+	res := NewLinkRecord(l).Link()
+	if res.From.Meta.Name() != "Place" || res.To.Meta.Name() != "Pay" {
+		t.Errorf("Unexpected link from %s to %s", res.From.Meta.Name(), res.To.Meta.Name())
+	}
+	if res.Relation != code.OneOne || res.Algorithm != code.CallGraphTypeCha {
+		t.Errorf("Unexpected relation %v or algorithm %s", res.Relation, res.Algorithm)
+	}
+}
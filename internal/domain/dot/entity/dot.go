@@ -55,6 +55,7 @@ type Edge struct {
 	L       string
 	T       string
 	A       string
+	C       string
 }
 
 func (d *Dot) Write(w io.Writer) error {
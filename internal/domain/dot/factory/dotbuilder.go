@@ -119,7 +119,7 @@ func (db *DotBuilder) buildEdge(e arch.Edge) *entity.Edge {
 			toPort = fmt.Sprintf("%s:%s", nodePort, toPort)
 		}
 	}
-	return &entity.Edge{
+	edge := &entity.Edge{
 		From:    fromPort,
 		To:      toPort,
 		Tooltip: fmt.Sprintf("%s -> %s: \n\n%s", path.Base(e.From()), path.Base(e.To()), ConcatenateRelationPos(e.Pos())),
@@ -127,6 +127,10 @@ func (db *DotBuilder) buildEdge(e arch.Edge) *entity.Edge {
 		T:       string(db.edgeStyle(e)),
 		A:       string(db.arrowHead(e)),
 	}
+	if e.Highlighted() {
+		edge.C = dot.EdgeColorHighlight
+	}
+	return edge
 }
 
 func ConcatenateRelationPos(relations []arch.RelationPos) string {
@@ -200,6 +200,24 @@ func TestDotBuilder_buildEdge(t *testing.T) {
 	}
 }
 
+func TestDotBuilder_buildEdge_Highlighted(t *testing.T) {
+	mockDiagram, err := archEntity.NewDiagram("MockOtherDiagram", arch.PlainDiagram)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	dotBuilder := &DotBuilder{archDiagram: mockDiagram, portMap: make(map[string]string)}
+
+	edge := dotBuilder.buildEdge(&DummyDotEdge{FromVal: "NodeA", ToVal: "NodeB", T: arch.RelationTypeDependency, H: true})
+	if edge.C != dot.EdgeColorHighlight {
+		t.Errorf("Expected highlight color %s, but got %s", dot.EdgeColorHighlight, edge.C)
+	}
+
+	edge = dotBuilder.buildEdge(&DummyDotEdge{FromVal: "NodeA", ToVal: "NodeB", T: arch.RelationTypeDependency})
+	if edge.C != "" {
+		t.Errorf("Expected no color, but got %s", edge.C)
+	}
+}
+
 func TestDotBuilder_buildEdges(t *testing.T) {
 	// 创建一个虚拟的 arch.Diagram 对象
 	mockDiagram, err := archEntity.NewDiagram("test", arch.TableDiagram)
@@ -34,6 +34,7 @@ type DummyDotEdge struct {
 	L       string
 	TT      string
 	T       arch.RelationType
+	H       bool
 }
 
 func (e *DummyDotEdge) From() string                 { return e.FromVal }
@@ -44,6 +45,7 @@ func (e *DummyDotEdge) ArrowHead() dot.EdgeArrowHead { return dot.EdgeArrowHeadO
 func (e *DummyDotEdge) Count() int                   { return 1 }
 func (e *DummyDotEdge) Pos() []arch.RelationPos      { return []arch.RelationPos{} }
 func (e *DummyDotEdge) Type() arch.RelationType      { return e.T }
+func (e *DummyDotEdge) Highlighted() bool            { return e.H }
 
 type DummyDotElement struct {
 	NameVal       string
@@ -15,4 +15,6 @@ const (
 	EdgeTypeSolid EdgeType = "solid"
 	EdgeTypeDot   EdgeType = "dotted"
 	EdgeTypeDash  EdgeType = "dashed"
+
+	EdgeColorHighlight = "#cc0000"
 )
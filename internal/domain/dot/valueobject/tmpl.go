@@ -1,7 +1,7 @@
 package valueobject
 
 const TmplEdge = `{{define "edge" -}}
-    {{printf "%s -> %s  [style=%s arrowhead=%s label=%q tooltip=%q]" .From .To .T .A .L .Tooltip}}
+    {{printf "%s -> %s  [style=%s arrowhead=%s label=%q tooltip=%q" .From .To .T .A .L .Tooltip}}{{if .C}}{{printf " color=%q penwidth=2" .C}}{{end}}]
 {{- end}}`
 
 const TmplColumn = `{{define "column" -}}
@@ -0,0 +1,211 @@
+package entity
+
+import (
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/export"
+	"path"
+	"sort"
+	"strings"
+)
+
+type Change string
+
+const (
+	ChangeAdded   Change = "added"
+	ChangeRemoved Change = "removed"
+	ChangeChanged Change = "changed"
+)
+
+type ObjectDiff struct {
+	Object  *Object
+	Change  Change
+	Details []string
+}
+
+type RelationDiff struct {
+	Type   string
+	From   string
+	To     string
+	Change Change
+}
+
+type AggregateDiff struct {
+	Name    string
+	Domain  string
+	Change  Change
+	Details []string
+}
+
+type Diff struct {
+	Objects    []*ObjectDiff
+	Relations  []*RelationDiff
+	Aggregates []*AggregateDiff
+}
+
+func (d *Diff) Empty() bool {
+	return len(d.Objects) == 0 && len(d.Relations) == 0 && len(d.Aggregates) == 0
+}
+
+// Compare reports how the domain model of head differs from base. Only types take part,
+// relations of attributes and methods are compared as relations of their types.
+func Compare(base, head *Model) *Diff {
+	d := &Diff{}
+
+	baseTypes, headTypes := modelTypes(base), modelTypes(head)
+	for id, h := range headTypes {
+		b, ok := baseTypes[id]
+		if !ok {
+			d.Objects = append(d.Objects, &ObjectDiff{Object: h, Change: ChangeAdded})
+			continue
+		}
+		if details := objectDetails(b, h); len(details) > 0 {
+			d.Objects = append(d.Objects, &ObjectDiff{Object: h, Change: ChangeChanged, Details: details})
+		}
+	}
+	for id, b := range baseTypes {
+		if _, ok := headTypes[id]; !ok {
+			d.Objects = append(d.Objects, &ObjectDiff{Object: b, Change: ChangeRemoved})
+		}
+	}
+	sort.Slice(d.Objects, func(i, j int) bool {
+		return d.Objects[i].Object.ID < d.Objects[j].Object.ID
+	})
+
+	baseRels, headRels := typeRelations(base, baseTypes), typeRelations(head, headTypes)
+	for key, r := range headRels {
+		if _, ok := baseRels[key]; !ok {
+			d.Relations = append(d.Relations, &RelationDiff{Type: r.Type, From: r.From, To: r.To, Change: ChangeAdded})
+		}
+	}
+	for key, r := range baseRels {
+		if _, ok := headRels[key]; !ok {
+			d.Relations = append(d.Relations, &RelationDiff{Type: r.Type, From: r.From, To: r.To, Change: ChangeRemoved})
+		}
+	}
+	sort.Slice(d.Relations, func(i, j int) bool {
+		return relationKey(d.Relations[i].Type, d.Relations[i].From, d.Relations[i].To) <
+			relationKey(d.Relations[j].Type, d.Relations[j].From, d.Relations[j].To)
+	})
+
+	d.Aggregates = compareAggregates(base.Aggregates, head.Aggregates)
+
+	return d
+}
+
+func modelTypes(m *Model) map[string]*Object {
+	types := make(map[string]*Object)
+	for _, o := range m.Objects {
+		if o.Kind == string(export.KindClass) || o.Kind == string(export.KindInterface) || o.Role != "" {
+			types[o.ID] = o
+		}
+	}
+	return types
+}
+
+func objectDetails(base, head *Object) []string {
+	var details []string
+	if base.Kind != head.Kind {
+		details = append(details, fmt.Sprintf("kind %s -> %s", base.Kind, head.Kind))
+	}
+	if base.Role != head.Role {
+		details = append(details, fmt.Sprintf("role %s -> %s", orNone(base.Role), orNone(head.Role)))
+	}
+	if base.Aggregate != head.Aggregate {
+		details = append(details, fmt.Sprintf("aggregate %s -> %s", orNone(base.Aggregate), orNone(head.Aggregate)))
+	}
+	return details
+}
+
+func typeRelations(m *Model, types map[string]*Object) map[string]*Relation {
+	rels := make(map[string]*Relation)
+	for _, r := range m.Relations {
+		from, to := ownerType(r.From), ownerType(r.To)
+		if from == to || types[from] == nil || types[to] == nil {
+			continue
+		}
+		rels[relationKey(r.Type, from, to)] = &Relation{Type: r.Type, From: from, To: to}
+	}
+	return rels
+}
+
+// ownerType returns the type an attribute or method id belongs to, and a type id as it is.
+func ownerType(id string) string {
+	name := strings.Split(path.Base(id), ".")[0]
+	return path.Join(path.Dir(id), name)
+}
+
+func relationKey(t, from, to string) string {
+	return fmt.Sprintf("%s|%s|%s", t, from, to)
+}
+
+func compareAggregates(base, head []*Aggregate) []*AggregateDiff {
+	key := func(a *Aggregate) string { return a.Domain + "|" + a.Name }
+	baseMap := make(map[string]*Aggregate)
+	for _, a := range base {
+		baseMap[key(a)] = a
+	}
+
+	var diffs []*AggregateDiff
+	seen := make(map[string]bool)
+	for _, h := range head {
+		seen[key(h)] = true
+		b, ok := baseMap[key(h)]
+		if !ok {
+			diffs = append(diffs, &AggregateDiff{Name: h.Name, Domain: h.Domain, Change: ChangeAdded})
+			continue
+		}
+		var details []string
+		if b.Root != h.Root {
+			details = append(details, fmt.Sprintf("root %s -> %s", orNone(b.Root), orNone(h.Root)))
+		}
+		details = append(details, memberDetails(string(export.RoleEntity), b.Entities, h.Entities)...)
+		details = append(details, memberDetails(string(export.RoleValueObject), b.ValueObjects, h.ValueObjects)...)
+		if len(details) > 0 {
+			diffs = append(diffs, &AggregateDiff{Name: h.Name, Domain: h.Domain, Change: ChangeChanged, Details: details})
+		}
+	}
+	for _, b := range base {
+		if !seen[key(b)] {
+			diffs = append(diffs, &AggregateDiff{Name: b.Name, Domain: b.Domain, Change: ChangeRemoved})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Domain != diffs[j].Domain {
+			return diffs[i].Domain < diffs[j].Domain
+		}
+		return diffs[i].Name < diffs[j].Name
+	})
+	return diffs
+}
+
+func memberDetails(role string, base, head []string) []string {
+	var details []string
+	for _, id := range head {
+		if !contains(base, id) {
+			details = append(details, fmt.Sprintf("%s +%s", role, id))
+		}
+	}
+	for _, id := range base {
+		if !contains(head, id) {
+			details = append(details, fmt.Sprintf("%s -%s", role, id))
+		}
+	}
+	return details
+}
+
+func contains(ids []string, id string) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	base := &Model{
+		Objects: []*Object{
+			{ID: "app/order/Order", Name: "Order", Kind: "class", Role: "aggregateRoot", Aggregate: "Order"},
+			{ID: "app/order/Order.items", Name: "Order.items", Kind: "attr"},
+			{ID: "app/order/Item", Name: "Item", Kind: "class", Role: "entity", Aggregate: "Order"},
+			{ID: "app/order/Money", Name: "Money", Kind: "class", Role: "valueObject", Aggregate: "Order"},
+			{ID: "app/order/total", Name: "total", Kind: "function"},
+		},
+		Relations: []*Relation{
+			{Type: "associationOneMany", From: "app/order/Order.items", To: "app/order/Item"},
+			{Type: "composition", From: "app/order/Order", To: "app/order/Order.items"},
+			{Type: "dependency", From: "app/order/Item.Price", To: "app/order/Money.Add"},
+		},
+		Aggregates: []*Aggregate{
+			{Name: "Order", Domain: "order", Root: "app/order/Order",
+				Entities: []string{"app/order/Item"}, ValueObjects: []string{"app/order/Money"}},
+		},
+	}
+	head := &Model{
+		Objects: []*Object{
+			{ID: "app/order/Order", Name: "Order", Kind: "class", Role: "aggregateRoot", Aggregate: "Order"},
+			{ID: "app/order/Order.items", Name: "Order.items", Kind: "attr"},
+			{ID: "app/order/Item", Name: "Item", Kind: "class", Role: "valueObject", Aggregate: "Order"},
+			{ID: "app/order/Address", Name: "Address", Kind: "class", Role: "valueObject", Aggregate: "Order"},
+		},
+		Relations: []*Relation{
+			{Type: "associationOneMany", From: "app/order/Order.items", To: "app/order/Item"},
+			{Type: "association", From: "app/order/Order.addr", To: "app/order/Address"},
+		},
+		Aggregates: []*Aggregate{
+			{Name: "Order", Domain: "order", Root: "app/order/Order",
+				Entities: []string{}, ValueObjects: []string{"app/order/Item", "app/order/Address"}},
+			{Name: "Payment", Domain: "payment"},
+		},
+	}
+
+	d := Compare(base, head)
+
+	var objs []string
+	for _, o := range d.Objects {
+		objs = append(objs, string(o.Change)+" "+o.Object.ID)
+	}
+	expected := []string{"added app/order/Address", "changed app/order/Item", "removed app/order/Money"}
+	if !reflect.DeepEqual(objs, expected) {
+		t.Errorf("Expected objects %v, but got %v", expected, objs)
+	}
+	if !reflect.DeepEqual(d.Objects[1].Details, []string{"role entity -> valueObject"}) {
+		t.Errorf("Expected role change details, but got %v", d.Objects[1].Details)
+	}
+
+	var rels []string
+	for _, r := range d.Relations {
+		rels = append(rels, string(r.Change)+" "+relationKey(r.Type, r.From, r.To))
+	}
+	expected = []string{
+		"added association|app/order/Order|app/order/Address",
+		"removed dependency|app/order/Item|app/order/Money",
+	}
+	if !reflect.DeepEqual(rels, expected) {
+		t.Errorf("Expected relations %v, but got %v", expected, rels)
+	}
+
+	if len(d.Aggregates) != 2 || d.Aggregates[0].Change != ChangeChanged || d.Aggregates[1].Change != ChangeAdded {
+		t.Fatalf("Expected Order changed and Payment added, but got %+v", d.Aggregates)
+	}
+	expected = []string{"entity -app/order/Item", "valueObject +app/order/Item",
+		"valueObject +app/order/Address", "valueObject -app/order/Money"}
+	if !reflect.DeepEqual(d.Aggregates[0].Details, expected) {
+		t.Errorf("Expected details %v, but got %v", expected, d.Aggregates[0].Details)
+	}
+
+	if d.Empty() || !Compare(head, head).Empty() {
+		t.Errorf("Expected only different models to have changes")
+	}
+}
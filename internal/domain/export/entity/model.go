@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type Model struct {
+	SchemaVersion string       `json:"schemaVersion"`
+	Scope         string       `json:"scope"`
+	Objects       []*Object    `json:"objects"`
+	Relations     []*Relation  `json:"relations"`
+	Aggregates    []*Aggregate `json:"aggregates"`
+}
+
+type Object struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Package   string    `json:"package"`
+	Kind      string    `json:"kind"`
+	Role      string    `json:"role,omitempty"`
+	Aggregate string    `json:"aggregate,omitempty"`
+	Position  *Position `json:"position"`
+}
+
+type Position struct {
+	Filename string `json:"filename"`
+	Offset   int    `json:"offset"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+}
+
+type Relation struct {
+	Type      string       `json:"type"`
+	From      string       `json:"from"`
+	To        string       `json:"to"`
+	Position  *RelationPos `json:"position"`
+	Algorithm string       `json:"algorithm,omitempty"`
+}
+
+type RelationPos struct {
+	From *Position `json:"from"`
+	To   *Position `json:"to"`
+}
+
+type Aggregate struct {
+	Name         string   `json:"name"`
+	Domain       string   `json:"domain"`
+	Root         string   `json:"root,omitempty"`
+	Entities     []string `json:"entities"`
+	ValueObjects []string `json:"valueObjects"`
+}
+
+func (m *Model) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(m)
+}
@@ -0,0 +1,209 @@
+package factory
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	archVO "github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"github.com/dddplayer/dp/internal/domain/export"
+	"github.com/dddplayer/dp/internal/domain/export/entity"
+	"sort"
+)
+
+func NewModelBuilder(scope string,
+	objRepo repository.ObjectRepository,
+	relRepo repository.RelationRepository,
+	aggregates []*archVO.AggregateGroup) *ModelBuilder {
+
+	return &ModelBuilder{
+		scope:      scope,
+		objRepo:    objRepo,
+		relRepo:    relRepo,
+		aggregates: aggregates,
+		objMap:     make(map[string]*entity.Object),
+	}
+}
+
+type ModelBuilder struct {
+	scope      string
+	objRepo    repository.ObjectRepository
+	relRepo    repository.RelationRepository
+	aggregates []*archVO.AggregateGroup
+	model      *entity.Model
+	objMap     map[string]*entity.Object
+}
+
+func (mb *ModelBuilder) Build() (*entity.Model, error) {
+	mb.model = &entity.Model{
+		SchemaVersion: export.SchemaVersion,
+		Scope:         mb.scope,
+		Objects:       []*entity.Object{},
+		Relations:     []*entity.Relation{},
+		Aggregates:    []*entity.Aggregate{},
+	}
+
+	mb.buildObjects()
+	if err := mb.buildAggregates(); err != nil {
+		return nil, err
+	}
+	mb.buildRelations()
+
+	return mb.model, nil
+}
+
+func (mb *ModelBuilder) buildObjects() {
+	for _, id := range mb.objRepo.All() {
+		obj := mb.objRepo.Find(id)
+		if obj == nil {
+			continue
+		}
+		o := &entity.Object{
+			ID:       id.ID(),
+			Name:     id.Name(),
+			Package:  id.Dir(),
+			Kind:     string(objKind(obj)),
+			Role:     string(annotatedRole(obj)),
+			Position: position(obj.Position()),
+		}
+		mb.model.Objects = append(mb.model.Objects, o)
+		mb.objMap[o.ID] = o
+	}
+
+	sort.Slice(mb.model.Objects, func(i, j int) bool {
+		return mb.model.Objects[i].ID < mb.model.Objects[j].ID
+	})
+}
+
+func (mb *ModelBuilder) buildAggregates() error {
+	for _, ag := range mb.aggregates {
+		a, err := ag.Aggregate()
+		if err != nil {
+			return err
+		}
+
+		agg := &entity.Aggregate{
+			Name:         ag.Name(),
+			Domain:       ag.DomainName(),
+			Entities:     []string{},
+			ValueObjects: []string{},
+		}
+		if a.Entity != nil {
+			agg.Root = a.Entity.OriginIdentifier().ID()
+		}
+
+		for _, sg := range ag.SubGroups() {
+			switch g := sg.(type) {
+			case *archVO.EntityGroup:
+				for _, e := range g.Entities() {
+					id := e.OriginIdentifier().ID()
+					agg.Entities = append(agg.Entities, id)
+					mb.assignRole(id, export.RoleEntity, agg.Name)
+				}
+			case *archVO.VOGroup:
+				for _, vo := range g.ValueObjects() {
+					id := vo.OriginIdentifier().ID()
+					agg.ValueObjects = append(agg.ValueObjects, id)
+					mb.assignRole(id, export.RoleValueObject, agg.Name)
+				}
+			case *archVO.RepositoryGroup:
+				for _, r := range g.Repositories() {
+					mb.assignRole(r.OriginIdentifier().ID(), export.RoleRepository, agg.Name)
+				}
+			case *archVO.FactoryGroup:
+				for _, f := range g.Factories() {
+					mb.assignRole(f.OriginIdentifier().ID(), export.RoleFactory, agg.Name)
+				}
+			}
+		}
+		if agg.Root != "" {
+			mb.assignRole(agg.Root, export.RoleAggregateRoot, agg.Name)
+		}
+
+		mb.model.Aggregates = append(mb.model.Aggregates, agg)
+	}
+	return nil
+}
+
+func (mb *ModelBuilder) assignRole(id string, role export.Role, aggregate string) {
+	if o, ok := mb.objMap[id]; ok {
+		o.Role = string(role)
+		o.Aggregate = aggregate
+	}
+}
+
+func (mb *ModelBuilder) buildRelations() {
+	mb.relRepo.Walk(func(rel arch.Relation) error {
+		switch r := rel.(type) {
+		case arch.DependenceRelation:
+			mb.appendRelation(rel.Type(), r.From(), r.DependsOn()).Algorithm = r.Algorithm()
+		case arch.CompositionRelation:
+			mb.appendRelation(rel.Type(), r.From(), r.Child())
+		case arch.EmbeddingRelation:
+			mb.appendRelation(rel.Type(), r.From(), r.Embedded())
+		case arch.ImplementationRelation:
+			for _, ifc := range r.Implements() {
+				mb.appendRelation(rel.Type(), r.From(), ifc)
+			}
+		case arch.AssociationRelation:
+			mb.appendRelation(r.AssociationType(), r.From(), r.Refer())
+		case arch.UsageRelation:
+			mb.appendRelation(rel.Type(), r.From(), r.Used())
+		}
+		return nil
+	})
+}
+
+func (mb *ModelBuilder) appendRelation(t arch.RelationType, from, to arch.Object) *entity.Relation {
+	r := &entity.Relation{
+		Type: export.RelationTypeNames[t],
+		From: from.Identifier().ID(),
+		To:   to.Identifier().ID(),
+		Position: &entity.RelationPos{
+			From: position(from.Position()),
+			To:   position(to.Position()),
+		},
+	}
+	mb.model.Relations = append(mb.model.Relations, r)
+	return r
+}
+
+func objKind(obj arch.Object) export.ObjectKind {
+	switch obj.(type) {
+	case *archVO.Class, *archVO.MissingReceiver:
+		return export.KindClass
+	case *archVO.Attr:
+		return export.KindAttr
+	case *archVO.Function, *archVO.InterfaceMethod:
+		return export.KindFunction
+	case *archVO.Interface:
+		return export.KindInterface
+	}
+	return export.KindGeneral
+}
+
+func annotatedRole(obj arch.Object) export.Role {
+	a, ok := obj.(arch.Annotated)
+	if !ok {
+		return export.RoleNone
+	}
+	switch {
+	case a.HasRole(arch.DomainRoleDomainEvent):
+		return export.RoleDomainEvent
+	case a.HasRole(arch.DomainRoleCommand):
+		return export.RoleCommand
+	case a.HasRole(arch.DomainRoleRepository):
+		return export.RoleRepository
+	}
+	return export.RoleNone
+}
+
+func position(p arch.Position) *entity.Position {
+	if p == nil {
+		return &entity.Position{}
+	}
+	return &entity.Position{
+		Filename: p.Filename(),
+		Offset:   p.Offset(),
+		Line:     p.Line(),
+		Column:   p.Column(),
+	}
+}
@@ -0,0 +1,159 @@
+package factory
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/dddplayer/dp/internal/domain/arch"
+	archVO "github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"github.com/dddplayer/dp/internal/domain/export"
+	"github.com/dddplayer/dp/internal/domain/export/entity"
+	"testing"
+)
+
+const domainDir = "test/internal/domain/order"
+
+func generateMockRepos() (*MockObjectRepository, *MockRelationRepository, []*archVO.AggregateGroup) {
+	order := archVO.NewClass(newMockObject(domainDir+"/entity", "Order", 3), nil, nil)
+	price := archVO.NewClass(newMockObject(domainDir+"/valueobject", "Price", 5), nil, nil)
+	attr := archVO.NewAttr(newMockObject(domainDir+"/entity", "Order.Total", 4))
+	fn := archVO.NewFunction(newMockObject(domainDir+"/entity", "Order.Pay", 7), order.Identifier())
+	payer := newMockObject(domainDir, "Payer", 9)
+	payer.roles = []arch.DomainRole{arch.DomainRoleRepository}
+	ifc := archVO.NewInterface(payer, nil)
+	gen := archVO.NewGeneral(newMockObject("test/cmd", "version", 1))
+
+	objRepo := &MockObjectRepository{objects: make(map[string]arch.Object), idents: []arch.ObjIdentifier{}}
+	for _, o := range []arch.Object{order, price, attr, fn, ifc, gen} {
+		_ = objRepo.Insert(o)
+	}
+
+	relRepo := &MockRelationRepository{relations: []arch.Relation{
+		&MockDependenceRelation{from: fn, dependsOn: price, algorithm: "cha"},
+		&MockImplementationRelation{from: order, implements: []arch.Object{ifc}},
+		&MockAssociationRelation{from: order, refer: price, t: arch.RelationTypeAssociationOneMany},
+	}}
+
+	ag := archVO.NewAggregateGroup(&archVO.Aggregate{Name: "order"}, domainDir)
+	ag.AppendGroups(
+		archVO.NewEntityGroup(domainDir, order),
+		archVO.NewVOGroup(domainDir, price),
+	)
+
+	return objRepo, relRepo, []*archVO.AggregateGroup{ag}
+}
+
+func findObject(m *entity.Model, id string) *entity.Object {
+	for _, o := range m.Objects {
+		if o.ID == id {
+			return o
+		}
+	}
+	return nil
+}
+
+func TestBuild(t *testing.T) {
+	objRepo, relRepo, ags := generateMockRepos()
+
+	m, err := NewModelBuilder("test", objRepo, relRepo, ags).Build()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	if m.SchemaVersion != export.SchemaVersion || m.Scope != "test" {
+		t.Errorf("Unexpected header %s %s", m.SchemaVersion, m.Scope)
+	}
+	if len(m.Objects) != 6 {
+		t.Fatalf("Expected 6 objects, but got %d", len(m.Objects))
+	}
+	for i := 1; i < len(m.Objects); i++ {
+		if m.Objects[i-1].ID > m.Objects[i].ID {
+			t.Errorf("Expected objects sorted by id")
+		}
+	}
+
+	kinds := map[string]export.ObjectKind{
+		domainDir + "/entity/Order":       export.KindClass,
+		domainDir + "/entity/Order.Total": export.KindAttr,
+		domainDir + "/entity/Order.Pay":   export.KindFunction,
+		domainDir + "/Payer":              export.KindInterface,
+		"test/cmd/version":                export.KindGeneral,
+	}
+	for id, kind := range kinds {
+		o := findObject(m, id)
+		if o == nil {
+			t.Errorf("Expected object %s", id)
+			continue
+		}
+		if o.Kind != string(kind) {
+			t.Errorf("Expected %s kind %s, but got %s", id, kind, o.Kind)
+		}
+	}
+
+	order := findObject(m, domainDir+"/entity/Order")
+	if order.Role != string(export.RoleAggregateRoot) || order.Aggregate != "order" {
+		t.Errorf("Expected Order to be aggregate root, but got %s %s", order.Role, order.Aggregate)
+	}
+	if order.Position.Line != 3 || order.Position.Filename != domainDir+"/entity/file.go" {
+		t.Errorf("Unexpected position %+v", order.Position)
+	}
+	if payer := findObject(m, domainDir+"/Payer"); payer.Role != string(export.RoleRepository) {
+		t.Errorf("Expected Payer to be repository, but got %s", payer.Role)
+	}
+	price := findObject(m, domainDir+"/valueobject/Price")
+	if price.Role != string(export.RoleValueObject) {
+		t.Errorf("Expected Price to be value object, but got %s", price.Role)
+	}
+
+	if len(m.Aggregates) != 1 {
+		t.Fatalf("Expected 1 aggregate, but got %d", len(m.Aggregates))
+	}
+	agg := m.Aggregates[0]
+	if agg.Root != order.ID || len(agg.Entities) != 1 || len(agg.ValueObjects) != 1 {
+		t.Errorf("Unexpected aggregate %+v", agg)
+	}
+
+	if len(m.Relations) != 3 {
+		t.Fatalf("Expected 3 relations, but got %d", len(m.Relations))
+	}
+	dep := m.Relations[0]
+	if dep.Type != "dependency" || dep.From != domainDir+"/entity/Order.Pay" || dep.To != price.ID || dep.Algorithm != "cha" {
+		t.Errorf("Unexpected dependency %+v", dep)
+	}
+	if dep.Position.From.Line != 7 || dep.Position.To.Line != 5 {
+		t.Errorf("Unexpected relation position %+v %+v", dep.Position.From, dep.Position.To)
+	}
+	if m.Relations[1].Type != "implementation" || m.Relations[2].Type != "associationOneMany" {
+		t.Errorf("Unexpected relation types %s %s", m.Relations[1].Type, m.Relations[2].Type)
+	}
+}
+
+func TestModelWrite(t *testing.T) {
+	objRepo, relRepo, ags := generateMockRepos()
+	m, err := NewModelBuilder("test", objRepo, relRepo, ags).Build()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := m.Write(&buf); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Expected valid json, but got: %v", err)
+	}
+	for _, k := range []string{"schemaVersion", "scope", "objects", "relations", "aggregates"} {
+		if _, ok := decoded[k]; !ok {
+			t.Errorf("Expected key %s in output", k)
+		}
+	}
+}
+
+func TestRelationTypeNames(t *testing.T) {
+	for rt := arch.RelationTypeAssociationOneOne; rt <= arch.RelationTypeNone; rt++ {
+		if export.RelationTypeNames[rt] == "" {
+			t.Errorf("Expected name for relation type %d", rt)
+		}
+	}
+}
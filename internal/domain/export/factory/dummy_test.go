@@ -0,0 +1,176 @@
+package factory
+
+import (
+	"errors"
+	"github.com/dddplayer/dp/internal/domain/arch"
+)
+
+type MockIdentifier struct {
+	name string
+	dir  string
+}
+
+func (mi *MockIdentifier) ID() string               { return mi.dir + "/" + mi.name }
+func (mi *MockIdentifier) Name() string             { return mi.name }
+func (mi *MockIdentifier) NameSeparatorLength() int { return 1 }
+func (mi *MockIdentifier) Dir() string              { return mi.dir }
+
+type MockPosition struct {
+	filename string
+	line     int
+}
+
+func (mp *MockPosition) Filename() string { return mp.filename }
+func (mp *MockPosition) Offset() int      { return 0 }
+func (mp *MockPosition) Line() int        { return mp.line }
+func (mp *MockPosition) Column() int      { return 1 }
+func (mp *MockPosition) IsEqual(pos arch.Position) bool {
+	return mp.Filename() == pos.Filename() && mp.Line() == pos.Line()
+}
+
+type MockObject struct {
+	id    *MockIdentifier
+	pos   *MockPosition
+	roles []arch.DomainRole
+}
+
+func (mo *MockObject) Identifier() arch.ObjIdentifier { return mo.id }
+func (mo *MockObject) Position() arch.Position        { return mo.pos }
+func (mo *MockObject) Roles() []arch.DomainRole       { return mo.roles }
+func (mo *MockObject) HasRole(role arch.DomainRole) bool {
+	for _, r := range mo.roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func newMockObject(dir, name string, line int) *MockObject {
+	return &MockObject{
+		id:  &MockIdentifier{name: name, dir: dir},
+		pos: &MockPosition{filename: dir + "/file.go", line: line},
+	}
+}
+
+type MockObjectRepository struct {
+	objects map[string]arch.Object
+	idents  []arch.ObjIdentifier
+}
+
+func (mor *MockObjectRepository) Find(id arch.ObjIdentifier) arch.Object {
+	return mor.objects[id.ID()]
+}
+
+func (mor *MockObjectRepository) GetObjects(ids []arch.ObjIdentifier) ([]arch.Object, error) {
+	var result []arch.Object
+	for _, id := range ids {
+		result = append(result, mor.objects[id.ID()])
+	}
+	return result, nil
+}
+
+func (mor *MockObjectRepository) All() []arch.ObjIdentifier {
+	return mor.idents
+}
+
+func (mor *MockObjectRepository) Insert(obj arch.Object) error {
+	mor.objects[obj.Identifier().ID()] = obj
+	mor.idents = append(mor.idents, obj.Identifier())
+	return nil
+}
+
+func (mor *MockObjectRepository) Update(obj arch.Object) error {
+	if _, ok := mor.objects[obj.Identifier().ID()]; !ok {
+		return errors.New("object not found in mock repository")
+	}
+	mor.objects[obj.Identifier().ID()] = obj
+	return nil
+}
+
+func (mor *MockObjectRepository) Delete(id arch.ObjIdentifier) error {
+	if _, ok := mor.objects[id.ID()]; !ok {
+		return errors.New("object not found in mock repository")
+	}
+	delete(mor.objects, id.ID())
+	for i, ident := range mor.idents {
+		if ident.ID() == id.ID() {
+			mor.idents = append(mor.idents[:i], mor.idents[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (mor *MockObjectRepository) Walk(walker func(obj arch.Object) error) {
+	for _, obj := range mor.objects {
+		if err := walker(obj); err != nil {
+			break
+		}
+	}
+}
+
+type MockRelationRepository struct {
+	relations []arch.Relation
+}
+
+func (mrr *MockRelationRepository) Insert(rel arch.Relation) error {
+	mrr.relations = append(mrr.relations, rel)
+	return nil
+}
+
+func (mrr *MockRelationRepository) Delete(match func(rel arch.Relation) bool) int {
+	var kept []arch.Relation
+	for _, rel := range mrr.relations {
+		if !match(rel) {
+			kept = append(kept, rel)
+		}
+	}
+	deleted := len(mrr.relations) - len(kept)
+	mrr.relations = kept
+	return deleted
+}
+
+func (mrr *MockRelationRepository) Walk(walker func(rel arch.Relation) error) {
+	for _, rel := range mrr.relations {
+		if err := walker(rel); err != nil {
+			break
+		}
+	}
+}
+
+type MockDependenceRelation struct {
+	from      arch.Object
+	dependsOn arch.Object
+	algorithm string
+}
+
+func (r *MockDependenceRelation) Type() arch.RelationType { return arch.RelationTypeDependency }
+func (r *MockDependenceRelation) From() arch.Object       { return r.from }
+func (r *MockDependenceRelation) DependsOn() arch.Object  { return r.dependsOn }
+func (r *MockDependenceRelation) Algorithm() string       { return r.algorithm }
+
+type MockImplementationRelation struct {
+	from       arch.Object
+	implements []arch.Object
+}
+
+func (r *MockImplementationRelation) Type() arch.RelationType { return arch.RelationTypeImplementation }
+func (r *MockImplementationRelation) From() arch.Object       { return r.from }
+func (r *MockImplementationRelation) Implements() []arch.Object {
+	return r.implements
+}
+func (r *MockImplementationRelation) Implemented(obj arch.Object) {
+	r.implements = append(r.implements, obj)
+}
+
+type MockAssociationRelation struct {
+	from  arch.Object
+	refer arch.Object
+	t     arch.RelationType
+}
+
+func (r *MockAssociationRelation) Type() arch.RelationType            { return arch.RelationTypeAssociation }
+func (r *MockAssociationRelation) From() arch.Object                  { return r.from }
+func (r *MockAssociationRelation) Refer() arch.Object                 { return r.refer }
+func (r *MockAssociationRelation) AssociationType() arch.RelationType { return r.t }
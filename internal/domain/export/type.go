@@ -0,0 +1,48 @@
+package export
+
+import "github.com/dddplayer/dp/internal/domain/arch"
+
+const SchemaVersion = "1"
+
+type ObjectKind string
+
+const (
+	KindClass     ObjectKind = "class"
+	KindAttr      ObjectKind = "attr"
+	KindFunction  ObjectKind = "function"
+	KindInterface ObjectKind = "interface"
+	KindGeneral   ObjectKind = "general"
+)
+
+type Role string
+
+const (
+	RoleNone          Role = ""
+	RoleAggregateRoot Role = "aggregateRoot"
+	RoleEntity        Role = "entity"
+	RoleValueObject   Role = "valueObject"
+	RoleDomainEvent   Role = "domainEvent"
+	RoleCommand       Role = "command"
+	RoleRepository    Role = "repository"
+	RoleFactory       Role = "factory"
+)
+
+var RelationTypeNames = map[arch.RelationType]string{
+	arch.RelationTypeAssociationOneOne:  "associationOneOne",
+	arch.RelationTypeAssociationOneMany: "associationOneMany",
+	arch.RelationTypeAssociation:        "association",
+	arch.RelationTypeComposition:        "composition",
+	arch.RelationTypeEmbedding:          "embedding",
+	arch.RelationTypeAggregation:        "aggregation",
+	arch.RelationTypeAggregationRoot:    "aggregationRoot",
+	arch.RelationTypeDependency:         "dependency",
+	arch.RelationTypeImplementation:     "implementation",
+	arch.RelationTypeAbstraction:        "abstraction",
+	arch.RelationTypeAttribution:        "attribution",
+	arch.RelationTypeBehavior:           "behavior",
+	arch.RelationTypeInstantiation:      "instantiation",
+	arch.RelationTypeParameter:          "parameter",
+	arch.RelationTypeResult:             "result",
+	arch.RelationTypeInterfaceEmbedding: "interfaceEmbedding",
+	arch.RelationTypeNone:               "none",
+}
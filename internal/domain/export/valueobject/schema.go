@@ -0,0 +1,87 @@
+package valueobject
+
+const Schema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "dddplayer/model/v1",
+  "title": "DDD Player analysis model",
+  "type": "object",
+  "required": ["schemaVersion", "scope", "objects", "relations", "aggregates"],
+  "properties": {
+    "schemaVersion": { "const": "1" },
+    "scope": { "type": "string" },
+    "objects": {
+      "type": "array",
+      "items": { "$ref": "#/$defs/object" }
+    },
+    "relations": {
+      "type": "array",
+      "items": { "$ref": "#/$defs/relation" }
+    },
+    "aggregates": {
+      "type": "array",
+      "items": { "$ref": "#/$defs/aggregate" }
+    }
+  },
+  "$defs": {
+    "position": {
+      "type": "object",
+      "required": ["filename", "offset", "line", "column"],
+      "properties": {
+        "filename": { "type": "string" },
+        "offset": { "type": "integer" },
+        "line": { "type": "integer" },
+        "column": { "type": "integer" }
+      }
+    },
+    "object": {
+      "type": "object",
+      "required": ["id", "name", "package", "kind", "position"],
+      "properties": {
+        "id": { "type": "string" },
+        "name": { "type": "string" },
+        "package": { "type": "string" },
+        "kind": { "enum": ["class", "attr", "function", "interface", "general"] },
+        "role": { "enum": ["aggregateRoot", "entity", "valueObject", "domainEvent", "command", "repository", "factory"] },
+        "aggregate": { "type": "string" },
+        "position": { "$ref": "#/$defs/position" }
+      }
+    },
+    "relation": {
+      "type": "object",
+      "required": ["type", "from", "to", "position"],
+      "properties": {
+        "type": {
+          "enum": [
+            "associationOneOne", "associationOneMany", "association", "composition",
+            "embedding", "aggregation", "aggregationRoot", "dependency",
+            "implementation", "abstraction", "attribution", "behavior",
+            "instantiation", "parameter", "result", "interfaceEmbedding", "none"
+          ]
+        },
+        "from": { "type": "string" },
+        "to": { "type": "string" },
+        "algorithm": { "type": "string" },
+        "position": {
+          "type": "object",
+          "required": ["from", "to"],
+          "properties": {
+            "from": { "$ref": "#/$defs/position" },
+            "to": { "$ref": "#/$defs/position" }
+          }
+        }
+      }
+    },
+    "aggregate": {
+      "type": "object",
+      "required": ["name", "domain", "entities", "valueObjects"],
+      "properties": {
+        "name": { "type": "string" },
+        "domain": { "type": "string" },
+        "root": { "type": "string" },
+        "entities": { "type": "array", "items": { "type": "string" } },
+        "valueObjects": { "type": "array", "items": { "type": "string" } }
+      }
+    }
+  }
+}
+`
@@ -0,0 +1,23 @@
+package valueobject
+
+import (
+	"encoding/json"
+	"github.com/dddplayer/dp/internal/domain/export"
+	"testing"
+)
+
+func TestSchema(t *testing.T) {
+	var s map[string]interface{}
+	if err := json.Unmarshal([]byte(Schema), &s); err != nil {
+		t.Fatalf("Expected schema to be valid json, but got: %v", err)
+	}
+
+	props, ok := s["properties"].(map[string]interface{})
+	if !ok {
+		t.Fatal("Expected schema properties")
+	}
+	version, ok := props["schemaVersion"].(map[string]interface{})
+	if !ok || version["const"] != export.SchemaVersion {
+		t.Errorf("Expected schemaVersion const %s, but got %v", export.SchemaVersion, props["schemaVersion"])
+	}
+}
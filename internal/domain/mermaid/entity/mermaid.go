@@ -0,0 +1,99 @@
+package entity
+
+import (
+	"bytes"
+	"github.com/dddplayer/dp/internal/domain/mermaid"
+	"io"
+	"strings"
+	"text/template"
+)
+
+type Mermaid struct {
+	Name         string
+	Kind         mermaid.DiagramKind
+	SubGraphs    []*SubGraph
+	Namespaces   []*Namespace
+	Participants []*Node
+	Edges        []*Edge
+	Styles       []*Style
+	Templates    []string
+}
+
+type SubGraph struct {
+	ID        string
+	Label     string
+	Nodes     []*Node
+	SubGraphs []*SubGraph
+}
+
+type Node struct {
+	ID    string
+	Label string
+}
+
+type Namespace struct {
+	Name    string
+	Classes []*Class
+}
+
+type Class struct {
+	ID         string
+	Label      string
+	Annotation string
+	Attributes []string
+	Methods    []string
+}
+
+type Edge struct {
+	From             string
+	To               string
+	Arrow            string
+	Label            string
+	FromMultiplicity string
+	ToMultiplicity   string
+	Highlight        bool
+}
+
+type Style struct {
+	ID    string
+	Color string
+}
+
+func (m *Mermaid) Write(w io.Writer) error {
+	t := template.New("mermaid")
+	for _, s := range m.Templates {
+		if _, err := t.Parse(s); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, m); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, trimBlankLines(buf.String()))
+	return err
+}
+
+func trimBlankLines(s string) string {
+	var lines []string
+	for _, l := range strings.Split(s, "\n") {
+		if strings.TrimSpace(l) != "" {
+			lines = append(lines, l)
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func (m *Mermaid) AddStyle(id, color string) {
+	if color == "" {
+		return
+	}
+	for _, s := range m.Styles {
+		if s.ID == id {
+			return
+		}
+	}
+	m.Styles = append(m.Styles, &Style{ID: id, Color: color})
+}
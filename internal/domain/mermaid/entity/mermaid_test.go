@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"bytes"
+	"github.com/dddplayer/dp/internal/domain/mermaid/valueobject"
+	"testing"
+)
+
+func TestAddStyle(t *testing.T) {
+	m := &Mermaid{}
+	m.AddStyle("a", "red")
+	m.AddStyle("a", "blue")
+	m.AddStyle("b", "")
+
+	if len(m.Styles) != 1 {
+		t.Fatalf("Expected 1 style, but got %d", len(m.Styles))
+	}
+	if m.Styles[0].Color != "red" {
+		t.Errorf("Expected first color to win, but got %s", m.Styles[0].Color)
+	}
+}
+
+func TestTrimBlankLines(t *testing.T) {
+	s := "a\n\n  \nb\n"
+	expected := "a\nb\n"
+	if res := trimBlankLines(s); res != expected {
+		t.Errorf("trimBlankLines(%q) = %q, expected %q", s, res, expected)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	m := &Mermaid{
+		Name: "demo",
+		SubGraphs: []*SubGraph{
+			{ID: "s1", Label: "pkg", Nodes: []*Node{{ID: "n1", Label: "A"}, {ID: "n2", Label: "B"}}},
+		},
+		Edges:  []*Edge{{From: "n1", To: "n2", Arrow: "-->", Label: "2"}},
+		Styles: []*Style{{ID: "n1", Color: "#fff"}},
+		Templates: []string{
+			valueobject.TmplTitle, valueobject.TmplFlowNode, valueobject.TmplFlowSubGraph,
+			valueobject.TmplFlowEdge, valueobject.TmplFlowchart,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := m.Write(&buf); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	expected := `---
+title: demo
+---
+flowchart LR
+    subgraph s1["pkg"]
+    n1["A"]
+    n2["B"]
+    end
+    n1 -->|2| n2
+    style n1 fill:#fff
+`
+	if buf.String() != expected {
+		t.Errorf("Unexpected output:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
+
+func TestWriteInvalidTemplate(t *testing.T) {
+	m := &Mermaid{Templates: []string{"{{"}}
+	if err := m.Write(&bytes.Buffer{}); err == nil {
+		t.Error("Expected an error for invalid template")
+	}
+}
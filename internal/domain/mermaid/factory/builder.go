@@ -0,0 +1,206 @@
+package factory
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	archVO "github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"github.com/dddplayer/dp/internal/domain/mermaid"
+	"github.com/dddplayer/dp/internal/domain/mermaid/entity"
+	"github.com/dddplayer/dp/internal/domain/mermaid/valueobject"
+	"path"
+	"strconv"
+	"strings"
+)
+
+func NewMermaidBuilder(diagram arch.Diagram) *MermaidBuilder {
+	return &MermaidBuilder{
+		archDiagram: diagram,
+		classMapper: archVO.NewClassMapper(),
+	}
+}
+
+type MermaidBuilder struct {
+	archDiagram arch.Diagram
+	mermaid     *entity.Mermaid
+	classMapper *archVO.ClassMapper
+}
+
+func (mb *MermaidBuilder) Build() (*entity.Mermaid, error) {
+	mb.mermaid = &entity.Mermaid{
+		Name:       mb.archDiagram.Name(),
+		SubGraphs:  []*entity.SubGraph{},
+		Namespaces: []*entity.Namespace{},
+		Edges:      []*entity.Edge{},
+		Styles:     []*entity.Style{},
+	}
+
+	if mb.isClassMode() {
+		mb.mermaid.Kind = mermaid.KindClassDiagram
+		mb.buildNamespaces()
+		mb.buildClassEdges()
+	} else {
+		mb.mermaid.Kind = mermaid.KindFlowchart
+		mb.buildSubGraphs()
+		mb.buildFlowEdges()
+	}
+	mb.buildTemplates()
+
+	return mb.mermaid, nil
+}
+
+func (mb *MermaidBuilder) isClassMode() bool {
+	return mb.archDiagram.Type() == arch.TableDiagram
+}
+
+func (mb *MermaidBuilder) buildSubGraphs() {
+	for _, sd := range mb.archDiagram.SubDiagrams() {
+		mb.mermaid.SubGraphs = append(mb.mermaid.SubGraphs, mb.buildSubGraph(sd))
+	}
+}
+
+func (mb *MermaidBuilder) buildSubGraph(sd arch.SubDiagram) *entity.SubGraph {
+	g := &entity.SubGraph{
+		ID:        valueobject.NodeID(sd.Name() + "_subgraph"),
+		Label:     valueobject.Label(sd.Name()),
+		Nodes:     []*entity.Node{},
+		SubGraphs: []*entity.SubGraph{},
+	}
+
+	for _, n := range sd.Nodes() {
+		id := valueobject.NodeID(n.ID())
+		g.Nodes = append(g.Nodes, &entity.Node{
+			ID:    id,
+			Label: valueobject.Label(path.Base(n.Name())),
+		})
+		mb.mermaid.AddStyle(id, n.Color())
+	}
+
+	for _, ssd := range sd.SubGraphs() {
+		g.SubGraphs = append(g.SubGraphs, mb.buildSubGraph(ssd))
+	}
+
+	return g
+}
+
+func (mb *MermaidBuilder) buildFlowEdges() {
+	for _, e := range mb.archDiagram.Edges() {
+		mb.mermaid.Edges = append(mb.mermaid.Edges, &entity.Edge{
+			From:      valueobject.NodeID(e.From()),
+			To:        valueobject.NodeID(e.To()),
+			Arrow:     string(flowArrow(e.Type())),
+			Label:     strconv.Itoa(e.Count()),
+			Highlight: e.Highlighted(),
+		})
+	}
+}
+
+func (mb *MermaidBuilder) buildNamespaces() {
+	for _, sd := range mb.archDiagram.SubDiagrams() {
+		mb.buildNamespace(sd)
+	}
+}
+
+func (mb *MermaidBuilder) buildNamespace(sd arch.SubDiagram) {
+	ns := &entity.Namespace{
+		Name:    valueobject.Namespace(sd.Name()),
+		Classes: []*entity.Class{},
+	}
+
+	for _, e := range sd.Summary() {
+		ns.Classes = append(ns.Classes, mb.buildClass(e))
+	}
+	if len(ns.Classes) > 0 {
+		mb.mermaid.Namespaces = append(mb.mermaid.Namespaces, ns)
+	}
+
+	for _, ssd := range sd.SubGraphs() {
+		mb.buildNamespace(ssd)
+	}
+}
+
+func (mb *MermaidBuilder) buildClass(e arch.Element) *entity.Class {
+	dc := mb.classMapper.Class(e)
+	id := valueobject.NodeID(dc.ID)
+	c := &entity.Class{
+		ID:         id,
+		Label:      valueobject.Label(dc.Name),
+		Annotation: dc.Stereotype,
+		Attributes: labels(dc.Attributes),
+		Methods:    labels(dc.Methods),
+	}
+	mb.mermaid.AddStyle(id, dc.Color)
+
+	return c
+}
+
+func (mb *MermaidBuilder) buildClassEdges() {
+	for _, ce := range mb.classMapper.Edges(mb.archDiagram.Edges()) {
+		edge := &entity.Edge{
+			From:             valueobject.NodeID(ce.From),
+			To:               valueobject.NodeID(ce.To),
+			Arrow:            string(classArrow(ce.Type)),
+			FromMultiplicity: ce.FromMultiplicity,
+			ToMultiplicity:   ce.ToMultiplicity,
+			Highlight:        ce.Highlighted,
+		}
+		if ce.Count > 1 {
+			edge.Label = strconv.Itoa(ce.Count)
+		}
+		if edge.Highlight {
+			edge.Label = strings.TrimSpace(mermaid.HighlightLabel + " " + edge.Label)
+		}
+		mb.mermaid.Edges = append(mb.mermaid.Edges, edge)
+	}
+}
+
+func (mb *MermaidBuilder) buildTemplates() {
+	switch mb.mermaid.Kind {
+	case mermaid.KindClassDiagram:
+		mb.mermaid.Templates = []string{
+			valueobject.TmplTitle, valueobject.TmplClass, valueobject.TmplNamespace,
+			valueobject.TmplClassEdge, valueobject.TmplClassDiagram,
+		}
+	default:
+		mb.mermaid.Templates = []string{
+			valueobject.TmplTitle, valueobject.TmplFlowNode, valueobject.TmplFlowSubGraph,
+			valueobject.TmplFlowEdge, valueobject.TmplFlowchart,
+		}
+	}
+}
+
+func flowArrow(t arch.RelationType) mermaid.FlowArrow {
+	switch t {
+	case arch.RelationTypeDependency:
+		return mermaid.FlowArrowNormal
+	case arch.RelationTypeAggregationRoot, arch.RelationTypeAggregation:
+		return mermaid.FlowArrowAggregation
+	case arch.RelationTypeAssociation:
+		return mermaid.FlowArrowDottedNone
+	}
+	return mermaid.FlowArrowDotted
+}
+
+func classArrow(t arch.RelationType) mermaid.ClassArrow {
+	switch t {
+	case arch.RelationTypeDependency:
+		return mermaid.ClassArrowDependency
+	case arch.RelationTypeComposition, arch.RelationTypeEmbedding:
+		return mermaid.ClassArrowComposition
+	case arch.RelationTypeAggregation, arch.RelationTypeAggregationRoot:
+		return mermaid.ClassArrowAggregation
+	case arch.RelationTypeImplementation:
+		return mermaid.ClassArrowImplementation
+	case arch.RelationTypeInterfaceEmbedding:
+		return mermaid.ClassArrowExtension
+	case arch.RelationTypeAssociationOneOne, arch.RelationTypeAssociationOneMany:
+		return mermaid.ClassArrowAssociation
+	}
+	return mermaid.ClassArrowLink
+}
+
+func labels(names []string) []string {
+	ls := make([]string, 0, len(names))
+	for _, n := range names {
+		ls = append(ls, valueobject.Label(n))
+	}
+	return ls
+}
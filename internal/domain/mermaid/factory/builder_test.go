@@ -0,0 +1,183 @@
+package factory
+
+import (
+	"bytes"
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/archtest"
+	archVO "github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"github.com/dddplayer/dp/internal/domain/mermaid"
+	"github.com/dddplayer/dp/internal/domain/mermaid/valueobject"
+	"strings"
+	"testing"
+)
+
+func TestNewMermaidBuilder(t *testing.T) {
+	d := archtest.PlainDiagram()
+	mb := NewMermaidBuilder(d)
+
+	if mb.archDiagram != d {
+		t.Errorf("Expected archDiagram to be set, but got %+v", mb.archDiagram)
+	}
+	if mb.classMapper == nil {
+		t.Error("Expected classMapper to be set")
+	}
+}
+
+func TestBuildFlowchart(t *testing.T) {
+	m, err := NewMermaidBuilder(archtest.PlainDiagram()).Build()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	if m.Kind != mermaid.KindFlowchart {
+		t.Errorf("Expected kind %s, but got %s", mermaid.KindFlowchart, m.Kind)
+	}
+	if len(m.SubGraphs) != 1 || len(m.SubGraphs[0].SubGraphs) != 1 {
+		t.Fatalf("Expected nested subgraphs, but got %+v", m.SubGraphs)
+	}
+	if m.SubGraphs[0].ID == valueobject.NodeID("example.com/app") {
+		t.Error("Expected subgraph id to differ from node id")
+	}
+	if l := m.SubGraphs[0].SubGraphs[0].Nodes[0].Label; l != "order.Order" {
+		t.Errorf("Expected node label order.Order, but got %s", l)
+	}
+	if len(m.Edges) != 1 || m.Edges[0].Arrow != string(mermaid.FlowArrowAggregation) || m.Edges[0].Label != "1" {
+		t.Errorf("Unexpected edges %+v", m.Edges)
+	}
+	if len(m.Styles) != 2 {
+		t.Errorf("Expected 2 styles, but got %d", len(m.Styles))
+	}
+
+	var buf bytes.Buffer
+	if err := m.Write(&buf); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	out := buf.String()
+	for _, s := range []string{
+		"title: plain",
+		"flowchart LR",
+		"subgraph " + m.SubGraphs[0].ID + "[\"example.com/app\"]",
+		valueobject.NodeID("example.com/app") + " --o|1| " + valueobject.NodeID("order.Order"),
+		"style " + valueobject.NodeID("order.Order") + " fill:" + string(arch.ColorAggregate),
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Expected output to contain %q, got:\n%s", s, out)
+		}
+	}
+}
+
+func TestBuildClassDiagram(t *testing.T) {
+	m, err := NewMermaidBuilder(archtest.TableDiagram()).Build()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	if m.Kind != mermaid.KindClassDiagram {
+		t.Errorf("Expected kind %s, but got %s", mermaid.KindClassDiagram, m.Kind)
+	}
+	if len(m.Namespaces) != 1 || m.Namespaces[0].Name != "order_Order" {
+		t.Fatalf("Unexpected namespaces %+v", m.Namespaces)
+	}
+
+	order := m.Namespaces[0].Classes[0]
+	if order.Annotation != "AggregateRoot" {
+		t.Errorf("Expected AggregateRoot annotation, but got %s", order.Annotation)
+	}
+	if len(order.Methods) != 1 || order.Methods[0] != "Pay" {
+		t.Errorf("Unexpected methods %v", order.Methods)
+	}
+	if len(order.Attributes) != 1 || order.Attributes[0] != "Items" {
+		t.Errorf("Unexpected attributes %v", order.Attributes)
+	}
+
+	if len(m.Edges) != 2 {
+		t.Fatalf("Expected 2 edges, but got %d", len(m.Edges))
+	}
+	assoc, dep := m.Edges[0], m.Edges[1]
+	if assoc.Arrow != string(mermaid.ClassArrowAssociation) ||
+		assoc.FromMultiplicity != archVO.MultiplicityOne || assoc.ToMultiplicity != archVO.MultiplicityMany {
+		t.Errorf("Unexpected association edge %+v", assoc)
+	}
+	if dep.Arrow != string(mermaid.ClassArrowDependency) || dep.Label != "3" {
+		t.Errorf("Unexpected dependency edge %+v", dep)
+	}
+
+	var buf bytes.Buffer
+	if err := m.Write(&buf); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	out := buf.String()
+	for _, s := range []string{
+		"classDiagram",
+		"namespace order_Order {",
+		"class " + order.ID + "[\"Order\"]",
+		"<<AggregateRoot>> " + order.ID,
+		order.ID + " : Items",
+		order.ID + " : Pay()",
+		order.ID + " \"1\" --> \"*\" " + valueobject.NodeID("order.Item"),
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Expected output to contain %q, got:\n%s", s, out)
+		}
+	}
+}
+
+func TestBuildHighlightedEdges(t *testing.T) {
+	d := archtest.PlainDiagram()
+	d.EdgesVal[0].(*archtest.DummyEdge).HighlightedVal = true
+	m, err := NewMermaidBuilder(d).Build()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := m.Write(&buf); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if !strings.Contains(buf.String(), "linkStyle 0 stroke:#cc0000,stroke-width:2px") {
+		t.Errorf("Expected highlighted link style, got:\n%s", buf.String())
+	}
+
+	td := archtest.TableDiagram()
+	td.EdgesVal[0].(*archtest.DummyEdge).HighlightedVal = true
+	m, err = NewMermaidBuilder(td).Build()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if m.Edges[0].Label != mermaid.HighlightLabel || !m.Edges[0].Highlight {
+		t.Errorf("Expected highlighted class edge, but got %+v", m.Edges[0])
+	}
+	if m.Edges[1].Highlight {
+		t.Errorf("Expected plain class edge, but got %+v", m.Edges[1])
+	}
+}
+
+func TestFlowArrow(t *testing.T) {
+	tests := map[arch.RelationType]mermaid.FlowArrow{
+		arch.RelationTypeDependency:      mermaid.FlowArrowNormal,
+		arch.RelationTypeAggregationRoot: mermaid.FlowArrowAggregation,
+		arch.RelationTypeAssociation:     mermaid.FlowArrowDottedNone,
+		arch.RelationTypeComposition:     mermaid.FlowArrowDotted,
+	}
+	for rt, expected := range tests {
+		if res := flowArrow(rt); res != expected {
+			t.Errorf("flowArrow(%v) = %s, expected %s", rt, res, expected)
+		}
+	}
+}
+
+func TestClassArrow(t *testing.T) {
+	tests := map[arch.RelationType]mermaid.ClassArrow{
+		arch.RelationTypeDependency:         mermaid.ClassArrowDependency,
+		arch.RelationTypeEmbedding:          mermaid.ClassArrowComposition,
+		arch.RelationTypeAggregation:        mermaid.ClassArrowAggregation,
+		arch.RelationTypeImplementation:     mermaid.ClassArrowImplementation,
+		arch.RelationTypeInterfaceEmbedding: mermaid.ClassArrowExtension,
+		arch.RelationTypeAssociationOneOne:  mermaid.ClassArrowAssociation,
+		arch.RelationTypeAttribution:        mermaid.ClassArrowLink,
+	}
+	for rt, expected := range tests {
+		if res := classArrow(rt); res != expected {
+			t.Errorf("classArrow(%v) = %s, expected %s", rt, res, expected)
+		}
+	}
+}
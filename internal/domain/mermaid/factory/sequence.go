@@ -0,0 +1,43 @@
+package factory
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/mermaid"
+	"github.com/dddplayer/dp/internal/domain/mermaid/entity"
+	"github.com/dddplayer/dp/internal/domain/mermaid/valueobject"
+)
+
+func NewSequenceBuilder(sequence arch.Sequence) *SequenceBuilder {
+	return &SequenceBuilder{archSequence: sequence}
+}
+
+type SequenceBuilder struct {
+	archSequence arch.Sequence
+}
+
+func (sb *SequenceBuilder) Build() (*entity.Mermaid, error) {
+	m := &entity.Mermaid{
+		Name:         sb.archSequence.Name(),
+		Kind:         mermaid.KindSequence,
+		Participants: []*entity.Node{},
+		Edges:        []*entity.Edge{},
+		Templates:    []string{valueobject.TmplTitle, valueobject.TmplSequenceDiagram},
+	}
+
+	for _, p := range sb.archSequence.Participants() {
+		m.Participants = append(m.Participants, &entity.Node{
+			ID:    valueobject.NodeID(p.ID()),
+			Label: valueobject.Label(p.Name()),
+		})
+	}
+	for _, msg := range sb.archSequence.Messages() {
+		m.Edges = append(m.Edges, &entity.Edge{
+			From:  valueobject.NodeID(msg.From()),
+			To:    valueobject.NodeID(msg.To()),
+			Arrow: mermaid.SequenceArrowCall,
+			Label: valueobject.Label(msg.Name()),
+		})
+	}
+
+	return m, nil
+}
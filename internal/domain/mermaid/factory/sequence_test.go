@@ -0,0 +1,35 @@
+package factory
+
+import (
+	"bytes"
+	"github.com/dddplayer/dp/internal/domain/arch/archtest"
+	"github.com/dddplayer/dp/internal/domain/mermaid/valueobject"
+	"strings"
+	"testing"
+)
+
+func TestBuildSequenceDiagram(t *testing.T) {
+	m, err := NewSequenceBuilder(archtest.Sequence()).Build()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := m.Write(&buf); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	cmd, order := valueobject.NodeID("example.com/app/cmd"), valueobject.NodeID("example.com/app/order/Order")
+	expected := strings.Join([]string{
+		"---",
+		"title: example.com/app",
+		"---",
+		"sequenceDiagram",
+		"    participant " + cmd + " as cmd",
+		"    participant " + order + " as order.Order",
+		"    " + cmd + "->>" + order + ": Place",
+		"    " + order + "->>" + order + ": validate",
+	}, "\n") + "\n"
+	if buf.String() != expected {
+		t.Errorf("Expected output\n%s\nbut got\n%s", expected, buf.String())
+	}
+}
@@ -0,0 +1,34 @@
+package mermaid
+
+type DiagramKind string
+
+const (
+	KindFlowchart    DiagramKind = "flowchart"
+	KindClassDiagram DiagramKind = "classDiagram"
+	KindSequence     DiagramKind = "sequenceDiagram"
+)
+
+type FlowArrow string
+
+const (
+	FlowArrowNormal      FlowArrow = "-->"
+	FlowArrowDotted      FlowArrow = "-.->"
+	FlowArrowDottedNone  FlowArrow = "-.-"
+	FlowArrowAggregation FlowArrow = "--o"
+)
+
+type ClassArrow string
+
+const (
+	ClassArrowAssociation    ClassArrow = "-->"
+	ClassArrowDependency     ClassArrow = "..>"
+	ClassArrowComposition    ClassArrow = "*--"
+	ClassArrowAggregation    ClassArrow = "o--"
+	ClassArrowImplementation ClassArrow = "..|>"
+	ClassArrowExtension      ClassArrow = "--|>"
+	ClassArrowLink           ClassArrow = "--"
+)
+
+const SequenceArrowCall = "->>"
+
+const HighlightLabel = "cycle"
@@ -0,0 +1,40 @@
+package valueobject
+
+import (
+	"hash/fnv"
+	"strings"
+)
+
+func NodeID(name string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	hash := h.Sum32()
+
+	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	id := ""
+	for hash > 0 {
+		index := int(hash % 62)
+		id = charset[index:index+1] + id
+		hash /= 62
+	}
+
+	return "m" + id
+}
+
+func Label(text string) string {
+	r := strings.NewReplacer(`"`, "#quot;", "\n", " ", "<", "#lt;", ">", "#gt;")
+	return r.Replace(text)
+}
+
+func Namespace(name string) string {
+	var sb strings.Builder
+	for _, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_':
+			sb.WriteRune(c)
+		default:
+			sb.WriteRune('_')
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,32 @@
+package valueobject
+
+import "testing"
+
+func TestNodeID(t *testing.T) {
+	id := NodeID("example.com/app/order.Order")
+	if id == "" || id[0] != 'm' {
+		t.Errorf("Expected id with prefix m, but got %q", id)
+	}
+	if id != NodeID("example.com/app/order.Order") {
+		t.Error("Expected NodeID to be stable")
+	}
+	if id == NodeID("example.com/app/order.Item") {
+		t.Error("Expected different names to have different ids")
+	}
+}
+
+func TestLabel(t *testing.T) {
+	s := "map[string]<\"a\">\nb"
+	expected := "map[string]#lt;#quot;a#quot;#gt; b"
+	if res := Label(s); res != expected {
+		t.Errorf("Label(%q) = %q, expected %q", s, res, expected)
+	}
+}
+
+func TestNamespace(t *testing.T) {
+	s := "github.com/dddplayer/dp-main"
+	expected := "github_com_dddplayer_dp_main"
+	if res := Namespace(s); res != expected {
+		t.Errorf("Namespace(%q) = %q, expected %q", s, res, expected)
+	}
+}
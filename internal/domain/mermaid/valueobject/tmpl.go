@@ -0,0 +1,96 @@
+package valueobject
+
+const TmplTitle = `{{define "title" -}}
+---
+title: {{.}}
+---
+{{- end}}`
+
+const TmplFlowNode = `{{define "flow_node" -}}
+    {{printf "%s[\"%s\"]" .ID .Label}}
+{{- end}}`
+
+const TmplFlowSubGraph = `{{define "flow_subgraph" -}}
+    {{printf "subgraph %s[\"%s\"]" .ID .Label}}
+	{{- range .Nodes}}
+    {{template "flow_node" .}}
+	{{- end}}
+	{{- range .SubGraphs}}
+    {{template "flow_subgraph" .}}
+	{{- end}}
+    end
+{{- end}}`
+
+const TmplFlowEdge = `{{define "flow_edge" -}}
+    {{if .Label}}{{printf "%s %s|%s| %s" .From .Arrow .Label .To}}{{else}}{{printf "%s %s %s" .From .Arrow .To}}{{end}}
+{{- end}}`
+
+const TmplFlowchart = `{{template "title" .Name}}
+flowchart LR
+{{- range .SubGraphs}}
+    {{template "flow_subgraph" .}}
+{{- end}}
+{{- range .Edges}}
+    {{template "flow_edge" .}}
+{{- end}}
+{{- range $i, $e := .Edges}}
+	{{- if $e.Highlight}}
+    {{printf "linkStyle %d stroke:#cc0000,stroke-width:2px" $i}}
+	{{- end}}
+{{- end}}
+{{- range .Styles}}
+    {{printf "style %s fill:%s" .ID .Color}}
+{{- end}}
+`
+
+const TmplClass = `{{define "class" -}}
+	{{- if .Annotation}}
+    {{printf "<<%s>> %s" .Annotation .ID}}
+	{{- end}}
+	{{- range .Attributes}}
+    {{printf "%s : %s" $.ID .}}
+	{{- end}}
+	{{- range .Methods}}
+    {{printf "%s : %s()" $.ID .}}
+	{{- end}}
+{{- end}}`
+
+const TmplNamespace = `{{define "namespace" -}}
+    {{printf "namespace %s {" .Name}}
+	{{- range .Classes}}
+    {{printf "class %s[\"%s\"]" .ID .Label}}
+	{{- end}}
+    }
+{{- end}}`
+
+const TmplClassEdge = `{{define "class_edge" -}}
+    {{.From}}{{if .FromMultiplicity}} "{{.FromMultiplicity}}"{{end}} {{.Arrow}}{{if .ToMultiplicity}} "{{.ToMultiplicity}}"{{end}} {{.To}}{{if .Label}} : {{.Label}}{{end}}
+{{- end}}`
+
+const TmplClassDiagram = `{{template "title" .Name}}
+classDiagram
+{{- range .Namespaces}}
+    {{template "namespace" .}}
+{{- end}}
+{{- range .Namespaces}}
+	{{- range .Classes}}
+{{template "class" .}}
+	{{- end}}
+{{- end}}
+{{- range .Edges}}
+    {{template "class_edge" .}}
+{{- end}}
+{{- range .Styles}}
+    {{printf "style %s fill:%s" .ID .Color}}
+{{- end}}
+`
+
+const TmplSequenceDiagram = `{{template "title" .Name}}
+sequenceDiagram
+{{- range .Participants}}
+    {{printf "participant %s as %s" .ID .Label}}
+{{- end}}
+{{- range .Edges}}
+    {{printf "%s%s%s: %s" .From .Arrow .To .Label}}
+{{- end}}
+`
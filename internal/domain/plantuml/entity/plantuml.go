@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"bytes"
+	"github.com/dddplayer/dp/internal/domain/plantuml"
+	"io"
+	"strings"
+	"text/template"
+)
+
+type PlantUML struct {
+	Name         string
+	Kind         plantuml.DiagramKind
+	Packages     []*Package
+	Participants []*Component
+	Edges        []*Edge
+	Templates    []string
+}
+
+type Package struct {
+	Name       string
+	Components []*Component
+	Classes    []*Class
+	Packages   []*Package
+}
+
+type Component struct {
+	ID    string
+	Label string
+	Color string
+}
+
+type Class struct {
+	ID         string
+	Label      string
+	Stereotype string
+	Color      string
+	Attributes []string
+	Methods    []string
+}
+
+type Edge struct {
+	From             string
+	To               string
+	Arrow            string
+	Label            string
+	FromMultiplicity string
+	ToMultiplicity   string
+}
+
+func (p *PlantUML) Write(w io.Writer) error {
+	t := template.New("plantuml")
+	for _, s := range p.Templates {
+		if _, err := t.Parse(s); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, p); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, trimBlankLines(buf.String()))
+	return err
+}
+
+func trimBlankLines(s string) string {
+	var lines []string
+	for _, l := range strings.Split(s, "\n") {
+		if strings.TrimSpace(l) != "" {
+			lines = append(lines, l)
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
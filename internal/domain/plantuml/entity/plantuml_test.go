@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"bytes"
+	"github.com/dddplayer/dp/internal/domain/plantuml"
+	"github.com/dddplayer/dp/internal/domain/plantuml/valueobject"
+	"testing"
+)
+
+var templates = []string{
+	valueobject.TmplComponent, valueobject.TmplClass, valueobject.TmplPackage,
+	valueobject.TmplEdge, valueobject.TmplDiagram,
+}
+
+func TestTrimBlankLines(t *testing.T) {
+	s := "a\n\n  \nb\n"
+	expected := "a\nb\n"
+	if res := trimBlankLines(s); res != expected {
+		t.Errorf("trimBlankLines(%q) = %q, expected %q", s, res, expected)
+	}
+}
+
+func TestWriteClass(t *testing.T) {
+	p := &PlantUML{
+		Name: "demo",
+		Kind: plantuml.KindClass,
+		Packages: []*Package{
+			{Name: "order", Classes: []*Class{
+				{ID: "c1", Label: "Order", Stereotype: "AggregateRoot", Color: "#fff",
+					Attributes: []string{"ID"}, Methods: []string{"Pay"}},
+				{ID: "c2", Label: "Item"},
+			}},
+		},
+		Edges:     []*Edge{{From: "c1", To: "c2", Arrow: "-->", FromMultiplicity: "1", ToMultiplicity: "*"}},
+		Templates: templates,
+	}
+
+	var buf bytes.Buffer
+	if err := p.Write(&buf); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	expected := `@startuml
+title demo
+set namespaceSeparator none
+hide empty members
+    package "order" {
+    class "Order" as c1 <<AggregateRoot>> #fff {
+    +ID
+    +Pay()
+    }
+    class "Item" as c2 {
+    }
+    }
+    c1 "1" --> "*" c2
+@enduml
+`
+	if buf.String() != expected {
+		t.Errorf("Unexpected output:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
+
+func TestWriteComponent(t *testing.T) {
+	p := &PlantUML{
+		Name: "demo",
+		Kind: plantuml.KindComponent,
+		Packages: []*Package{
+			{Name: "app", Components: []*Component{{ID: "n1", Label: "A"}, {ID: "n2", Label: "B", Color: "#000"}}},
+		},
+		Edges:     []*Edge{{From: "n1", To: "n2", Arrow: "-->", Label: "2"}},
+		Templates: templates,
+	}
+
+	var buf bytes.Buffer
+	if err := p.Write(&buf); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	expected := `@startuml
+title demo
+    package "app" {
+    [A] as n1
+    [B] as n2 #000
+    }
+    n1 --> n2 : 2
+@enduml
+`
+	if buf.String() != expected {
+		t.Errorf("Unexpected output:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
+
+func TestWriteInvalidTemplate(t *testing.T) {
+	p := &PlantUML{Templates: []string{"{{"}}
+	if err := p.Write(&bytes.Buffer{}); err == nil {
+		t.Error("Expected an error for invalid template")
+	}
+}
@@ -0,0 +1,180 @@
+package factory
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	archVO "github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"github.com/dddplayer/dp/internal/domain/plantuml"
+	"github.com/dddplayer/dp/internal/domain/plantuml/entity"
+	"github.com/dddplayer/dp/internal/domain/plantuml/valueobject"
+	"path"
+	"strconv"
+)
+
+func NewPlantUMLBuilder(diagram arch.Diagram) *PlantUMLBuilder {
+	return &PlantUMLBuilder{
+		archDiagram: diagram,
+		classMapper: archVO.NewClassMapper(),
+	}
+}
+
+type PlantUMLBuilder struct {
+	archDiagram arch.Diagram
+	uml         *entity.PlantUML
+	classMapper *archVO.ClassMapper
+}
+
+func (pb *PlantUMLBuilder) Build() (*entity.PlantUML, error) {
+	pb.uml = &entity.PlantUML{
+		Name:     pb.archDiagram.Name(),
+		Packages: []*entity.Package{},
+		Edges:    []*entity.Edge{},
+		Templates: []string{
+			valueobject.TmplComponent, valueobject.TmplClass, valueobject.TmplPackage,
+			valueobject.TmplEdge, valueobject.TmplDiagram,
+		},
+	}
+
+	if pb.isClassMode() {
+		pb.uml.Kind = plantuml.KindClass
+		for _, sd := range pb.archDiagram.SubDiagrams() {
+			pb.uml.Packages = append(pb.uml.Packages, pb.buildClassPackage(sd))
+		}
+		pb.buildClassEdges()
+	} else {
+		pb.uml.Kind = plantuml.KindComponent
+		for _, sd := range pb.archDiagram.SubDiagrams() {
+			pb.uml.Packages = append(pb.uml.Packages, pb.buildComponentPackage(sd))
+		}
+		pb.buildComponentEdges()
+	}
+
+	return pb.uml, nil
+}
+
+func (pb *PlantUMLBuilder) isClassMode() bool {
+	return pb.archDiagram.Type() == arch.TableDiagram
+}
+
+func (pb *PlantUMLBuilder) buildComponentPackage(sd arch.SubDiagram) *entity.Package {
+	p := &entity.Package{
+		Name:       valueobject.Label(sd.Name()),
+		Components: []*entity.Component{},
+		Packages:   []*entity.Package{},
+	}
+
+	for _, n := range sd.Nodes() {
+		p.Components = append(p.Components, &entity.Component{
+			ID:    valueobject.Alias(n.ID()),
+			Label: valueobject.Label(path.Base(n.Name())),
+			Color: n.Color(),
+		})
+	}
+
+	for _, ssd := range sd.SubGraphs() {
+		p.Packages = append(p.Packages, pb.buildComponentPackage(ssd))
+	}
+
+	return p
+}
+
+func (pb *PlantUMLBuilder) buildComponentEdges() {
+	for _, e := range pb.archDiagram.Edges() {
+		arrow := string(componentArrow(e.Type()))
+		if e.Highlighted() {
+			arrow = valueobject.ColoredArrow(arrow, plantuml.HighlightColor)
+		}
+		pb.uml.Edges = append(pb.uml.Edges, &entity.Edge{
+			From:  valueobject.Alias(e.From()),
+			To:    valueobject.Alias(e.To()),
+			Arrow: arrow,
+			Label: strconv.Itoa(e.Count()),
+		})
+	}
+}
+
+func (pb *PlantUMLBuilder) buildClassPackage(sd arch.SubDiagram) *entity.Package {
+	p := &entity.Package{
+		Name:     valueobject.Label(sd.Name()),
+		Classes:  []*entity.Class{},
+		Packages: []*entity.Package{},
+	}
+
+	for _, e := range sd.Summary() {
+		p.Classes = append(p.Classes, pb.buildClass(e))
+	}
+
+	for _, ssd := range sd.SubGraphs() {
+		p.Packages = append(p.Packages, pb.buildClassPackage(ssd))
+	}
+
+	return p
+}
+
+func (pb *PlantUMLBuilder) buildClass(e arch.Element) *entity.Class {
+	dc := pb.classMapper.Class(e)
+	return &entity.Class{
+		ID:         valueobject.Alias(dc.ID),
+		Label:      valueobject.Label(dc.Name),
+		Stereotype: dc.Stereotype,
+		Color:      dc.Color,
+		Attributes: labels(dc.Attributes),
+		Methods:    labels(dc.Methods),
+	}
+}
+
+func (pb *PlantUMLBuilder) buildClassEdges() {
+	for _, ce := range pb.classMapper.Edges(pb.archDiagram.Edges()) {
+		edge := &entity.Edge{
+			From:             valueobject.Alias(ce.From),
+			To:               valueobject.Alias(ce.To),
+			Arrow:            string(classArrow(ce.Type)),
+			FromMultiplicity: ce.FromMultiplicity,
+			ToMultiplicity:   ce.ToMultiplicity,
+		}
+		if ce.Count > 1 {
+			edge.Label = strconv.Itoa(ce.Count)
+		}
+		if ce.Highlighted {
+			edge.Arrow = valueobject.ColoredArrow(edge.Arrow, plantuml.HighlightColor)
+		}
+		pb.uml.Edges = append(pb.uml.Edges, edge)
+	}
+}
+
+func componentArrow(t arch.RelationType) plantuml.ComponentArrow {
+	switch t {
+	case arch.RelationTypeDependency:
+		return plantuml.ComponentArrowDependency
+	case arch.RelationTypeAggregationRoot, arch.RelationTypeAggregation:
+		return plantuml.ComponentArrowAggregation
+	case arch.RelationTypeAssociation:
+		return plantuml.ComponentArrowAssociation
+	}
+	return plantuml.ComponentArrowDotted
+}
+
+func classArrow(t arch.RelationType) plantuml.ClassArrow {
+	switch t {
+	case arch.RelationTypeDependency:
+		return plantuml.ClassArrowDependency
+	case arch.RelationTypeComposition, arch.RelationTypeEmbedding:
+		return plantuml.ClassArrowComposition
+	case arch.RelationTypeAggregation, arch.RelationTypeAggregationRoot:
+		return plantuml.ClassArrowAggregation
+	case arch.RelationTypeImplementation:
+		return plantuml.ClassArrowImplementation
+	case arch.RelationTypeInterfaceEmbedding:
+		return plantuml.ClassArrowExtension
+	case arch.RelationTypeAssociationOneOne, arch.RelationTypeAssociationOneMany:
+		return plantuml.ClassArrowAssociation
+	}
+	return plantuml.ClassArrowLink
+}
+
+func labels(names []string) []string {
+	ls := make([]string, 0, len(names))
+	for _, n := range names {
+		ls = append(ls, valueobject.Label(n))
+	}
+	return ls
+}
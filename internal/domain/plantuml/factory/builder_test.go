@@ -0,0 +1,156 @@
+package factory
+
+import (
+	"bytes"
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/archtest"
+	archVO "github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	"github.com/dddplayer/dp/internal/domain/plantuml"
+	"github.com/dddplayer/dp/internal/domain/plantuml/valueobject"
+	"strings"
+	"testing"
+)
+
+func TestNewPlantUMLBuilder(t *testing.T) {
+	d := archtest.PlainDiagram()
+	pb := NewPlantUMLBuilder(d)
+
+	if pb.archDiagram != d {
+		t.Errorf("Expected archDiagram to be set, but got %+v", pb.archDiagram)
+	}
+	if pb.classMapper == nil {
+		t.Error("Expected classMapper to be set")
+	}
+}
+
+func TestBuildComponentDiagram(t *testing.T) {
+	uml, err := NewPlantUMLBuilder(archtest.PlainDiagram()).Build()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	if uml.Kind != plantuml.KindComponent {
+		t.Errorf("Expected kind %s, but got %s", plantuml.KindComponent, uml.Kind)
+	}
+	if len(uml.Packages) != 1 || len(uml.Packages[0].Packages) != 1 {
+		t.Fatalf("Expected nested packages, but got %+v", uml.Packages)
+	}
+	if len(uml.Edges) != 1 || uml.Edges[0].Arrow != string(plantuml.ComponentArrowAggregation) {
+		t.Errorf("Unexpected edges %+v", uml.Edges)
+	}
+
+	var buf bytes.Buffer
+	if err := uml.Write(&buf); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	out := buf.String()
+	for _, s := range []string{
+		"@startuml",
+		"title plain",
+		"package \"example.com/app\" {",
+		"[order.Order] as " + valueobject.Alias("order.Order") + " " + string(arch.ColorAggregate),
+		valueobject.Alias("example.com/app") + " o-- " + valueobject.Alias("order.Order") + " : 1",
+		"@enduml",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Expected output to contain %q, got:\n%s", s, out)
+		}
+	}
+	if strings.Contains(out, "namespaceSeparator") {
+		t.Error("Expected component diagram without class settings")
+	}
+}
+
+func TestBuildClassDiagram(t *testing.T) {
+	uml, err := NewPlantUMLBuilder(archtest.TableDiagram()).Build()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	if uml.Kind != plantuml.KindClass {
+		t.Errorf("Expected kind %s, but got %s", plantuml.KindClass, uml.Kind)
+	}
+	if len(uml.Packages) != 1 || uml.Packages[0].Name != "order.Order" {
+		t.Fatalf("Unexpected packages %+v", uml.Packages)
+	}
+
+	order := uml.Packages[0].Classes[0]
+	if order.Stereotype != archVO.StereotypeAggregateRoot {
+		t.Errorf("Expected AggregateRoot stereotype, but got %s", order.Stereotype)
+	}
+	if len(order.Methods) != 1 || order.Methods[0] != "Pay" {
+		t.Errorf("Unexpected methods %v", order.Methods)
+	}
+	if len(order.Attributes) != 1 || order.Attributes[0] != "Items" {
+		t.Errorf("Unexpected attributes %v", order.Attributes)
+	}
+
+	if len(uml.Edges) != 2 {
+		t.Fatalf("Expected 2 edges, but got %d", len(uml.Edges))
+	}
+	if dep := uml.Edges[1]; dep.Arrow != string(plantuml.ClassArrowDependency) || dep.Label != "3" {
+		t.Errorf("Unexpected dependency edge %+v", dep)
+	}
+
+	var buf bytes.Buffer
+	if err := uml.Write(&buf); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	out := buf.String()
+	for _, s := range []string{
+		"set namespaceSeparator none",
+		"package \"order.Order\" {",
+		"class \"Order\" as " + order.ID + " <<AggregateRoot>> " + string(arch.ColorAggregate) + " {",
+		"+Items",
+		"+Pay()",
+		"<<Entity>>",
+		order.ID + " \"1\" --> \"*\" " + valueobject.Alias("order.Item"),
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Expected output to contain %q, got:\n%s", s, out)
+		}
+	}
+}
+
+func TestBuildHighlightedEdges(t *testing.T) {
+	d := archtest.PlainDiagram()
+	d.EdgesVal[0].(*archtest.DummyEdge).HighlightedVal = true
+	uml, err := NewPlantUMLBuilder(d).Build()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if uml.Edges[0].Arrow != "o-[#cc0000]-" {
+		t.Errorf("Expected highlighted arrow, but got %s", uml.Edges[0].Arrow)
+	}
+
+	td := archtest.TableDiagram()
+	td.EdgesVal[0].(*archtest.DummyEdge).HighlightedVal = true
+	uml, err = NewPlantUMLBuilder(td).Build()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if uml.Edges[0].Arrow != "-[#cc0000]->" {
+		t.Errorf("Expected highlighted class arrow, but got %s", uml.Edges[0].Arrow)
+	}
+	if uml.Edges[1].Arrow != string(plantuml.ClassArrowDependency) {
+		t.Errorf("Expected plain class arrow, but got %s", uml.Edges[1].Arrow)
+	}
+}
+
+func TestClassArrow(t *testing.T) {
+	tests := map[arch.RelationType]plantuml.ClassArrow{
+		arch.RelationTypeDependency:         plantuml.ClassArrowDependency,
+		arch.RelationTypeComposition:        plantuml.ClassArrowComposition,
+		arch.RelationTypeEmbedding:          plantuml.ClassArrowComposition,
+		arch.RelationTypeAggregation:        plantuml.ClassArrowAggregation,
+		arch.RelationTypeImplementation:     plantuml.ClassArrowImplementation,
+		arch.RelationTypeInterfaceEmbedding: plantuml.ClassArrowExtension,
+		arch.RelationTypeAssociationOneOne:  plantuml.ClassArrowAssociation,
+		arch.RelationTypeAttribution:        plantuml.ClassArrowLink,
+	}
+	for rt, expected := range tests {
+		if res := classArrow(rt); res != expected {
+			t.Errorf("classArrow(%v) = %s, expected %s", rt, res, expected)
+		}
+	}
+}
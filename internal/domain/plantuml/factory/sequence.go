@@ -0,0 +1,44 @@
+package factory
+
+import (
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/plantuml"
+	"github.com/dddplayer/dp/internal/domain/plantuml/entity"
+	"github.com/dddplayer/dp/internal/domain/plantuml/valueobject"
+)
+
+func NewSequenceBuilder(sequence arch.Sequence) *SequenceBuilder {
+	return &SequenceBuilder{archSequence: sequence}
+}
+
+type SequenceBuilder struct {
+	archSequence arch.Sequence
+}
+
+func (sb *SequenceBuilder) Build() (*entity.PlantUML, error) {
+	uml := &entity.PlantUML{
+		Name:         sb.archSequence.Name(),
+		Kind:         plantuml.KindSequence,
+		Participants: []*entity.Component{},
+		Edges:        []*entity.Edge{},
+		Templates:    []string{valueobject.TmplSequenceDiagram},
+	}
+
+	for _, p := range sb.archSequence.Participants() {
+		uml.Participants = append(uml.Participants, &entity.Component{
+			ID:    valueobject.Alias(p.ID()),
+			Label: valueobject.Label(p.Name()),
+			Color: p.Color(),
+		})
+	}
+	for _, msg := range sb.archSequence.Messages() {
+		uml.Edges = append(uml.Edges, &entity.Edge{
+			From:  valueobject.Alias(msg.From()),
+			To:    valueobject.Alias(msg.To()),
+			Arrow: plantuml.SequenceArrowCall,
+			Label: valueobject.Label(msg.Name()),
+		})
+	}
+
+	return uml, nil
+}
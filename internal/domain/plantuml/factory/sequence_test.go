@@ -0,0 +1,35 @@
+package factory
+
+import (
+	"bytes"
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/archtest"
+	"github.com/dddplayer/dp/internal/domain/plantuml/valueobject"
+	"strings"
+	"testing"
+)
+
+func TestBuildSequenceDiagram(t *testing.T) {
+	uml, err := NewSequenceBuilder(archtest.Sequence()).Build()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := uml.Write(&buf); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	cmd, order := valueobject.Alias("example.com/app/cmd"), valueobject.Alias("example.com/app/order/Order")
+	expected := strings.Join([]string{
+		"@startuml",
+		"title example.com/app",
+		"    participant \"cmd\" as " + cmd + " " + string(arch.ColorFunc),
+		"    participant \"order.Order\" as " + order + " " + string(arch.ColorClass),
+		"    " + cmd + " -> " + order + " : Place",
+		"    " + order + " -> " + order + " : validate",
+		"@enduml",
+	}, "\n") + "\n"
+	if buf.String() != expected {
+		t.Errorf("Expected output\n%s\nbut got\n%s", expected, buf.String())
+	}
+}
@@ -0,0 +1,34 @@
+package plantuml
+
+type DiagramKind string
+
+const (
+	KindComponent DiagramKind = "component"
+	KindClass     DiagramKind = "class"
+	KindSequence  DiagramKind = "sequence"
+)
+
+type ComponentArrow string
+
+const (
+	ComponentArrowDependency  ComponentArrow = "-->"
+	ComponentArrowAggregation ComponentArrow = "o--"
+	ComponentArrowAssociation ComponentArrow = ".."
+	ComponentArrowDotted      ComponentArrow = "..>"
+)
+
+type ClassArrow string
+
+const (
+	ClassArrowAssociation    ClassArrow = "-->"
+	ClassArrowDependency     ClassArrow = "..>"
+	ClassArrowComposition    ClassArrow = "*--"
+	ClassArrowAggregation    ClassArrow = "o--"
+	ClassArrowImplementation ClassArrow = "..|>"
+	ClassArrowExtension      ClassArrow = "--|>"
+	ClassArrowLink           ClassArrow = "--"
+)
+
+const SequenceArrowCall = "->"
+
+const HighlightColor = "#cc0000"
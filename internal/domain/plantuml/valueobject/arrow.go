@@ -0,0 +1,11 @@
+package valueobject
+
+import "strings"
+
+func ColoredArrow(arrow, color string) string {
+	i := strings.IndexAny(arrow, "-.")
+	if i < 0 {
+		return arrow
+	}
+	return arrow[:i+1] + "[" + color + "]" + arrow[i+1:]
+}
@@ -0,0 +1,23 @@
+package valueobject
+
+import "testing"
+
+func TestColoredArrow(t *testing.T) {
+	tests := []struct {
+		arrow    string
+		expected string
+	}{
+		{"-->", "-[#cc0000]->"},
+		{"..>", ".[#cc0000].>"},
+		{"*--", "*-[#cc0000]-"},
+		{"..|>", ".[#cc0000].|>"},
+		{"..", ".[#cc0000]."},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		if res := ColoredArrow(test.arrow, "#cc0000"); res != test.expected {
+			t.Errorf("ColoredArrow(%q) = %s, expected %s", test.arrow, res, test.expected)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package valueobject
+
+import (
+	"hash/fnv"
+	"strings"
+)
+
+func Alias(name string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	hash := h.Sum32()
+
+	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	id := ""
+	for hash > 0 {
+		index := int(hash % 62)
+		id = charset[index:index+1] + id
+		hash /= 62
+	}
+
+	return "p" + id
+}
+
+func Label(text string) string {
+	r := strings.NewReplacer(`"`, "'", "\n", " ")
+	return r.Replace(text)
+}
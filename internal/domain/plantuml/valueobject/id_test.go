@@ -0,0 +1,24 @@
+package valueobject
+
+import "testing"
+
+func TestAlias(t *testing.T) {
+	id := Alias("example.com/app/order.Order")
+	if id == "" || id[0] != 'p' {
+		t.Errorf("Expected alias with prefix p, but got %q", id)
+	}
+	if id != Alias("example.com/app/order.Order") {
+		t.Error("Expected Alias to be stable")
+	}
+	if id == Alias("example.com/app/order.Item") {
+		t.Error("Expected different names to have different aliases")
+	}
+}
+
+func TestLabel(t *testing.T) {
+	s := "say \"hi\"\nnow"
+	expected := "say 'hi' now"
+	if res := Label(s); res != expected {
+		t.Errorf("Label(%q) = %q, expected %q", s, res, expected)
+	}
+}
@@ -0,0 +1,60 @@
+package valueobject
+
+const TmplComponent = `{{define "component" -}}
+    {{printf "[%s] as %s" .Label .ID}}{{if .Color}} {{.Color}}{{end}}
+{{- end}}`
+
+const TmplClass = `{{define "class" -}}
+    {{printf "class \"%s\" as %s" .Label .ID}}{{if .Stereotype}} <<{{.Stereotype}}>>{{end}}{{if .Color}} {{.Color}}{{end}} {
+	{{- range .Attributes}}
+    {{printf "+%s" .}}
+	{{- end}}
+	{{- range .Methods}}
+    {{printf "+%s()" .}}
+	{{- end}}
+    }
+{{- end}}`
+
+const TmplPackage = `{{define "package" -}}
+    {{printf "package \"%s\" {" .Name}}
+	{{- range .Components}}
+    {{template "component" .}}
+	{{- end}}
+	{{- range .Classes}}
+    {{template "class" .}}
+	{{- end}}
+	{{- range .Packages}}
+    {{template "package" .}}
+	{{- end}}
+    }
+{{- end}}`
+
+const TmplEdge = `{{define "edge" -}}
+    {{.From}}{{if .FromMultiplicity}} "{{.FromMultiplicity}}"{{end}} {{.Arrow}}{{if .ToMultiplicity}} "{{.ToMultiplicity}}"{{end}} {{.To}}{{if .Label}} : {{.Label}}{{end}}
+{{- end}}`
+
+const TmplDiagram = `@startuml
+{{printf "title %s" .Name}}
+{{- if eq .Kind "class"}}
+set namespaceSeparator none
+hide empty members
+{{- end}}
+{{- range .Packages}}
+    {{template "package" .}}
+{{- end}}
+{{- range .Edges}}
+    {{template "edge" .}}
+{{- end}}
+@enduml
+`
+
+const TmplSequenceDiagram = `@startuml
+{{printf "title %s" .Name}}
+{{- range .Participants}}
+    {{printf "participant \"%s\" as %s" .Label .ID}}{{if .Color}} {{.Color}}{{end}}
+{{- end}}
+{{- range .Edges}}
+    {{printf "%s %s %s : %s" .From .Arrow .To .Label}}
+{{- end}}
+@enduml
+`
@@ -0,0 +1,70 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Worktrees checks out revisions of a local repository into temporary worktrees,
+// so they can be analysed without touching the working copy or the network.
+type Worktrees struct{}
+
+func NewWorktrees() *Worktrees {
+	return &Worktrees{}
+}
+
+// Checkout adds a detached worktree of rev for the repository containing dir, and returns
+// the directory matching dir inside it, with a func removing the worktree again.
+func (w *Worktrees) Checkout(dir, rev string) (string, func(), error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	top, err := run(abs, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", nil, err
+	}
+	if top, err = filepath.EvalSymlinks(top); err != nil {
+		return "", nil, err
+	}
+	if abs, err = filepath.EvalSymlinks(abs); err != nil {
+		return "", nil, err
+	}
+	rel, err := filepath.Rel(top, abs)
+	if err != nil {
+		return "", nil, err
+	}
+
+	tmp, err := os.MkdirTemp("", "dp-worktree-")
+	if err != nil {
+		return "", nil, err
+	}
+	tree := filepath.Join(tmp, "tree")
+	if _, err := run(top, "worktree", "add", "--detach", tree, rev); err != nil {
+		_ = os.RemoveAll(tmp)
+		return "", nil, err
+	}
+
+	cleanup := func() {
+		_, _ = run(top, "worktree", "remove", "--force", tree)
+		_ = os.RemoveAll(tmp)
+		_, _ = run(top, "worktree", "prune")
+	}
+	return filepath.Join(tree, rel), cleanup, nil
+}
+
+func run(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,51 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWorktrees_Checkout(t *testing.T) {
+	repo := t.TempDir()
+	sub := filepath.Join(repo, "svc")
+	if err := os.MkdirAll(sub, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	commit := func(content string) {
+		if err := os.WriteFile(filepath.Join(sub, "main.go"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		for _, args := range [][]string{
+			{"add", "-A"},
+			{"-c", "user.name=dp", "-c", "user.email=dp@example.com", "commit", "-q", "-m", content},
+		} {
+			if _, err := run(repo, args...); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if _, err := run(repo, "init", "-q"); err != nil {
+		t.Skipf("git not usable: %v", err)
+	}
+	commit("package first\n")
+	commit("package second\n")
+
+	dir, cleanup, err := NewWorktrees().Checkout(sub, "HEAD~1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "main.go"))
+	if err != nil || string(content) != "package first\n" {
+		t.Errorf("Expected the first revision of main.go, but got %q (%v)", content, err)
+	}
+
+	cleanup()
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("Expected the worktree to be removed, but got %v", err)
+	}
+
+	if _, _, err := NewWorktrees().Checkout(sub, "no-such-rev"); err == nil {
+		t.Errorf("Expected an unknown revision to fail")
+	}
+}
@@ -0,0 +1,72 @@
+package persistence
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/code/entity"
+	"os"
+	"path/filepath"
+)
+
+const cacheFolderName = ".dddplayer-cache"
+
+// DiskCache stores analysis records in a folder next to the dddplayer output folder
+// of the project, one file per analysis scope.
+type DiskCache struct{}
+
+func NewDiskCache() *DiskCache {
+	return &DiskCache{}
+}
+
+func (dc *DiskCache) Load(mainPkgPath, scope string) (*entity.Record, error) {
+	filename, err := dc.filename(mainPkgPath, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r := &entity.Record{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (dc *DiskCache) Save(mainPkgPath, scope string, r *entity.Record) error {
+	filename, err := dc.filename(mainPkgPath, scope)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), os.ModePerm); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	tmp := filename + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filename)
+}
+
+func (dc *DiskCache) filename(mainPkgPath, scope string) (string, error) {
+	dir, err := entity.ModuleRoot(mainPkgPath)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, cacheFolderName, fmt.Sprintf("%x.json", sha1.Sum([]byte(scope)))), nil
+}
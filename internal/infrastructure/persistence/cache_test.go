@@ -0,0 +1,47 @@
+package persistence
+
+import (
+	"github.com/dddplayer/dp/internal/domain/code"
+	"github.com/dddplayer/dp/internal/domain/code/entity"
+	"github.com/dddplayer/dp/internal/domain/code/valueobject"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiskCache(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/shop\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mainPkg := filepath.Join(root, "cmd")
+
+	dc := NewDiskCache()
+	r, err := dc.Load(mainPkg, "scope")
+	if err != nil || r != nil {
+		t.Fatalf("Expected no record before saving, but got %v, %v", r, err)
+	}
+
+	saved := entity.NewRecord("key", "example.com/shop/cmd")
+	saved.NodeHandler(&code.Node{Meta: valueobject.NewMeta("example.com/shop/order", "Order"), Type: code.TypeGenStruct})
+	if err := dc.Save(mainPkg, "scope", saved); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, cacheFolderName)); err != nil {
+		t.Errorf("Expected cache folder in the project root, but got %v", err)
+	}
+
+	r, err = dc.Load(mainPkg, "scope")
+	if err != nil || r == nil {
+		t.Fatalf("Expected saved record, but got %v, %v", r, err)
+	}
+	if r.Key != "key" || r.MainPkgPath != "example.com/shop/cmd" || len(r.Events) != 1 ||
+		r.Events[0].Node.Name != "Order" || r.Events[0].Node.Type != code.TypeGenStruct {
+		t.Errorf("Unexpected record %+v", r)
+	}
+
+	if r, _ := dc.Load(mainPkg, "other"); r != nil {
+		t.Errorf("Expected no record for another scope, but got %+v", r)
+	}
+}
@@ -15,6 +15,22 @@ func (kv *Relations) Insert(rel arch.Relation) error {
 	return nil
 }
 
+func (kv *Relations) Delete(match func(rel arch.Relation) bool) int {
+	kept := kv.relations[:0]
+	for _, rel := range kv.relations {
+		if !match(rel) {
+			kept = append(kept, rel)
+		}
+	}
+	deleted := len(kv.relations) - len(kept)
+	for i := len(kept); i < len(kv.relations); i++ {
+		kv.relations[i] = nil
+	}
+	kv.relations = kept
+
+	return deleted
+}
+
 func (kv *Relations) Walk(walker func(rel arch.Relation) error) {
 	for _, rel := range kv.relations {
 		if err := walker(rel); err != nil {
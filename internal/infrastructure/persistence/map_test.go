@@ -152,3 +152,25 @@ func TestRelations_Walk_ErrorHandling(t *testing.T) {
 		}
 	})
 }
+
+func TestRelations_Delete(t *testing.T) {
+	kv := &Relations{}
+	mockObject1 := &MockObject{id: &MockIdentifier{IDVal: "id1"}}
+	mockObject2 := &MockObject{id: &MockIdentifier{IDVal: "id2"}}
+	kv.relations = []arch.Relation{
+		&MockRelation{relationType: arch.RelationTypeAssociation, fromObject: mockObject1},
+		&MockRelation{relationType: arch.RelationTypeComposition, fromObject: mockObject2},
+		&MockRelation{relationType: arch.RelationTypeDependency, fromObject: mockObject1},
+	}
+
+	deleted := kv.Delete(func(rel arch.Relation) bool {
+		return rel.From().Identifier().ID() == "id1"
+	})
+
+	if deleted != 2 {
+		t.Errorf("Expected 2 relations deleted, but got %d", deleted)
+	}
+	if len(kv.relations) != 1 || kv.relations[0].Type() != arch.RelationTypeComposition {
+		t.Errorf("Expected only the composition relation left, but got %v", kv.relations)
+	}
+}
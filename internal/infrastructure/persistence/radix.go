@@ -37,6 +37,21 @@ func (r *RadixTree) Insert(obj arch.Object) error {
 	return fmt.Errorf("insert failed")
 }
 
+func (r *RadixTree) Update(obj arch.Object) error {
+	if r.Find(obj.Identifier()) == nil {
+		return fmt.Errorf("object %s not found", obj.Identifier().ID())
+	}
+	return r.Insert(obj)
+}
+
+func (r *RadixTree) Delete(id arch.ObjIdentifier) error {
+	if ok := r.Tree.Delete(id.ID()); !ok {
+		return fmt.Errorf("object %s not found", id.ID())
+	}
+	delete(r.objIds, id.ID())
+	return nil
+}
+
 func (r *RadixTree) All() []arch.ObjIdentifier {
 	var ids []arch.ObjIdentifier
 	for _, i := range r.objIds {
@@ -289,3 +289,45 @@ func TestRadixTree_GetObjects(t *testing.T) {
 		}
 	})
 }
+
+func TestRadixTree_Update(t *testing.T) {
+	r := NewRadixTree()
+	mockID := &MockIdentifier{IDVal: "id1"}
+
+	if err := r.Update(&MockObject{id: mockID}); err == nil {
+		t.Errorf("Expected updating a missing object to fail")
+	}
+
+	_ = r.Insert(&MockObject{id: mockID})
+	updated := &MockObject{id: mockID}
+	if err := r.Update(updated); err != nil {
+		t.Errorf("Expected update to be successful, but got error: %v", err)
+	}
+	if r.Find(mockID) != updated {
+		t.Errorf("Expected the updated object to be found")
+	}
+}
+
+func TestRadixTree_Delete(t *testing.T) {
+	r := NewRadixTree()
+	mockID := &MockIdentifier{IDVal: "id1"}
+	otherID := &MockIdentifier{IDVal: "id12"}
+	_ = r.Insert(&MockObject{id: mockID})
+	_ = r.Insert(&MockObject{id: otherID})
+
+	if err := r.Delete(mockID); err != nil {
+		t.Errorf("Expected delete to be successful, but got error: %v", err)
+	}
+	if r.Find(mockID) != nil {
+		t.Errorf("Expected the deleted object to be gone")
+	}
+	if r.Find(otherID) == nil {
+		t.Errorf("Expected the other object to be kept")
+	}
+	if len(r.All()) != 1 {
+		t.Errorf("Expected 1 object id left, but got %d", len(r.All()))
+	}
+	if err := r.Delete(mockID); err == nil {
+		t.Errorf("Expected deleting a missing object to fail")
+	}
+}
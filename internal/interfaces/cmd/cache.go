@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/dddplayer/dp/internal/application"
+	"github.com/dddplayer/dp/internal/infrastructure/persistence"
+	"os"
+)
+
+const noCacheEnv = "DP_NO_CACHE"
+
+// codeCache returns the disk cache for the analysed code, or nil when it is
+// turned off by the DP_NO_CACHE environment variable.
+func codeCache() application.CodeCache {
+	if os.Getenv(noCacheEnv) != "" {
+		return nil
+	}
+	return persistence.NewDiskCache()
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/dddplayer/dp/internal/application"
+	"github.com/dddplayer/dp/internal/infrastructure/persistence"
+)
+
+type callersCmd struct {
+	parent     *flag.FlagSet
+	cmd        *flag.FlagSet
+	mainFlag   *string
+	targetFlag *string
+	depthFlag  *int
+	algoFlag   *string
+}
+
+func NewCallersCmd(parent *flag.FlagSet) (*callersCmd, error) {
+	cCmd := &callersCmd{
+		parent: parent,
+	}
+
+	cCmd.cmd = flag.NewFlagSet("callers", flag.ExitOnError)
+	cCmd.mainFlag = cCmd.cmd.String("m", "", fmt.Sprintf(
+		"[required] main package path \n(e.g. %s)", "~/github/dddplayer/dp"))
+	cCmd.targetFlag = cCmd.cmd.String("t", "", fmt.Sprintf(
+		"[required] comma separated functions to trace the callers of, glob patterns allowed \n(e.g. %s)",
+		"github.com/dddplayer/dp/internal/domain/arch/entity/Arch.Callers"))
+	cCmd.depthFlag = cCmd.cmd.Int("max-depth", 0, "maximum number of callers walked back from a target, 0 for no limit")
+	cCmd.algoFlag = callGraphFlag(cCmd.cmd)
+
+	err := cCmd.cmd.Parse(parent.Args()[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cCmd, nil
+}
+
+func (cc *callersCmd) Usage() {
+	cc.cmd.Usage()
+}
+
+func (cc *callersCmd) Run() error {
+	if *cc.mainFlag == "" {
+		cc.cmd.Usage()
+		return errors.New("please specify the main package")
+	}
+
+	targets := funcPatterns(*cc.targetFlag)
+	if len(targets) == 0 {
+		cc.cmd.Usage()
+		return errors.New("please specify a target function")
+	}
+
+	if *cc.depthFlag < 0 {
+		cc.cmd.Usage()
+		return errors.New("max depth cannot be negative")
+	}
+
+	algo, err := application.ParseCallGraph(*cc.algoFlag)
+	if err != nil {
+		cc.cmd.Usage()
+		return err
+	}
+
+	lines, err := application.Callers(*cc.mainFlag, targets, *cc.depthFlag, algo,
+		persistence.NewRadixTree(),
+		&persistence.Relations{},
+		codeCache(),
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, l := range lines {
+		fmt.Println(l)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/dddplayer/dp/internal/application"
+	"github.com/dddplayer/dp/internal/infrastructure/persistence"
+)
+
+func callGraphFlag(fs *flag.FlagSet) *string {
+	return fs.String("callgraph", string(application.CallGraphStatic),
+		"call graph algorithm: static, cha, rta, pointer")
+}
+
+type callGraphCmd struct {
+	parent      *flag.FlagSet
+	cmd         *flag.FlagSet
+	mainFlag    *string
+	pkgFlag     *string
+	baseFlag    *string
+	compareFlag *string
+}
+
+func NewCallGraphCmd(parent *flag.FlagSet) (*callGraphCmd, error) {
+	cCmd := &callGraphCmd{
+		parent: parent,
+	}
+
+	cCmd.cmd = flag.NewFlagSet("callgraph", flag.ExitOnError)
+	cCmd.mainFlag = cCmd.cmd.String("m", "", fmt.Sprintf(
+		"[required] main package path \n(e.g. %s)", "github.com/dddplayer/dp"))
+	cCmd.pkgFlag = cCmd.cmd.String("p", "", fmt.Sprintf(
+		"[required] target package \n(e.g. %s)", "github.com/dddplayer/dp/internal/domain"))
+	cCmd.baseFlag = cCmd.cmd.String("base", string(application.CallGraphStatic),
+		"call graph algorithm to compare from: static, cha, rta, pointer")
+	cCmd.compareFlag = cCmd.cmd.String("compare", string(application.CallGraphCha),
+		"call graph algorithm to compare with: static, cha, rta, pointer")
+
+	err := cCmd.cmd.Parse(parent.Args()[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cCmd, nil
+}
+
+func (cc *callGraphCmd) Usage() {
+	cc.cmd.Usage()
+}
+
+func (cc *callGraphCmd) Run() error {
+	if *cc.mainFlag == "" {
+		cc.cmd.Usage()
+		return errors.New("please specify the main package")
+	}
+
+	if *cc.pkgFlag == "" {
+		cc.cmd.Usage()
+		return errors.New("please specify a target package full name")
+	}
+
+	base, err := application.ParseCallGraph(*cc.baseFlag)
+	if err != nil {
+		cc.cmd.Usage()
+		return err
+	}
+	other, err := application.ParseCallGraph(*cc.compareFlag)
+	if err != nil {
+		cc.cmd.Usage()
+		return err
+	}
+
+	lines, err := application.CompareCallGraphs(*cc.mainFlag, *cc.pkgFlag, base, other,
+		persistence.NewRadixTree(),
+		&persistence.Relations{},
+		codeCache(),
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, l := range lines {
+		fmt.Println(l)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/dddplayer/dp/internal/application"
+	"github.com/dddplayer/dp/internal/domain/code/entity"
+	"github.com/dddplayer/dp/internal/infrastructure/persistence"
+	"os"
+	"path"
+)
+
+const defaultRulesFileName = ".dp-rules.json"
+
+type checkCmd struct {
+	parent        *flag.FlagSet
+	cmd           *flag.FlagSet
+	mainFlag      *string
+	rulesFlag     *string
+	aggregateFlag *bool
+	repoFlag      *bool
+}
+
+func NewCheckCmd(parent *flag.FlagSet) (*checkCmd, error) {
+	cCmd := &checkCmd{
+		parent: parent,
+	}
+
+	cCmd.cmd = flag.NewFlagSet("check", flag.ExitOnError)
+	cCmd.mainFlag = cCmd.cmd.String("m", "", fmt.Sprintf(
+		"[required] main package path \n(e.g. %s)", "~/github/dddplayer/dp"))
+	cCmd.rulesFlag = cCmd.cmd.String("r", "", fmt.Sprintf(
+		"rules file path, defaults to %s in the project root", defaultRulesFileName))
+	cCmd.aggregateFlag = cCmd.cmd.Bool("aggregate", false,
+		"check that references and calls across aggregates go through the aggregate root")
+	cCmd.repoFlag = cCmd.cmd.Bool("repository", false, "check that each aggregate has exactly one repository")
+
+	err := cCmd.cmd.Parse(parent.Args()[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cCmd, nil
+}
+
+func (cc *checkCmd) Usage() {
+	cc.cmd.Usage()
+}
+
+func (cc *checkCmd) Run() error {
+	if *cc.mainFlag == "" {
+		cc.cmd.Usage()
+		return errors.New("please specify the main package")
+	}
+
+	rules, err := cc.readRules()
+	if err != nil {
+		return err
+	}
+	if rules == nil && !*cc.aggregateFlag && !*cc.repoFlag {
+		return errors.New("no rules file found, please specify one with -r or use -aggregate or -repository")
+	}
+
+	violations, err := application.Check(*cc.mainFlag, rules, *cc.aggregateFlag, *cc.repoFlag,
+		persistence.NewRadixTree(),
+		&persistence.Relations{},
+		codeCache(),
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range violations {
+		fmt.Println(v)
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("%d architecture violation(s) found", len(violations))
+	}
+
+	fmt.Println("no architecture violations found")
+	return nil
+}
+
+func (cc *checkCmd) readRules() ([]byte, error) {
+	if *cc.rulesFlag != "" {
+		return os.ReadFile(*cc.rulesFlag)
+	}
+
+	projectRootDir, err := entity.ModuleRoot(*cc.mainFlag)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := os.ReadFile(path.Join(projectRootDir, defaultRulesFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return rules, err
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/dddplayer/dp/internal/application"
+	"github.com/dddplayer/dp/internal/infrastructure/persistence"
+)
+
+type cycleCmd struct {
+	parent      *flag.FlagSet
+	cmd         *flag.FlagSet
+	mainFlag    *string
+	levelFlag   *string
+	diagramFlag *bool
+	formatFlag  *string
+	websiteFlag *bool
+}
+
+func NewCycleCmd(parent *flag.FlagSet) (*cycleCmd, error) {
+	cCmd := &cycleCmd{
+		parent: parent,
+	}
+
+	cCmd.cmd = flag.NewFlagSet("cycle", flag.ExitOnError)
+	cCmd.mainFlag = cCmd.cmd.String("m", "", fmt.Sprintf(
+		"[required] main package path \n(e.g. %s)", "~/github/dddplayer/dp"))
+	cCmd.levelFlag = cCmd.cmd.String("level", "package", "cycle level: package or object")
+	cCmd.diagramFlag = cCmd.cmd.Bool("diagram", false, "render the cycle subgraph with cycle edges highlighted")
+	cCmd.formatFlag = formatFlag(cCmd.cmd)
+	cCmd.websiteFlag = websiteFlag(cCmd.cmd)
+
+	err := cCmd.cmd.Parse(parent.Args()[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cCmd, nil
+}
+
+func (cc *cycleCmd) Usage() {
+	cc.cmd.Usage()
+}
+
+func (cc *cycleCmd) Run() error {
+	if *cc.mainFlag == "" {
+		cc.cmd.Usage()
+		return errors.New("please specify the main package")
+	}
+
+	level, err := application.ParseCycleLevel(*cc.levelFlag)
+	if err != nil {
+		cc.cmd.Usage()
+		return err
+	}
+
+	if *cc.diagramFlag {
+		format, err := application.ParseFormat(*cc.formatFlag)
+		if err != nil {
+			cc.cmd.Usage()
+			return err
+		}
+
+		raw, err := application.CycleGraph(*cc.mainFlag, level,
+			persistence.NewRadixTree(),
+			&persistence.Relations{},
+			codeCache(),
+			format,
+		)
+		if err != nil {
+			return err
+		}
+
+		return present(raw, format, *cc.websiteFlag, filename(string(level), "cycle"), *cc.mainFlag)
+	}
+
+	lines, err := application.Cycles(*cc.mainFlag, level,
+		persistence.NewRadixTree(),
+		&persistence.Relations{},
+		codeCache(),
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, l := range lines {
+		fmt.Println(l)
+	}
+	if len(lines) > 0 {
+		return fmt.Errorf("%s dependency cycles found", level)
+	}
+
+	fmt.Printf("no %s dependency cycles found\n", level)
+	return nil
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/dddplayer/dp/internal/application"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/infrastructure/git"
+	"github.com/dddplayer/dp/internal/infrastructure/persistence"
+)
+
+type diffCmd struct {
+	parent      *flag.FlagSet
+	cmd         *flag.FlagSet
+	mainFlag    *string
+	diagramFlag *bool
+	formatFlag  *string
+	websiteFlag *bool
+}
+
+func NewDiffCmd(parent *flag.FlagSet) (*diffCmd, error) {
+	dCmd := &diffCmd{
+		parent: parent,
+	}
+
+	dCmd.cmd = flag.NewFlagSet("diff", flag.ExitOnError)
+	dCmd.cmd.Usage = func() {
+		fmt.Println("Usage:\n  dp diff -m <main package path> [flags] <rev-a> <rev-b>")
+		dCmd.cmd.PrintDefaults()
+	}
+	dCmd.mainFlag = dCmd.cmd.String("m", "", fmt.Sprintf(
+		"[required] main package path \n(e.g. %s)", "~/github/dddplayer/dp"))
+	dCmd.diagramFlag = dCmd.cmd.Bool("diagram", false, "render added, removed and changed objects and relations by colour")
+	dCmd.formatFlag = formatFlag(dCmd.cmd)
+	dCmd.websiteFlag = websiteFlag(dCmd.cmd)
+
+	err := dCmd.cmd.Parse(parent.Args()[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return dCmd, nil
+}
+
+func (dc *diffCmd) Usage() {
+	dc.cmd.Usage()
+}
+
+func (dc *diffCmd) Run() error {
+	if *dc.mainFlag == "" {
+		dc.cmd.Usage()
+		return errors.New("please specify the main package")
+	}
+
+	if dc.cmd.NArg() != 2 {
+		dc.cmd.Usage()
+		return errors.New("please specify the two revisions to compare")
+	}
+	base, head := dc.cmd.Arg(0), dc.cmd.Arg(1)
+
+	if *dc.diagramFlag {
+		format, err := application.ParseFormat(*dc.formatFlag)
+		if err != nil {
+			dc.cmd.Usage()
+			return err
+		}
+		if format == application.FormatJSON {
+			dc.cmd.Usage()
+			return errors.New("json is not supported by the diff diagram")
+		}
+
+		raw, err := application.RevisionDiffGraph(*dc.mainFlag, base, head, git.NewWorktrees(), newRepositories, format)
+		if err != nil {
+			return err
+		}
+
+		return present(raw, format, *dc.websiteFlag, filename(fmt.Sprintf("diff/%s/%s", base, head), ""), *dc.mainFlag)
+	}
+
+	lines, err := application.RevisionDiff(*dc.mainFlag, base, head, git.NewWorktrees(), newRepositories)
+	if err != nil {
+		return err
+	}
+
+	for _, l := range lines {
+		fmt.Println(l)
+	}
+	return nil
+}
+
+func newRepositories() (repository.ObjectRepository, repository.RelationRepository) {
+	return persistence.NewRadixTree(), &persistence.Relations{}
+}
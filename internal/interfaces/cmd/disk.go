@@ -3,9 +3,9 @@ package cmd
 import (
 	"crypto/sha1"
 	"fmt"
+	"github.com/dddplayer/dp/internal/domain/code/entity"
 	"os"
 	"path"
-	"path/filepath"
 )
 
 const diskFolderName = "dddplayer"
@@ -13,10 +13,11 @@ const diskFolderName = "dddplayer"
 type DiskWriter struct {
 	content string
 	name    string
+	ext     string
 	root    string
 }
 
-func NewDiskWriter(content, filename, mainPath string) (*DiskWriter, error) {
+func NewDiskWriter(content, filename, ext, mainPath string) (*DiskWriter, error) {
 	rootDir, err := createDiskFolderIfNotExist(mainPath)
 	if err != nil {
 		return nil, err
@@ -25,6 +26,7 @@ func NewDiskWriter(content, filename, mainPath string) (*DiskWriter, error) {
 	dw := &DiskWriter{
 		content: content,
 		name:    filename,
+		ext:     ext,
 		root:    rootDir,
 	}
 
@@ -32,7 +34,7 @@ func NewDiskWriter(content, filename, mainPath string) (*DiskWriter, error) {
 }
 
 func createDiskFolderIfNotExist(mainPath string) (string, error) {
-	projectRootDir, err := findProjectRootDir(mainPath)
+	projectRootDir, err := entity.ModuleRoot(mainPath)
 	if err != nil {
 		return "", err
 	}
@@ -109,29 +111,11 @@ func (dw *DiskWriter) write(content, filename string) error {
 }
 
 func (dw *DiskWriter) filename() string {
-	return path.Join(dw.root, fmt.Sprintf("%s.dot", dw.name))
+	return path.Join(dw.root, fmt.Sprintf("%s.%s", dw.name, dw.ext))
 }
 
 func (dw *DiskWriter) hashName() string {
-	return path.Join(dw.root, fmt.Sprintf("%s.hash", dw.name))
-}
-
-func findProjectRootDir(startDir string) (string, error) {
-	dir := startDir
-	for {
-		goModPath := filepath.Join(dir, "go.mod")
-		_, err := os.Stat(goModPath)
-		if err == nil {
-			return dir, nil
-		}
-
-		if dir == "/" || dir == "." {
-			return dir, fmt.Errorf("cannot find go.mod file")
-		}
-
-		// 向上级目录移动一级
-		dir = filepath.Dir(dir)
-	}
+	return path.Join(dw.root, fmt.Sprintf("%s.%s.hash", dw.name, dw.ext))
 }
 
 func sha1Sum(s string) string {
@@ -141,13 +125,14 @@ func sha1Sum(s string) string {
 	return fmt.Sprintf("%x", b)
 }
 
-func writeToDisk(raw, filename, mainPkg string) error {
-	dw, err := NewDiskWriter(raw, filename, mainPkg)
+// writeToDisk saves raw in the dddplayer folder of the project and returns the file written.
+func writeToDisk(raw, filename, ext, mainPkg string) (string, error) {
+	dw, err := NewDiskWriter(raw, filename, ext, mainPkg)
 	if err != nil {
-		return err
+		return "", err
 	}
 	if err := dw.Write(); err != nil {
-		return err
+		return "", err
 	}
-	return nil
+	return dw.filename(), nil
 }
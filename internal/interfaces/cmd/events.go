@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/dddplayer/dp/internal/application"
+	"github.com/dddplayer/dp/internal/infrastructure/persistence"
+)
+
+type eventsCmd struct {
+	parent      *flag.FlagSet
+	cmd         *flag.FlagSet
+	mainFlag    *string
+	diagramFlag *bool
+	formatFlag  *string
+	websiteFlag *bool
+}
+
+func NewEventsCmd(parent *flag.FlagSet) (*eventsCmd, error) {
+	eCmd := &eventsCmd{
+		parent: parent,
+	}
+
+	eCmd.cmd = flag.NewFlagSet("events", flag.ExitOnError)
+	eCmd.mainFlag = eCmd.cmd.String("m", "", fmt.Sprintf(
+		"[required] main package path \n(e.g. %s)", "~/github/dddplayer/dp"))
+	eCmd.diagramFlag = eCmd.cmd.Bool("diagram", false, "render the event flow of publishers, messages and subscribers per context")
+	eCmd.formatFlag = formatFlag(eCmd.cmd)
+	eCmd.websiteFlag = websiteFlag(eCmd.cmd)
+
+	err := eCmd.cmd.Parse(parent.Args()[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return eCmd, nil
+}
+
+func (ec *eventsCmd) Usage() {
+	ec.cmd.Usage()
+}
+
+func (ec *eventsCmd) Run() error {
+	if *ec.mainFlag == "" {
+		ec.cmd.Usage()
+		return errors.New("please specify the main package")
+	}
+
+	if *ec.diagramFlag {
+		format, err := application.ParseFormat(*ec.formatFlag)
+		if err != nil {
+			ec.cmd.Usage()
+			return err
+		}
+
+		raw, err := application.EventFlowGraph(*ec.mainFlag,
+			persistence.NewRadixTree(),
+			&persistence.Relations{},
+			codeCache(),
+			format,
+		)
+		if err != nil {
+			return err
+		}
+
+		return present(raw, format, *ec.websiteFlag, filename("event", "flow"), *ec.mainFlag)
+	}
+
+	lines, err := application.EventFlows(*ec.mainFlag,
+		persistence.NewRadixTree(),
+		&persistence.Relations{},
+		codeCache(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(lines) == 0 {
+		fmt.Println("no domain events or commands found")
+		return nil
+	}
+	for _, l := range lines {
+		fmt.Println(l)
+	}
+	return nil
+}
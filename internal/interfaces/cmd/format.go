@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"github.com/dddplayer/dp/internal/application"
+)
+
+var formatExt = map[application.Format]string{
+	application.FormatDot:      "dot",
+	application.FormatMermaid:  "mmd",
+	application.FormatPlantUML: "puml",
+	application.FormatJSON:     "json",
+}
+
+func formatFlag(fs *flag.FlagSet) *string {
+	return fs.String("format", string(application.FormatDot), "output format: dot, mermaid, plantuml, json")
+}
+
+func websiteFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("website", false, fmt.Sprintf(
+		"also open a dot diagram on %s, which uploads the diagram in the url, local only by default", officialWebsiteUrl))
+}
+
+// present saves the diagram in the project before showing it, a dot diagram is only handed to the
+// website when asked to, otherwise it stays on this machine for the local viewer.
+func present(raw string, format application.Format, website bool, name, mainPkg string) error {
+	file, err := writeToDisk(raw, name, formatExt[format], mainPkg)
+	if err != nil {
+		return err
+	}
+
+	if format != application.FormatDot {
+		fmt.Print(raw)
+		return nil
+	}
+
+	fmt.Printf("saved %s, view it with: dp serve -m %s (http://%s)\n", file, mainPkg, defaultServeAddr)
+	if website {
+		return open(raw)
+	}
+	return nil
+}
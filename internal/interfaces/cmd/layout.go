@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/code/entity"
+	"os"
+	"path"
+)
+
+const defaultLayoutFileName = ".dp-layout.json"
+
+func layoutFlag(fs *flag.FlagSet) *string {
+	return fs.String("layout", "", fmt.Sprintf(
+		"project layout file path, defaults to %s in the project root", defaultLayoutFileName))
+}
+
+func readLayout(layoutFile, mainPkg string) ([]byte, error) {
+	if layoutFile != "" {
+		return os.ReadFile(layoutFile)
+	}
+
+	projectRootDir, err := entity.ModuleRoot(mainPkg)
+	if err != nil {
+		return nil, err
+	}
+	layout, err := os.ReadFile(path.Join(projectRootDir, defaultLayoutFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return layout, err
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/dddplayer/dp/internal/application"
+	"github.com/dddplayer/dp/internal/infrastructure/persistence"
+)
+
+type metricsCmd struct {
+	parent     *flag.FlagSet
+	cmd        *flag.FlagSet
+	mainFlag   *string
+	formatFlag *string
+}
+
+func NewMetricsCmd(parent *flag.FlagSet) (*metricsCmd, error) {
+	mCmd := &metricsCmd{
+		parent: parent,
+	}
+
+	mCmd.cmd = flag.NewFlagSet("metrics", flag.ExitOnError)
+	mCmd.mainFlag = mCmd.cmd.String("m", "", fmt.Sprintf(
+		"[required] main package path \n(e.g. %s)", "~/github/dddplayer/dp"))
+	mCmd.formatFlag = mCmd.cmd.String("format", string(application.MetricsFormatTable),
+		"output format: table, json, csv")
+
+	err := mCmd.cmd.Parse(parent.Args()[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return mCmd, nil
+}
+
+func (mc *metricsCmd) Usage() {
+	mc.cmd.Usage()
+}
+
+func (mc *metricsCmd) Run() error {
+	if *mc.mainFlag == "" {
+		mc.cmd.Usage()
+		return errors.New("please specify the main package")
+	}
+
+	format, err := application.ParseMetricsFormat(*mc.formatFlag)
+	if err != nil {
+		mc.cmd.Usage()
+		return err
+	}
+
+	out, err := application.Metrics(*mc.mainFlag,
+		persistence.NewRadixTree(),
+		&persistence.Relations{},
+		codeCache(),
+		format,
+	)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(out)
+	return nil
+}
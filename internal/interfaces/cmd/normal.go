@@ -5,18 +5,30 @@ import (
 	"flag"
 	"fmt"
 	"github.com/dddplayer/dp/internal/application"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
 	"github.com/dddplayer/dp/internal/infrastructure/persistence"
 	"strings"
 )
 
 type normalCmd struct {
-	parent     *flag.FlagSet
-	cmd        *flag.FlagSet
-	mainFlag   *string
-	pkgFlag    *string
-	comFlag    *bool
-	detailFlag *bool
-	mfFlag     *bool
+	parent      *flag.FlagSet
+	cmd         *flag.FlagSet
+	mainFlag    *string
+	pkgFlag     *string
+	comFlag     *bool
+	detailFlag  *bool
+	mfFlag      *bool
+	startFlag   *string
+	endFlag     *string
+	seqFlag     *bool
+	routesFlag  *bool
+	modeFlag    *string
+	depthFlag   *int
+	pathsFlag   *int
+	stepsFlag   *int
+	formatFlag  *string
+	websiteFlag *bool
+	algoFlag    *string
 }
 
 func NewNormalCmd(parent *flag.FlagSet) (*normalCmd, error) {
@@ -32,6 +44,24 @@ func NewNormalCmd(parent *flag.FlagSet) (*normalCmd, error) {
 	nCmd.comFlag = nCmd.cmd.Bool("c", false, "show struct composition relation")
 	nCmd.detailFlag = nCmd.cmd.Bool("d", false, "show all relations")
 	nCmd.mfFlag = nCmd.cmd.Bool("mf", false, "show message flow relations")
+	nCmd.startFlag = nCmd.cmd.String("start", "", fmt.Sprintf(
+		"comma separated functions the message flows start from, glob patterns allowed, main func by default \n(e.g. %s)",
+		"github.com/dddplayer/dp/internal/interfaces/cmd/*.Run"))
+	nCmd.endFlag = nCmd.cmd.String("end", "", fmt.Sprintf(
+		"package or function the message flows end at, target package by default \n(e.g. %s)",
+		"github.com/dddplayer/dp/internal/domain/arch/entity"))
+	nCmd.seqFlag = nCmd.cmd.Bool("seq", false, "show message flows as a sequence diagram in mermaid or plantuml format, implies -mf")
+	nCmd.routesFlag = nCmd.cmd.Bool("routes", false,
+		"start the message flows from the http routes, -start then matches route names like 'GET /orders/*', implies -mf")
+	nCmd.modeFlag = nCmd.cmd.String("mf-mode", "all",
+		"how message flow paths are searched: all, shortest (the -max-paths shortest ones) or reach (every relation on a path, linear time)")
+	nCmd.depthFlag = nCmd.cmd.Int("max-depth", 16, "maximum number of calls of a message flow path, 0 for no limit")
+	nCmd.pathsFlag = nCmd.cmd.Int("max-paths", 1000, "maximum number of message flow paths per start function, 0 for no limit")
+	nCmd.stepsFlag = nCmd.cmd.Int("max-steps", 1000000,
+		"maximum number of calls the all mode follows per start function, stops the search when no path is found, 0 for no limit")
+	nCmd.formatFlag = formatFlag(nCmd.cmd)
+	nCmd.websiteFlag = websiteFlag(nCmd.cmd)
+	nCmd.algoFlag = callGraphFlag(nCmd.cmd)
 
 	err := nCmd.cmd.Parse(parent.Args()[1:])
 	if err != nil {
@@ -56,99 +86,127 @@ func (nc *normalCmd) Run() error {
 		return errors.New("please specify a target package full name")
 	}
 
+	format, err := application.ParseFormat(*nc.formatFlag)
+	if err != nil {
+		nc.cmd.Usage()
+		return err
+	}
+
+	algo, err := application.ParseCallGraph(*nc.algoFlag)
+	if err != nil {
+		nc.cmd.Usage()
+		return err
+	}
+
 	if *nc.comFlag {
-		return normalCompositionGraph(*nc.mainFlag, *nc.pkgFlag)
+		return normalCompositionGraph(*nc.mainFlag, *nc.pkgFlag, algo, format, *nc.websiteFlag)
 	}
 
-	if *nc.mfFlag {
-		return normalMessageFlowGraph(*nc.mainFlag, *nc.pkgFlag)
+	if *nc.seqFlag || *nc.mfFlag || *nc.routesFlag {
+		search, err := application.NewFlowSearch(*nc.modeFlag, *nc.depthFlag, *nc.pathsFlag, *nc.stepsFlag)
+		if err != nil {
+			nc.cmd.Usage()
+			return err
+		}
+		search.Routes = *nc.routesFlag
+
+		if *nc.seqFlag {
+			return normalMessageFlowSequence(*nc.mainFlag, *nc.pkgFlag,
+				funcPatterns(*nc.startFlag), *nc.endFlag, search, algo, format, *nc.websiteFlag)
+		}
+		return normalMessageFlowGraph(*nc.mainFlag, *nc.pkgFlag,
+			funcPatterns(*nc.startFlag), *nc.endFlag, search, algo, format, *nc.websiteFlag)
 	}
 
 	if *nc.detailFlag {
-		return normalDetailGraph(*nc.mainFlag, *nc.pkgFlag)
+		return normalDetailGraph(*nc.mainFlag, *nc.pkgFlag, algo, format, *nc.websiteFlag)
 	}
 
-	return normalGraph(*nc.mainFlag, *nc.pkgFlag)
+	return normalGraph(*nc.mainFlag, *nc.pkgFlag, algo, format, *nc.websiteFlag)
 }
 
-func normalCompositionGraph(mainPkg, domain string) error {
-	dot, err := application.CompositionGeneralGraph(mainPkg, domain,
+func normalCompositionGraph(mainPkg, domain string, algo application.CallGraph, format application.Format, website bool) error {
+	raw, err := application.CompositionGeneralGraph(mainPkg, domain, algo,
 		persistence.NewRadixTree(),
 		&persistence.Relations{},
+		codeCache(),
+		format,
 	)
 	if err != nil {
 		return err
 	}
 
-	if err = open(dot); err != nil {
-		return err
-	}
-
-	if err = writeToDisk(dot, filename(domain, "composition"), mainPkg); err != nil {
-		return err
-	}
-
-	return nil
+	return present(raw, format, website, filename(domain, "composition"), mainPkg)
 }
 
-func normalDetailGraph(mainPkg, domain string) error {
-	dot, err := application.DetailGeneralGraph(mainPkg, domain,
+func normalDetailGraph(mainPkg, domain string, algo application.CallGraph, format application.Format, website bool) error {
+	raw, err := application.DetailGeneralGraph(mainPkg, domain, algo,
 		persistence.NewRadixTree(),
 		&persistence.Relations{},
+		codeCache(),
+		format,
 	)
 	if err != nil {
 		return err
 	}
 
-	if err = open(dot); err != nil {
-		return err
-	}
-
-	if err = writeToDisk(dot, filename(domain, "detail"), mainPkg); err != nil {
-		return err
-	}
-
-	return nil
+	return present(raw, format, website, filename(domain, "detail"), mainPkg)
 }
 
-func normalMessageFlowGraph(mainPkg, domain string) error {
-	dot, err := application.MessageFlowGraph(mainPkg, domain,
+func normalMessageFlowGraph(mainPkg, domain string, starts []string, end string, search valueobject.FlowSearch,
+	algo application.CallGraph, format application.Format, website bool) error {
+	raw, err := application.MessageFlowGraph(mainPkg, domain, algo,
 		persistence.NewRadixTree(),
 		&persistence.Relations{},
+		codeCache(),
+		starts, end, search,
+		format,
 	)
 	if err != nil {
 		return err
 	}
 
-	if err = open(dot); err != nil {
-		return err
-	}
+	return present(raw, format, website, filename(domain, "messageflow"), mainPkg)
+}
 
-	if err = writeToDisk(dot, filename(domain, "messageflow"), mainPkg); err != nil {
+func normalMessageFlowSequence(mainPkg, domain string, starts []string, end string, search valueobject.FlowSearch,
+	algo application.CallGraph, format application.Format, website bool) error {
+	raw, err := application.MessageFlowSequence(mainPkg, domain, algo,
+		persistence.NewRadixTree(),
+		&persistence.Relations{},
+		codeCache(),
+		starts, end, search,
+		format,
+	)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	return present(raw, format, website, filename(domain, "sequence"), mainPkg)
 }
 
-func normalGraph(mainPkg, domain string) error {
-	dot, err := application.GeneralGraph(mainPkg, domain,
+func normalGraph(mainPkg, domain string, algo application.CallGraph, format application.Format, website bool) error {
+	raw, err := application.GeneralGraph(mainPkg, domain, algo,
 		persistence.NewRadixTree(),
 		&persistence.Relations{},
+		codeCache(),
+		format,
 	)
 	if err != nil {
 		return err
 	}
 
-	if err = open(dot); err != nil {
-		return err
-	}
+	return present(raw, format, website, filename(domain, ""), mainPkg)
+}
 
-	if err = writeToDisk(dot, filename(domain, ""), mainPkg); err != nil {
-		return err
+func funcPatterns(raw string) []string {
+	var starts []string
+	for _, s := range strings.Split(raw, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			starts = append(starts, s)
+		}
 	}
-
-	return nil
+	return starts
 }
 
 func filename(main, sub string) string {
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/dddplayer/dp/internal/application"
+	"github.com/dddplayer/dp/internal/infrastructure/persistence"
+)
+
+type portsCmd struct {
+	parent      *flag.FlagSet
+	cmd         *flag.FlagSet
+	mainFlag    *string
+	diagramFlag *bool
+	formatFlag  *string
+	websiteFlag *bool
+}
+
+func NewPortsCmd(parent *flag.FlagSet) (*portsCmd, error) {
+	pCmd := &portsCmd{
+		parent: parent,
+	}
+
+	pCmd.cmd = flag.NewFlagSet("ports", flag.ExitOnError)
+	pCmd.mainFlag = pCmd.cmd.String("m", "", fmt.Sprintf(
+		"[required] main package path \n(e.g. %s)", "~/github/dddplayer/dp"))
+	pCmd.diagramFlag = pCmd.cmd.Bool("diagram", false, "render ports grouped by layer with edges to their adapters")
+	pCmd.formatFlag = formatFlag(pCmd.cmd)
+	pCmd.websiteFlag = websiteFlag(pCmd.cmd)
+
+	err := pCmd.cmd.Parse(parent.Args()[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return pCmd, nil
+}
+
+func (pc *portsCmd) Usage() {
+	pc.cmd.Usage()
+}
+
+func (pc *portsCmd) Run() error {
+	if *pc.mainFlag == "" {
+		pc.cmd.Usage()
+		return errors.New("please specify the main package")
+	}
+
+	if *pc.diagramFlag {
+		format, err := application.ParseFormat(*pc.formatFlag)
+		if err != nil {
+			pc.cmd.Usage()
+			return err
+		}
+
+		raw, err := application.PortsGraph(*pc.mainFlag,
+			persistence.NewRadixTree(),
+			&persistence.Relations{},
+			codeCache(),
+			format,
+		)
+		if err != nil {
+			return err
+		}
+
+		return present(raw, format, *pc.websiteFlag, filename("ports", "adapters"), *pc.mainFlag)
+	}
+
+	lines, err := application.Ports(*pc.mainFlag,
+		persistence.NewRadixTree(),
+		&persistence.Relations{},
+		codeCache(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(lines) == 0 {
+		fmt.Println("no ports found")
+		return nil
+	}
+	for _, l := range lines {
+		fmt.Println(l)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/dddplayer/dp/internal/application"
+	"github.com/dddplayer/dp/internal/infrastructure/persistence"
+)
+
+type routesCmd struct {
+	parent   *flag.FlagSet
+	cmd      *flag.FlagSet
+	mainFlag *string
+}
+
+func NewRoutesCmd(parent *flag.FlagSet) (*routesCmd, error) {
+	rCmd := &routesCmd{
+		parent: parent,
+	}
+
+	rCmd.cmd = flag.NewFlagSet("routes", flag.ExitOnError)
+	rCmd.mainFlag = rCmd.cmd.String("m", "", fmt.Sprintf(
+		"[required] main package path \n(e.g. %s)", "~/github/dddplayer/dp"))
+
+	err := rCmd.cmd.Parse(parent.Args()[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return rCmd, nil
+}
+
+func (rc *routesCmd) Usage() {
+	rc.cmd.Usage()
+}
+
+func (rc *routesCmd) Run() error {
+	if *rc.mainFlag == "" {
+		rc.cmd.Usage()
+		return errors.New("please specify the main package")
+	}
+
+	lines, err := application.Routes(*rc.mainFlag,
+		persistence.NewRadixTree(),
+		&persistence.Relations{},
+		codeCache(),
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, l := range lines {
+		fmt.Println(l)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"github.com/dddplayer/dp/internal/application"
+)
+
+type schemaCmd struct {
+	parent *flag.FlagSet
+	cmd    *flag.FlagSet
+}
+
+func NewSchemaCmd(parent *flag.FlagSet) (*schemaCmd, error) {
+	sCmd := &schemaCmd{
+		parent: parent,
+	}
+
+	sCmd.cmd = flag.NewFlagSet("schema", flag.ExitOnError)
+	err := sCmd.cmd.Parse(parent.Args()[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return sCmd, nil
+}
+
+func (sc *schemaCmd) Usage() {
+	sc.cmd.Usage()
+}
+
+func (sc *schemaCmd) Run() error {
+	fmt.Print(application.ModelSchema())
+	return nil
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/dddplayer/dp/internal/domain/code/entity"
+	"github.com/dddplayer/dp/internal/interfaces/viewer"
+	"path"
+)
+
+const defaultServeAddr = "127.0.0.1:7070"
+
+type serveCmd struct {
+	parent   *flag.FlagSet
+	cmd      *flag.FlagSet
+	mainFlag *string
+	addrFlag *string
+}
+
+func NewServeCmd(parent *flag.FlagSet) (*serveCmd, error) {
+	sCmd := &serveCmd{
+		parent: parent,
+	}
+
+	sCmd.cmd = flag.NewFlagSet("serve", flag.ExitOnError)
+	sCmd.cmd.Usage = func() {
+		fmt.Println("Usage:\n  dp serve -m <main package path> [flags]")
+		fmt.Println("\nRequires graphviz dot in PATH to render the diagrams as svg, without it the DOT source is shown.")
+		sCmd.cmd.PrintDefaults()
+	}
+	sCmd.mainFlag = sCmd.cmd.String("m", "", fmt.Sprintf(
+		"[required] main package path \n(e.g. %s)", "~/github/dddplayer/dp"))
+	sCmd.addrFlag = sCmd.cmd.String("addr", defaultServeAddr, "local address the viewer listens on")
+
+	err := sCmd.cmd.Parse(parent.Args()[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return sCmd, nil
+}
+
+func (sc *serveCmd) Usage() {
+	sc.cmd.Usage()
+}
+
+func (sc *serveCmd) Run() error {
+	if *sc.mainFlag == "" {
+		sc.cmd.Usage()
+		return errors.New("please specify the main package")
+	}
+
+	projectRootDir, err := entity.ModuleRoot(*sc.mainFlag)
+	if err != nil {
+		return err
+	}
+
+	s, err := viewer.NewServer(path.Join(projectRootDir, diskFolderName))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("serving diagrams in %s\n", s.Root())
+	if !s.CanRender() {
+		fmt.Println("warn: graphviz dot not found in PATH, diagrams will be shown as DOT source")
+	}
+	fmt.Printf("open http://%s in your browser\n", *sc.addrFlag)
+
+	return s.ListenAndServe(*sc.addrFlag)
+}
@@ -15,6 +15,10 @@ type strategicCmd struct {
 	pkgFlag      *string
 	fastModeFlag *bool
 	deepModeFlag *bool
+	formatFlag   *string
+	websiteFlag  *bool
+	layoutFlag   *string
+	algoFlag     *string
 }
 
 func NewStrategicCmd(parent *flag.FlagSet) (*strategicCmd, error) {
@@ -28,7 +32,11 @@ func NewStrategicCmd(parent *flag.FlagSet) (*strategicCmd, error) {
 	sCmd.pkgFlag = sCmd.cmd.String("p", "", fmt.Sprintf(
 		"[required] target package \n(e.g. %s)", "github.com/dddplayer/dp/internal/domain"))
 	sCmd.fastModeFlag = sCmd.cmd.Bool("fast", true, "analysis code in fast mode to save time")
-	sCmd.deepModeFlag = sCmd.cmd.Bool("deep", false, "analysis code in fast mode to get more accurate information")
+	sCmd.deepModeFlag = sCmd.cmd.Bool("deep", false, "analysis code in deep mode to get more accurate information, same as -callgraph pointer")
+	sCmd.formatFlag = formatFlag(sCmd.cmd)
+	sCmd.websiteFlag = websiteFlag(sCmd.cmd)
+	sCmd.layoutFlag = layoutFlag(sCmd.cmd)
+	sCmd.algoFlag = callGraphFlag(sCmd.cmd)
 
 	err := sCmd.cmd.Parse(parent.Args()[1:])
 	if err != nil {
@@ -53,28 +61,40 @@ func (sc *strategicCmd) Run() error {
 		return errors.New("please specify a target package full name")
 	}
 
+	format, err := application.ParseFormat(*sc.formatFlag)
+	if err != nil {
+		sc.cmd.Usage()
+		return err
+	}
+
+	algo, err := application.ParseCallGraph(*sc.algoFlag)
+	if err != nil {
+		sc.cmd.Usage()
+		return err
+	}
+
+	layout, err := readLayout(*sc.layoutFlag, *sc.mainFlag)
+	if err != nil {
+		return err
+	}
+
 	if *sc.deepModeFlag {
-		return strategicGraph(*sc.mainFlag, *sc.pkgFlag, true)
+		return strategicGraph(*sc.mainFlag, *sc.pkgFlag, application.CallGraphPointer, layout, format, *sc.websiteFlag)
 	}
 
-	return strategicGraph(*sc.mainFlag, *sc.pkgFlag, false)
+	return strategicGraph(*sc.mainFlag, *sc.pkgFlag, algo, layout, format, *sc.websiteFlag)
 }
 
-func strategicGraph(mainPkg, domain string, deep bool) error {
-	dot, err := application.StrategicGraph(mainPkg, domain, deep,
+func strategicGraph(mainPkg, domain string, algo application.CallGraph, layout []byte, format application.Format, website bool) error {
+	raw, err := application.StrategicGraph(mainPkg, domain, algo, layout,
 		persistence.NewRadixTree(),
 		&persistence.Relations{},
+		codeCache(),
+		format,
 	)
 	if err != nil {
 		return err
 	}
 
-	if err = open(dot); err != nil {
-		return err
-	}
-	if err = writeToDisk(dot, filename(domain, "strategic"), mainPkg); err != nil {
-		return err
-	}
-
-	return nil
+	return present(raw, format, website, filename(domain, "strategic"), mainPkg)
 }
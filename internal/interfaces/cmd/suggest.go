@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/dddplayer/dp/internal/application"
+	"github.com/dddplayer/dp/internal/infrastructure/persistence"
+)
+
+const defaultIntimacyThreshold = 1.5
+
+type suggestCmd struct {
+	parent        *flag.FlagSet
+	cmd           *flag.FlagSet
+	mainFlag      *string
+	thresholdFlag *float64
+	diagramFlag   *bool
+	formatFlag    *string
+	websiteFlag   *bool
+}
+
+func NewSuggestCmd(parent *flag.FlagSet) (*suggestCmd, error) {
+	sCmd := &suggestCmd{
+		parent: parent,
+	}
+
+	sCmd.cmd = flag.NewFlagSet("suggest", flag.ExitOnError)
+	sCmd.mainFlag = sCmd.cmd.String("m", "", fmt.Sprintf(
+		"[required] main package path \n(e.g. %s)", "~/github/dddplayer/dp"))
+	sCmd.thresholdFlag = sCmd.cmd.Float64("threshold", defaultIntimacyThreshold,
+		"minimum intimacy for two objects to be clustered together")
+	sCmd.diagramFlag = sCmd.cmd.Bool("diagram", false,
+		"render the suggested aggregates with misplaced relations highlighted")
+	sCmd.formatFlag = formatFlag(sCmd.cmd)
+	sCmd.websiteFlag = websiteFlag(sCmd.cmd)
+
+	err := sCmd.cmd.Parse(parent.Args()[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return sCmd, nil
+}
+
+func (sc *suggestCmd) Usage() {
+	sc.cmd.Usage()
+}
+
+func (sc *suggestCmd) Run() error {
+	if *sc.mainFlag == "" {
+		sc.cmd.Usage()
+		return errors.New("please specify the main package")
+	}
+
+	if *sc.diagramFlag {
+		format, err := application.ParseFormat(*sc.formatFlag)
+		if err != nil {
+			sc.cmd.Usage()
+			return err
+		}
+
+		raw, err := application.SuggestedAggregatesGraph(*sc.mainFlag, *sc.thresholdFlag,
+			persistence.NewRadixTree(),
+			&persistence.Relations{},
+			codeCache(),
+			format,
+		)
+		if err != nil {
+			return err
+		}
+
+		return present(raw, format, *sc.websiteFlag, filename("aggregates", "suggested"), *sc.mainFlag)
+	}
+
+	lines, err := application.SuggestAggregates(*sc.mainFlag, *sc.thresholdFlag,
+		persistence.NewRadixTree(),
+		&persistence.Relations{},
+		codeCache(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(lines) == 0 {
+		fmt.Println("no aggregate suggestions found")
+		return nil
+	}
+	for _, l := range lines {
+		fmt.Println(l)
+	}
+	return nil
+}
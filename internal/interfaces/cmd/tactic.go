@@ -9,11 +9,15 @@ import (
 )
 
 type tacticCmd struct {
-	parent     *flag.FlagSet
-	cmd        *flag.FlagSet
-	mainFlag   *string
-	pkgFlag    *string
-	detailFlag *bool
+	parent      *flag.FlagSet
+	cmd         *flag.FlagSet
+	mainFlag    *string
+	pkgFlag     *string
+	detailFlag  *bool
+	formatFlag  *string
+	websiteFlag *bool
+	layoutFlag  *string
+	algoFlag    *string
 }
 
 func NewTacticCmd(parent *flag.FlagSet) (*tacticCmd, error) {
@@ -27,6 +31,10 @@ func NewTacticCmd(parent *flag.FlagSet) (*tacticCmd, error) {
 	tCmd.pkgFlag = tCmd.cmd.String("p", "", fmt.Sprintf(
 		"[required] target package \n(e.g. %s)", "github.com/dddplayer/dp/internal/domain"))
 	tCmd.detailFlag = tCmd.cmd.Bool("d", false, "show all relations")
+	tCmd.formatFlag = formatFlag(tCmd.cmd)
+	tCmd.websiteFlag = websiteFlag(tCmd.cmd)
+	tCmd.layoutFlag = layoutFlag(tCmd.cmd)
+	tCmd.algoFlag = callGraphFlag(tCmd.cmd)
 
 	err := tCmd.cmd.Parse(parent.Args()[1:])
 	if err != nil {
@@ -51,45 +59,54 @@ func (sc *tacticCmd) Run() error {
 		return errors.New("please specify a target package full name")
 	}
 
+	format, err := application.ParseFormat(*sc.formatFlag)
+	if err != nil {
+		sc.cmd.Usage()
+		return err
+	}
+
+	algo, err := application.ParseCallGraph(*sc.algoFlag)
+	if err != nil {
+		sc.cmd.Usage()
+		return err
+	}
+
+	layout, err := readLayout(*sc.layoutFlag, *sc.mainFlag)
+	if err != nil {
+		return err
+	}
+
 	if *sc.detailFlag {
-		return detailTacticGraph(*sc.mainFlag, *sc.pkgFlag)
+		return detailTacticGraph(*sc.mainFlag, *sc.pkgFlag, algo, layout, format, *sc.websiteFlag)
 	}
 
-	return tacticGraph(*sc.mainFlag, *sc.pkgFlag)
+	return tacticGraph(*sc.mainFlag, *sc.pkgFlag, algo, layout, format, *sc.websiteFlag)
 }
 
-func tacticGraph(mainPkg, domain string) error {
-	dot, err := application.TacticGraph(mainPkg, domain,
+func tacticGraph(mainPkg, domain string, algo application.CallGraph, layout []byte, format application.Format, website bool) error {
+	raw, err := application.TacticGraph(mainPkg, domain, algo, layout,
 		persistence.NewRadixTree(),
 		&persistence.Relations{},
+		codeCache(),
+		format,
 	)
 	if err != nil {
 		return err
 	}
 
-	if err = open(dot); err != nil {
-		return err
-	}
-	if err = writeToDisk(dot, filename(domain, "tactic"), mainPkg); err != nil {
-		return err
-	}
-	return nil
+	return present(raw, format, website, filename(domain, "tactic"), mainPkg)
 }
 
-func detailTacticGraph(mainPkg, domain string) error {
-	dot, err := application.DetailTacticGraph(mainPkg, domain,
+func detailTacticGraph(mainPkg, domain string, algo application.CallGraph, layout []byte, format application.Format, website bool) error {
+	raw, err := application.DetailTacticGraph(mainPkg, domain, algo, layout,
 		persistence.NewRadixTree(),
 		&persistence.Relations{},
+		codeCache(),
+		format,
 	)
 	if err != nil {
 		return err
 	}
 
-	if err = open(dot); err != nil {
-		return err
-	}
-	if err = writeToDisk(dot, filename(domain, "tactic.detail"), mainPkg); err != nil {
-		return err
-	}
-	return nil
+	return present(raw, format, website, filename(domain, "tactic.detail"), mainPkg)
 }
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/dddplayer/dp/internal/application"
+	"github.com/dddplayer/dp/internal/infrastructure/persistence"
+	"strings"
+	"time"
+)
+
+type watchCmd struct {
+	parent       *flag.FlagSet
+	cmd          *flag.FlagSet
+	mainFlag     *string
+	pkgFlag      *string
+	algoFlag     *string
+	intervalFlag *time.Duration
+}
+
+func NewWatchCmd(parent *flag.FlagSet) (*watchCmd, error) {
+	wCmd := &watchCmd{
+		parent: parent,
+	}
+
+	wCmd.cmd = flag.NewFlagSet("watch", flag.ExitOnError)
+	wCmd.mainFlag = wCmd.cmd.String("m", "", fmt.Sprintf(
+		"[required] main package path \n(e.g. %s)", "github.com/dddplayer/dp"))
+	wCmd.pkgFlag = wCmd.cmd.String("p", "", fmt.Sprintf(
+		"[required] target package path \n(e.g. %s)", "github.com/dddplayer/dp/internal/domain"))
+	wCmd.algoFlag = callGraphFlag(wCmd.cmd)
+	wCmd.intervalFlag = wCmd.cmd.Duration("interval", time.Second, "how often to look for changed files")
+
+	err := wCmd.cmd.Parse(parent.Args()[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return wCmd, nil
+}
+
+func (wc *watchCmd) Usage() {
+	wc.cmd.Usage()
+}
+
+func (wc *watchCmd) Run() error {
+	if *wc.mainFlag == "" {
+		wc.cmd.Usage()
+		return errors.New("please specify the main package")
+	}
+
+	if *wc.pkgFlag == "" {
+		wc.cmd.Usage()
+		return errors.New("please specify a target package full name")
+	}
+
+	algo, err := application.ParseCallGraph(*wc.algoFlag)
+	if err != nil {
+		wc.cmd.Usage()
+		return err
+	}
+
+	w := application.NewWatcher(*wc.mainFlag, *wc.pkgFlag, algo,
+		persistence.NewRadixTree(),
+		&persistence.Relations{},
+	)
+	raw, err := w.Build()
+	if err != nil {
+		return err
+	}
+	if err := wc.write(raw); err != nil {
+		return err
+	}
+	fmt.Println("watching", *wc.pkgFlag, "for changes")
+
+	for range time.Tick(*wc.intervalFlag) {
+		files := w.Changed()
+		if len(files) == 0 {
+			continue
+		}
+
+		raw, pkgs, err := w.Update(files)
+		if err != nil {
+			fmt.Println("watch error:", err)
+			continue
+		}
+		if pkgs == nil {
+			fmt.Println("rebuilt all packages")
+		} else {
+			fmt.Println("updated", strings.Join(pkgs, ", "))
+		}
+		if err := wc.write(raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (wc *watchCmd) write(raw string) error {
+	_, err := writeToDisk(raw, filename(*wc.pkgFlag, ""), formatExt[application.FormatDot], *wc.mainFlag)
+	return err
+}
@@ -0,0 +1,108 @@
+(function () {
+    const list = document.getElementById("diagrams");
+    const status = document.getElementById("status");
+    const title = document.getElementById("title");
+    const canvas = document.getElementById("canvas");
+    const source = document.getElementById("source");
+
+    let current = "";
+    let scale = 1;
+
+    function setStatus(text) {
+        status.textContent = text;
+    }
+
+    function applyScale() {
+        const svg = canvas.querySelector("svg");
+        if (svg) {
+            svg.style.transform = "scale(" + scale + ")";
+        }
+        document.getElementById("zoom-reset").textContent = Math.round(scale * 100) + "%";
+    }
+
+    async function loadList() {
+        const resp = await fetch("api/diagrams");
+        const diagrams = await resp.json();
+        list.innerHTML = "";
+        diagrams.forEach(function (d) {
+            const li = document.createElement("li");
+            li.textContent = d.name;
+            const info = document.createElement("small");
+            info.textContent = new Date(d.modTime).toLocaleString();
+            li.appendChild(info);
+            li.dataset.name = d.name;
+            if (d.name === current) {
+                li.classList.add("active");
+            }
+            li.addEventListener("click", function () {
+                show(d.name);
+            });
+            list.appendChild(li);
+        });
+        setStatus(diagrams.length + " diagram(s)");
+    }
+
+    async function show(name) {
+        current = name;
+        title.textContent = name;
+        Array.prototype.forEach.call(list.children, function (li) {
+            li.classList.toggle("active", li.dataset.name === name);
+        });
+
+        const raw = await fetch("api/diagrams/" + encodeURIComponent(name));
+        source.textContent = await raw.text();
+
+        const svg = await fetch("api/diagrams/" + encodeURIComponent(name) + "/svg");
+        if (svg.ok) {
+            canvas.innerHTML = await svg.text();
+            applyScale();
+        } else {
+            const msg = document.createElement("p");
+            msg.className = "notice";
+            msg.textContent = (await svg.text()) + " - showing DOT source instead.";
+            canvas.innerHTML = "";
+            canvas.appendChild(msg);
+            source.hidden = false;
+        }
+    }
+
+    document.getElementById("zoom-in").addEventListener("click", function () {
+        scale = Math.min(scale * 1.25, 8);
+        applyScale();
+    });
+    document.getElementById("zoom-out").addEventListener("click", function () {
+        scale = Math.max(scale / 1.25, 0.1);
+        applyScale();
+    });
+    document.getElementById("zoom-reset").addEventListener("click", function () {
+        scale = 1;
+        applyScale();
+    });
+    document.getElementById("toggle-source").addEventListener("click", function () {
+        source.hidden = !source.hidden;
+    });
+
+    const events = new EventSource("api/events");
+    events.addEventListener("change", function (e) {
+        const changed = JSON.parse(e.data);
+        loadList();
+        if (current && changed.indexOf(current) !== -1) {
+            show(current);
+        }
+    });
+    events.onerror = function () {
+        setStatus("disconnected, retrying...");
+    };
+
+    fetch("api/status").then(function (resp) {
+        return resp.json();
+    }).then(function (s) {
+        document.getElementById("renderer").hidden = s.canRender;
+    });
+
+    loadList().then(function () {
+        if (list.firstChild) {
+            show(list.firstChild.dataset.name);
+        }
+    });
+})();
@@ -0,0 +1,253 @@
+package viewer
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+//go:embed assets
+var assets embed.FS
+
+const (
+	diagramExt   = ".dot"
+	pollInterval = time.Second
+)
+
+type Diagram struct {
+	Name    string    `json:"name"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+}
+
+type Server struct {
+	root     string
+	renderer string
+}
+
+func NewServer(root string) (*Server, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", root)
+	}
+
+	s := &Server{root: root}
+	if p, err := exec.LookPath("dot"); err == nil {
+		s.renderer = p
+	}
+	return s, nil
+}
+
+func (s *Server) Root() string {
+	return s.root
+}
+
+func (s *Server) CanRender() bool {
+	return s.renderer != ""
+}
+
+func (s *Server) Handler() (http.Handler, error) {
+	static, err := fs.Sub(assets, "assets")
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(http.FS(static)))
+	mux.HandleFunc("/api/status", s.handleStatus)
+	mux.HandleFunc("/api/diagrams", s.handleList)
+	mux.HandleFunc("/api/diagrams/", s.handleDiagram)
+	mux.HandleFunc("/api/events", s.handleEvents)
+	return mux, nil
+}
+
+func (s *Server) ListenAndServe(addr string) error {
+	h, err := s.Handler()
+	if err != nil {
+		return err
+	}
+	return http.ListenAndServe(addr, h)
+}
+
+func (s *Server) Diagrams() ([]Diagram, error) {
+	entries, err := os.ReadDir(s.root)
+	if err != nil {
+		return nil, err
+	}
+
+	var ds []Diagram
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != diagramExt {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		ds = append(ds, Diagram{
+			Name:    e.Name(),
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+		})
+	}
+	sort.Slice(ds, func(i, j int) bool {
+		return ds[i].Name < ds[j].Name
+	})
+	return ds, nil
+}
+
+type Status struct {
+	CanRender bool `json:"canRender"`
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(Status{CanRender: s.CanRender()})
+}
+
+func (s *Server) handleList(w http.ResponseWriter, _ *http.Request) {
+	ds, err := s.Diagrams()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if ds == nil {
+		ds = []Diagram{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(ds)
+}
+
+func (s *Server) handleDiagram(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/api/diagrams/")
+	svg := strings.HasSuffix(name, "/svg")
+	name = strings.TrimSuffix(name, "/svg")
+
+	file, err := s.diagramPath(name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	if !svg {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write(raw)
+		return
+	}
+
+	if !s.CanRender() {
+		http.Error(w, "graphviz dot is not installed", http.StatusNotImplemented)
+		return
+	}
+	out, err := s.render(raw)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	w.Header().Set("Content-Type", "image/svg+xml")
+	_, _ = w.Write(out)
+}
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	last := s.snapshot()
+	// send the headers right away, the client is connected before the first change
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			current := s.snapshot()
+			changed := changedDiagrams(last, current)
+			if len(changed) == 0 {
+				continue
+			}
+			last = current
+			data, err := json.Marshal(changed)
+			if err != nil {
+				continue
+			}
+			_, _ = fmt.Fprintf(w, "event: change\ndata: %s\n\n", data)
+			flusher.Flush()
+		}
+	}
+}
+
+func (s *Server) snapshot() map[string]Diagram {
+	snap := make(map[string]Diagram)
+	ds, err := s.Diagrams()
+	if err != nil {
+		return snap
+	}
+	for _, d := range ds {
+		snap[d.Name] = d
+	}
+	return snap
+}
+
+func changedDiagrams(last, current map[string]Diagram) []string {
+	var changed []string
+	for name, d := range current {
+		if old, ok := last[name]; !ok || !old.ModTime.Equal(d.ModTime) || old.Size != d.Size {
+			changed = append(changed, name)
+		}
+	}
+	for name := range last {
+		if _, ok := current[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func (s *Server) diagramPath(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || filepath.Ext(name) != diagramExt {
+		return "", errors.New("invalid diagram name")
+	}
+	return filepath.Join(s.root, name), nil
+}
+
+func (s *Server) render(raw []byte) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(s.renderer, "-Tsvg")
+	cmd.Stdin = bytes.NewReader(raw)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("dot: %s", strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
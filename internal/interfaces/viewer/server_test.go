@@ -0,0 +1,196 @@
+package viewer
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestServer(t *testing.T) (*Server, http.Handler) {
+	parent := t.TempDir()
+	root := filepath.Join(parent, "dddplayer")
+	if err := os.Mkdir(root, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		filepath.Join(root, "strategic.dot"): "digraph { a -> b }",
+		filepath.Join(root, "notes.txt"):     "not a diagram",
+		filepath.Join(parent, "secret.dot"):  "digraph { secret }",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s, err := NewServer(root)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	s.renderer = ""
+	h, err := s.Handler()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return s, h
+}
+
+func TestNewServer(t *testing.T) {
+	if _, err := NewServer(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Expected an error for a missing directory, but got nil")
+	}
+
+	file := filepath.Join(t.TempDir(), "file.dot")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewServer(file); err == nil {
+		t.Error("Expected an error for a file, but got nil")
+	}
+}
+
+func TestServer_HandleList(t *testing.T) {
+	_, h := newTestServer(t)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/diagrams", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, but got %d", http.StatusOK, rec.Code)
+	}
+
+	var ds []Diagram
+	if err := json.NewDecoder(rec.Body).Decode(&ds); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(ds) != 1 || ds[0].Name != "strategic.dot" {
+		t.Errorf("Expected only strategic.dot to be listed, but got %+v", ds)
+	}
+}
+
+func TestServer_HandleStatus(t *testing.T) {
+	_, h := newTestServer(t)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/status", nil))
+
+	var status Status
+	if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status.CanRender {
+		t.Error("Expected the status to report that diagrams cannot be rendered without graphviz")
+	}
+}
+
+func TestServer_HandleDiagram(t *testing.T) {
+	s, h := newTestServer(t)
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/api/diagrams/strategic.dot", http.StatusOK, "digraph { a -> b }"},
+		{"/api/diagrams/missing.dot", http.StatusNotFound, ""},
+		{"/api/diagrams/notes.txt", http.StatusBadRequest, "invalid diagram name"},
+		{"/api/diagrams/..%2Fsecret.dot", http.StatusBadRequest, "invalid diagram name"},
+		{"/api/diagrams/..%2Fdddplayer%2Fstrategic.dot", http.StatusBadRequest, "invalid diagram name"},
+		{"/api/diagrams/strategic.dot/svg", http.StatusNotImplemented, "graphviz dot is not installed"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		// call the handler directly, the mux would redirect a cleaned path before it is checked
+		s.handleDiagram(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if rec.Code != tt.status {
+			t.Errorf("Expected status %d for %s, but got %d", tt.status, tt.path, rec.Code)
+		}
+		if !strings.Contains(rec.Body.String(), tt.body) {
+			t.Errorf("Expected %q in the response for %s, but got %q", tt.body, tt.path, rec.Body.String())
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/diagrams/../secret.dot", nil))
+	if rec.Code != http.StatusMovedPermanently || rec.Header().Get("Location") != "/api/secret.dot" {
+		t.Errorf("Expected the mux to redirect to the cleaned path, but got %d %s", rec.Code, rec.Header().Get("Location"))
+	}
+}
+
+func TestServer_DiagramPath(t *testing.T) {
+	s := &Server{root: "/tmp/dddplayer"}
+
+	if p, err := s.diagramPath("strategic.dot"); err != nil || p != filepath.Join("/tmp/dddplayer", "strategic.dot") {
+		t.Errorf("Expected the diagram in the root, but got %s, %v", p, err)
+	}
+	for _, name := range []string{"", "../secret.dot", "../../etc/passwd", "sub/strategic.dot", "/etc/secret.dot", "notes.txt"} {
+		if _, err := s.diagramPath(name); err == nil {
+			t.Errorf("Expected %q to be rejected, but got nil", name)
+		}
+	}
+}
+
+func TestServer_HandleEvents(t *testing.T) {
+	s, h := newTestServer(t)
+	ts := httptest.NewServer(h)
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/api/events", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Expected an event stream, but got %s", ct)
+	}
+
+	if err := os.WriteFile(filepath.Join(s.Root(), "tactic.dot"), []byte("digraph {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() && len(lines) < 2 {
+		if line := scanner.Text(); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	expected := []string{"event: change", `data: ["tactic.dot"]`}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected the reload event %v, but got %v", expected, lines)
+	}
+}
+
+func TestChangedDiagrams(t *testing.T) {
+	now := time.Now()
+	last := map[string]Diagram{
+		"kept.dot":    {Name: "kept.dot", Size: 1, ModTime: now},
+		"resized.dot": {Name: "resized.dot", Size: 1, ModTime: now},
+		"touched.dot": {Name: "touched.dot", Size: 1, ModTime: now},
+		"removed.dot": {Name: "removed.dot", Size: 1, ModTime: now},
+	}
+	current := map[string]Diagram{
+		"kept.dot":    {Name: "kept.dot", Size: 1, ModTime: now},
+		"resized.dot": {Name: "resized.dot", Size: 2, ModTime: now},
+		"touched.dot": {Name: "touched.dot", Size: 1, ModTime: now.Add(time.Second)},
+		"added.dot":   {Name: "added.dot", Size: 1, ModTime: now},
+	}
+
+	changed := changedDiagrams(last, current)
+	expected := "added.dot,removed.dot,resized.dot,touched.dot"
+	if strings.Join(changed, ",") != expected {
+		t.Errorf("Expected %s to change, but got %v", expected, changed)
+	}
+}
@@ -88,6 +88,37 @@ func (g *Graph) AddEdge(from string, to string, t any, value any) error {
 }
 
 func (g *Graph) FindPathsToPrefix(startKey, endKeyPrefix string) [][]*Node {
+	return g.FindPaths(startKey, func(n *Node) bool {
+		return strings.HasPrefix(n.Key, endKeyPrefix)
+	}, PathLimits{})
+}
+
+// PathLimits bounds a path search, a zero value is no limit.
+type PathLimits struct {
+	// MaxDepth is the maximum number of edges of a path.
+	MaxDepth int
+	// MaxPaths is the maximum number of paths returned.
+	MaxPaths int
+	// MaxSteps is the maximum number of nodes a search expands, which bounds it
+	// even when no path is found.
+	MaxSteps int
+}
+
+func (l PathLimits) deeper(path []*Node) bool {
+	return l.MaxDepth <= 0 || len(path) <= l.MaxDepth
+}
+
+func (l PathLimits) full(paths [][]*Node) bool {
+	return l.MaxPaths > 0 && len(paths) >= l.MaxPaths
+}
+
+func (l PathLimits) exhausted(steps int) bool {
+	return l.MaxSteps > 0 && steps >= l.MaxSteps
+}
+
+// FindPaths returns the paths without repeated nodes from the start node to a node isEnd accepts,
+// a path stops at the first such node.
+func (g *Graph) FindPaths(startKey string, isEnd func(n *Node) bool, limits PathLimits) [][]*Node {
 	startNode := g.FindNodeByKey(startKey)
 
 	if startNode == nil {
@@ -98,25 +129,332 @@ func (g *Graph) FindPathsToPrefix(startKey, endKeyPrefix string) [][]*Node {
 	currentPath := []*Node{startNode}
 
 	visited := make(map[*Node]bool)
-	g.findAllPathsToPrefix(startNode, endKeyPrefix, &paths, currentPath, visited)
+	g.findAllPaths(startNode, isEnd, limits, &paths, currentPath, visited, new(int))
 
 	return paths
 }
 
 func (g *Graph) findAllPathsToPrefix(node *Node, endKeyPrefix string, paths *[][]*Node, currentPath []*Node, visited map[*Node]bool) {
-	if strings.HasPrefix(node.Key, endKeyPrefix) {
+	g.findAllPaths(node, func(n *Node) bool {
+		return strings.HasPrefix(n.Key, endKeyPrefix)
+	}, PathLimits{}, paths, currentPath, visited, new(int))
+}
+
+func (g *Graph) findAllPaths(node *Node, isEnd func(n *Node) bool, limits PathLimits,
+	paths *[][]*Node, currentPath []*Node, visited map[*Node]bool, steps *int) {
+	if isEnd(node) {
 		// 找到一条路径，将其添加到结果中
 		*paths = append(*paths, append([]*Node(nil), currentPath...))
 		return
 	}
+	if !limits.deeper(currentPath) || limits.exhausted(*steps) {
+		return
+	}
+	*steps++
 
 	visited[node] = true
 	for _, edge := range node.Edges {
+		if limits.full(*paths) {
+			break
+		}
 		nextNode := edge.To
 		if !visited[nextNode] {
 			// 递归探索下一个节点
-			g.findAllPathsToPrefix(nextNode, endKeyPrefix, paths, append(currentPath, nextNode), visited)
+			g.findAllPaths(nextNode, isEnd, limits, paths, append(currentPath, nextNode), visited, steps)
 		}
 	}
 	visited[node] = false
 }
+
+// ShortestPaths returns the MaxPaths shortest paths, at least one, without repeated nodes from the
+// start node to a node isEnd accepts, shorter paths first and paths of equal length in edge order.
+// The paths are found with Yen's algorithm, each one deviating from a shorter path found before.
+func (g *Graph) ShortestPaths(startKey string, isEnd func(n *Node) bool, limits PathLimits) [][]*Node {
+	startNode := g.FindNodeByKey(startKey)
+	if startNode == nil {
+		return nil
+	}
+
+	k := limits.MaxPaths
+	if k <= 0 {
+		k = 1
+	}
+
+	first := shortestPath(startNode, isEnd, nil, nil)
+	if first == nil || !limits.deeper(first[:len(first)-1]) {
+		return nil
+	}
+
+	paths := [][]*Node{first}
+	var candidates [][]*Node
+	for len(paths) < k {
+		prev := paths[len(paths)-1]
+		for i := 0; i < len(prev)-1; i++ {
+			root := prev[:i+1]
+
+			blockedEdges := make(map[[2]*Node]bool)
+			for _, p := range paths {
+				if len(p) > i+1 && samePath(p[:i+1], root) {
+					blockedEdges[[2]*Node{p[i], p[i+1]}] = true
+				}
+			}
+			blockedNodes := make(map[*Node]bool)
+			for _, n := range root[:i] {
+				blockedNodes[n] = true
+			}
+
+			spur := shortestPath(prev[i], isEnd, blockedNodes, blockedEdges)
+			if spur == nil {
+				continue
+			}
+			p := append(append([]*Node(nil), root[:i]...), spur...)
+			if !limits.deeper(p[:len(p)-1]) || containsPath(candidates, p) {
+				continue
+			}
+			candidates = append(candidates, p)
+		}
+		if len(candidates) == 0 {
+			break
+		}
+
+		best := 0
+		for i, c := range candidates {
+			if shorterPath(c, candidates[best]) {
+				best = i
+			}
+		}
+		paths = append(paths, candidates[best])
+		candidates = append(candidates[:best], candidates[best+1:]...)
+	}
+
+	return paths
+}
+
+// shortestPath returns the path with the fewest edges from the start node to a node isEnd accepts,
+// the first in edge order among equally short ones, avoiding the blocked nodes and edges.
+func shortestPath(start *Node, isEnd func(n *Node) bool, blockedNodes map[*Node]bool,
+	blockedEdges map[[2]*Node]bool) []*Node {
+
+	parent := map[*Node]*Node{start: nil}
+	queue := []*Node{start}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+
+		if isEnd(n) {
+			var p []*Node
+			for ; n != nil; n = parent[n] {
+				p = append([]*Node{n}, p...)
+			}
+			return p
+		}
+
+		for _, e := range n.Edges {
+			if _, ok := parent[e.To]; ok || blockedNodes[e.To] || blockedEdges[[2]*Node{n, e.To}] {
+				continue
+			}
+			parent[e.To] = n
+			queue = append(queue, e.To)
+		}
+	}
+
+	return nil
+}
+
+// shorterPath reports whether p has fewer edges than q, or as many and comes first in edge order.
+func shorterPath(p, q []*Node) bool {
+	if len(p) != len(q) {
+		return len(p) < len(q)
+	}
+	for i := 1; i < len(p); i++ {
+		if pi, qi := edgeIndex(p[i-1], p[i]), edgeIndex(q[i-1], q[i]); pi != qi {
+			return pi < qi
+		}
+	}
+	return false
+}
+
+func edgeIndex(from, to *Node) int {
+	for i, e := range from.Edges {
+		if e.To == to {
+			return i
+		}
+	}
+	return -1
+}
+
+func samePath(p, q []*Node) bool {
+	if len(p) != len(q) {
+		return false
+	}
+	for i := range p {
+		if p[i] != q[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func containsPath(paths [][]*Node, p []*Node) bool {
+	for _, q := range paths {
+		if samePath(p, q) {
+			return true
+		}
+	}
+	return false
+}
+
+// Reachable returns the nodes and edges lying on a walk of at most maxDepth edges, no limit when
+// it is zero, from the start node to a node isEnd accepts. Unlike the paths, a walk may pass a node
+// twice, so nodes on a cycle through the start are kept. It runs in linear time, walking the graph
+// once forward from the start and once backward from the ends.
+func (g *Graph) Reachable(startKey string, isEnd func(n *Node) bool, maxDepth int) ([]*Node, []*Edge) {
+	startNode := g.FindNodeByKey(startKey)
+	if startNode == nil {
+		return nil, nil
+	}
+
+	forward := map[*Node]int{startNode: 0}
+	var ends []*Node
+	queue := []*Node{startNode}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if isEnd(n) {
+			ends = append(ends, n)
+			continue
+		}
+		for _, e := range n.Edges {
+			if _, ok := forward[e.To]; !ok {
+				forward[e.To] = forward[n] + 1
+				queue = append(queue, e.To)
+			}
+		}
+	}
+
+	incoming := make(map[*Node][]*Edge)
+	for n := range forward {
+		if isEnd(n) {
+			continue
+		}
+		for _, e := range n.Edges {
+			incoming[e.To] = append(incoming[e.To], e)
+		}
+	}
+
+	backward := make(map[*Node]int)
+	for _, n := range ends {
+		backward[n] = 0
+	}
+	queue = append(queue, ends...)
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for _, e := range incoming[n] {
+			if _, ok := backward[e.From]; !ok {
+				backward[e.From] = backward[n] + 1
+				queue = append(queue, e.From)
+			}
+		}
+	}
+
+	within := func(length int) bool {
+		return maxDepth <= 0 || length <= maxDepth
+	}
+
+	var nodes []*Node
+	var edges []*Edge
+	for _, n := range g.Nodes {
+		f, ok := forward[n]
+		b, ok2 := backward[n]
+		if !ok || !ok2 || !within(f+b) {
+			continue
+		}
+		nodes = append(nodes, n)
+		if isEnd(n) {
+			continue
+		}
+		for _, e := range n.Edges {
+			if b, ok := backward[e.To]; ok && within(f+1+b) {
+				edges = append(edges, e)
+			}
+		}
+	}
+
+	return nodes, edges
+}
+
+func (g *Graph) StronglyConnectedComponents() [][]*Node {
+	t := &tarjan{
+		index:   make(map[*Node]int),
+		lowLink: make(map[*Node]int),
+		onStack: make(map[*Node]bool),
+	}
+
+	for _, n := range g.Nodes {
+		if _, ok := t.index[n]; !ok {
+			t.strongConnect(n)
+		}
+	}
+
+	return t.components
+}
+
+func (g *Graph) Cycles() [][]*Node {
+	var cycles [][]*Node
+	for _, c := range g.StronglyConnectedComponents() {
+		if len(c) > 1 || hasSelfLoop(c[0]) {
+			cycles = append(cycles, c)
+		}
+	}
+	return cycles
+}
+
+func hasSelfLoop(n *Node) bool {
+	for _, e := range n.Edges {
+		if e.To == n {
+			return true
+		}
+	}
+	return false
+}
+
+type tarjan struct {
+	counter    int
+	index      map[*Node]int
+	lowLink    map[*Node]int
+	onStack    map[*Node]bool
+	stack      []*Node
+	components [][]*Node
+}
+
+func (t *tarjan) strongConnect(n *Node) {
+	t.index[n] = t.counter
+	t.lowLink[n] = t.counter
+	t.counter++
+	t.stack = append(t.stack, n)
+	t.onStack[n] = true
+
+	for _, e := range n.Edges {
+		if _, ok := t.index[e.To]; !ok {
+			t.strongConnect(e.To)
+			t.lowLink[n] = min(t.lowLink[n], t.lowLink[e.To])
+		} else if t.onStack[e.To] {
+			t.lowLink[n] = min(t.lowLink[n], t.index[e.To])
+		}
+	}
+
+	if t.lowLink[n] == t.index[n] {
+		var component []*Node
+		for {
+			last := t.stack[len(t.stack)-1]
+			t.stack = t.stack[:len(t.stack)-1]
+			t.onStack[last] = false
+			component = append([]*Node{last}, component...)
+			if last == n {
+				break
+			}
+		}
+		t.components = append(t.components, component)
+	}
+}
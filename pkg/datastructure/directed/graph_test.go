@@ -3,6 +3,7 @@ package directed
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -233,6 +234,188 @@ func TestGraph_FindPathsToPrefix(t *testing.T) {
 	}
 }
 
+func TestGraph_FindPaths(t *testing.T) {
+	graph := NewDirectedGraph()
+
+	for _, k := range []string{"A", "B", "C", "D1", "D2"} {
+		_ = graph.AddNode(k, nil)
+	}
+	_ = graph.AddEdge("A", "B", nil, nil)
+	_ = graph.AddEdge("A", "C", nil, nil)
+	_ = graph.AddEdge("B", "D1", nil, nil)
+	_ = graph.AddEdge("C", "D2", nil, nil)
+
+	paths := graph.FindPaths("A", func(n *Node) bool { return n.Key == "D2" }, PathLimits{})
+	if len(paths) != 1 {
+		t.Fatalf("Expected 1 path, but got %d", len(paths))
+	}
+	var keys []string
+	for _, n := range paths[0] {
+		keys = append(keys, n.Key)
+	}
+	if strings.Join(keys, "->") != "A->C->D2" {
+		t.Errorf("Expected path A->C->D2, but got %s", strings.Join(keys, "->"))
+	}
+
+	if paths := graph.FindPaths("X", func(n *Node) bool { return true }, PathLimits{}); paths != nil {
+		t.Errorf("Expected no path from a missing node, but got %v", paths)
+	}
+}
+
+// newLadderGraph links A to E through B1 or B2, then C1 or C2, then D, and A to E directly.
+func newLadderGraph() *Graph {
+	graph := NewDirectedGraph()
+	for _, k := range []string{"A", "B1", "B2", "C1", "C2", "D", "E", "X"} {
+		_ = graph.AddNode(k, nil)
+	}
+	for _, e := range [][2]string{
+		{"A", "B1"}, {"A", "B2"}, {"B1", "C1"}, {"B1", "C2"}, {"B2", "C1"}, {"B2", "C2"},
+		{"C1", "D"}, {"C2", "D"}, {"D", "E"}, {"A", "X"}, {"X", "A"}, {"A", "E"},
+	} {
+		_ = graph.AddEdge(e[0], e[1], nil, nil)
+	}
+	return graph
+}
+
+func pathKeys(paths [][]*Node) []string {
+	var keys []string
+	for _, p := range paths {
+		var ks []string
+		for _, n := range p {
+			ks = append(ks, n.Key)
+		}
+		keys = append(keys, strings.Join(ks, "->"))
+	}
+	return keys
+}
+
+func isE(n *Node) bool { return n.Key == "E" }
+
+func TestGraph_FindPathsWithLimits(t *testing.T) {
+	graph := newLadderGraph()
+
+	if paths := graph.FindPaths("A", isE, PathLimits{}); len(paths) != 5 {
+		t.Errorf("Expected 5 paths, but got %v", pathKeys(paths))
+	}
+
+	paths := graph.FindPaths("A", isE, PathLimits{MaxDepth: 3})
+	if !reflect.DeepEqual(pathKeys(paths), []string{"A->E"}) {
+		t.Errorf("Expected only A->E within 3 edges, but got %v", pathKeys(paths))
+	}
+
+	if paths := graph.FindPaths("A", isE, PathLimits{MaxPaths: 2}); len(paths) != 2 {
+		t.Errorf("Expected 2 paths, but got %v", pathKeys(paths))
+	}
+}
+
+func TestGraph_FindPathsMaxSteps(t *testing.T) {
+	graph := NewDirectedGraph()
+	keys := []string{"A", "B", "C", "D", "F", "G", "H", "I", "J", "K", "E"}
+	for _, k := range keys {
+		_ = graph.AddNode(k, nil)
+	}
+	for _, from := range keys[:len(keys)-1] {
+		for _, to := range keys[:len(keys)-1] {
+			if from != to {
+				_ = graph.AddEdge(from, to, nil, nil)
+			}
+		}
+	}
+
+	checked := 0
+	paths := graph.FindPaths("A", func(n *Node) bool {
+		checked++
+		return isE(n)
+	}, PathLimits{MaxSteps: 100})
+	if paths != nil {
+		t.Errorf("Expected no path to the unreachable E, but got %v", pathKeys(paths))
+	}
+	if checked > 100*len(keys) {
+		t.Errorf("Expected the search to stop after 100 steps, but it checked %d nodes", checked)
+	}
+
+	_ = graph.AddEdge("K", "E", nil, nil)
+	if paths := graph.FindPaths("A", isE, PathLimits{MaxSteps: 3}); paths != nil {
+		t.Errorf("Expected no path within 3 steps, but got %v", pathKeys(paths))
+	}
+	if paths := graph.FindPaths("A", isE, PathLimits{MaxSteps: 10, MaxPaths: 1}); len(paths) != 1 {
+		t.Errorf("Expected the path through every node within 10 steps, but got %v", pathKeys(paths))
+	}
+}
+
+func TestGraph_ShortestPaths(t *testing.T) {
+	graph := newLadderGraph()
+
+	paths := graph.ShortestPaths("A", isE, PathLimits{})
+	if !reflect.DeepEqual(pathKeys(paths), []string{"A->E"}) {
+		t.Errorf("Expected the shortest path A->E, but got %v", pathKeys(paths))
+	}
+
+	paths = graph.ShortestPaths("A", isE, PathLimits{MaxPaths: 3})
+	expected := []string{"A->E", "A->B1->C1->D->E", "A->B1->C2->D->E"}
+	if !reflect.DeepEqual(pathKeys(paths), expected) {
+		t.Errorf("Expected %v, but got %v", expected, pathKeys(paths))
+	}
+
+	graph = newLadderGraph()
+	graph.Nodes[0].Edges = graph.Nodes[0].Edges[:len(graph.Nodes[0].Edges)-1]
+	if paths := graph.ShortestPaths("A", isE, PathLimits{MaxDepth: 3}); paths != nil {
+		t.Errorf("Expected no path within 3 edges, but got %v", pathKeys(paths))
+	}
+	if paths := graph.ShortestPaths("Y", isE, PathLimits{}); paths != nil {
+		t.Errorf("Expected no path from a missing node, but got %v", pathKeys(paths))
+	}
+}
+
+func TestGraph_ShortestPathsDeviation(t *testing.T) {
+	graph := NewDirectedGraph()
+	for _, k := range []string{"A", "X", "M", "G", "N", "D", "E"} {
+		_ = graph.AddNode(k, nil)
+	}
+	for _, e := range [][2]string{
+		{"A", "X"}, {"A", "M"}, {"X", "E"}, {"X", "D"}, {"X", "G"},
+		{"M", "N"}, {"G", "D"}, {"N", "D"}, {"D", "X"},
+	} {
+		_ = graph.AddEdge(e[0], e[1], nil, nil)
+	}
+
+	// D is reached twice through X before the only other path passes it
+	paths := graph.ShortestPaths("A", isE, PathLimits{MaxPaths: 2})
+	expected := []string{"A->X->E", "A->M->N->D->X->E"}
+	if !reflect.DeepEqual(pathKeys(paths), expected) {
+		t.Errorf("Expected %v, but got %v", expected, pathKeys(paths))
+	}
+}
+
+func TestGraph_Reachable(t *testing.T) {
+	graph := newLadderGraph()
+
+	nodes, edges := graph.Reachable("A", isE, 0)
+	var keys []string
+	for _, n := range nodes {
+		keys = append(keys, n.Key)
+	}
+	if !reflect.DeepEqual(keys, []string{"A", "B1", "B2", "C1", "C2", "D", "E", "X"}) {
+		t.Errorf("Unexpected reachable nodes %v", keys)
+	}
+	if len(edges) != 12 {
+		t.Errorf("Expected 12 edges, but got %d", len(edges))
+	}
+
+	nodes, edges = graph.Reachable("A", isE, 3)
+	keys = nil
+	for _, n := range nodes {
+		keys = append(keys, n.Key)
+	}
+	if !reflect.DeepEqual(keys, []string{"A", "E", "X"}) || len(edges) != 3 {
+		t.Errorf("Expected A, E, X and 3 edges within 3 edges, but got %v and %d edges", keys, len(edges))
+	}
+
+	if nodes, edges := graph.Reachable("Y", isE, 0); nodes != nil || edges != nil {
+		t.Error("Expected nothing from a missing node")
+	}
+}
+
 func TestFindAllPathsToPrefix(t *testing.T) {
 	graph := NewDirectedGraph()
 
@@ -272,3 +455,53 @@ func TestFindAllPathsToPrefix(t *testing.T) {
 		t.Errorf("No paths found to keys with prefix %s.", endKeyPrefix)
 	}
 }
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	graph := NewDirectedGraph()
+
+	for _, k := range []string{"A", "B", "C", "D", "E", "F"} {
+		_ = graph.AddNode(k, nil)
+	}
+
+	_ = graph.AddEdge("A", "B", nil, nil)
+	_ = graph.AddEdge("B", "C", nil, nil)
+	_ = graph.AddEdge("C", "A", nil, nil)
+	_ = graph.AddEdge("C", "D", nil, nil)
+	_ = graph.AddEdge("D", "E", nil, nil)
+	_ = graph.AddEdge("F", "F", nil, nil)
+
+	sccs := graph.StronglyConnectedComponents()
+	if len(sccs) != 4 {
+		t.Fatalf("Expected 4 components, but got %d", len(sccs))
+	}
+
+	var keys []string
+	for _, n := range sccs[2] {
+		keys = append(keys, n.Key)
+	}
+	if strings.Join(keys, ",") != "A,B,C" {
+		t.Errorf("Expected component A,B,C, but got %v", keys)
+	}
+
+	cycles := graph.Cycles()
+	if len(cycles) != 2 {
+		t.Fatalf("Expected 2 cycles, but got %d", len(cycles))
+	}
+	if len(cycles[0]) != 3 {
+		t.Errorf("Expected first cycle with 3 nodes, but got %d", len(cycles[0]))
+	}
+	if len(cycles[1]) != 1 || cycles[1][0].Key != "F" {
+		t.Errorf("Expected self loop cycle F, but got %v", cycles[1])
+	}
+}
+
+func TestCycles_Empty(t *testing.T) {
+	graph := NewDirectedGraph()
+	_ = graph.AddNode("A", nil)
+	_ = graph.AddNode("B", nil)
+	_ = graph.AddEdge("A", "B", nil, nil)
+
+	if cycles := graph.Cycles(); len(cycles) != 0 {
+		t.Errorf("Expected no cycles, but got %d", len(cycles))
+	}
+}
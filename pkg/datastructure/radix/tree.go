@@ -138,6 +138,51 @@ func (t *Tree) Insert(k string, v interface{}) bool {
 	return false
 }
 
+// Delete removes the value of k from radix tree, merging the nodes left without a value
+func (t *Tree) Delete(k string) bool {
+	n := t.root
+	if n == nil || len(k) == 0 {
+		return false
+	}
+
+	path := k
+	for len(path) > 0 {
+		e := n.getEdge(path)
+		if e == nil || e.name == "" || !strings.HasPrefix(path, e.name) {
+			return false
+		}
+		path = path[len(e.name):]
+		n = e.end
+	}
+	if n.val == nil {
+		return false
+	}
+
+	n.val = nil
+	n.compact()
+	return true
+}
+
+// compact removes a node without value and children, and joins a node without value
+// into its only child, so the tree stays the same as if the deleted key was never inserted
+func (n *node) compact() {
+	for n.prefix != nil && n.val == nil {
+		parent := n.prefix.start
+		switch len(n.suffixes) {
+		case 0:
+			parent.delEdge(n.prefix)
+			n = parent
+			continue
+		case 1:
+			child := n.suffixes[0]
+			child.name = n.prefix.name + child.name
+			parent.delEdge(n.prefix)
+			parent.addEdge(child)
+		}
+		return
+	}
+}
+
 func newNodeEdge() *edge {
 	e := &edge{
 		name:  "",
@@ -279,6 +279,49 @@ func TestTree_Get(t *testing.T) {
 	}
 }
 
+func TestTree_Delete(t *testing.T) {
+	tree := NewTree()
+	tree.Insert("abc", 1)
+	tree.Insert("abx", 2)
+	tree.Insert("abcd", 3)
+
+	if tree.Delete("ab") {
+		t.Errorf("Expected deleting a key without value to fail")
+	}
+	if tree.Delete("foo") {
+		t.Errorf("Expected deleting a missing key to fail")
+	}
+
+	if !tree.Delete("abc") {
+		t.Fatalf("Expected abc to be deleted")
+	}
+	if v, _ := tree.Get("abc"); v != nil {
+		t.Errorf("Expected abc to be gone, but got %v", v)
+	}
+	if v, ok := tree.Get("abcd"); v != 3 || !ok {
+		t.Errorf("Expected (3, true), but got (%v, %v)", v, ok)
+	}
+
+	if !tree.Delete("abx") {
+		t.Fatalf("Expected abx to be deleted")
+	}
+	if len(tree.root.suffixes) != 1 || tree.root.suffixes[0].name != "abcd" {
+		t.Errorf("Expected the remaining nodes to be merged into abcd, but got %v", tree.root.suffixes)
+	}
+
+	if !tree.Delete("abcd") {
+		t.Fatalf("Expected abcd to be deleted")
+	}
+	if len(tree.root.suffixes) != 0 {
+		t.Errorf("Expected an empty tree, but got %d edges", len(tree.root.suffixes))
+	}
+
+	tree.Insert("abc", 4)
+	if v, ok := tree.Get("abc"); v != 4 || !ok {
+		t.Errorf("Expected (4, true), but got (%v, %v)", v, ok)
+	}
+}
+
 func TestDelEdge(t *testing.T) {
 	rootNode := &node{}
 	edge1 := &edge{name: "Edge1", start: rootNode, end: nil}
@@ -0,0 +1,167 @@
+// Package dp analyses the architecture of a Go module from other Go programs,
+// returning the same diagrams as the dp command together with the exported model.
+package dp
+
+import (
+	"context"
+	"errors"
+	"github.com/dddplayer/dp/internal/application"
+	"github.com/dddplayer/dp/internal/domain/arch"
+	"github.com/dddplayer/dp/internal/domain/arch/repository"
+	"github.com/dddplayer/dp/internal/domain/arch/valueobject"
+	exportEntity "github.com/dddplayer/dp/internal/domain/export/entity"
+	"github.com/dddplayer/dp/internal/infrastructure/persistence"
+)
+
+type (
+	Diagram      = arch.Diagram
+	SubDiagram   = arch.SubDiagram
+	Node         = arch.Node
+	Element      = arch.Element
+	Edge         = arch.Edge
+	RelationType = arch.RelationType
+	Model        = exportEntity.Model
+	CallGraph    = application.CallGraph
+	Format       = application.Format
+	FlowSearch   = valueobject.FlowSearch
+	FlowMode     = valueobject.FlowMode
+)
+
+// ObjectRepository and RelationRepository store the analysed code, the objects and
+// relations they receive are described by the types below.
+type (
+	ObjectRepository   = repository.ObjectRepository
+	RelationRepository = repository.RelationRepository
+
+	Object                 = arch.Object
+	ObjIdentifier          = arch.ObjIdentifier
+	Identifier             = arch.Identifier
+	Position               = arch.Position
+	Relation               = arch.Relation
+	DependenceRelation     = arch.DependenceRelation
+	CompositionRelation    = arch.CompositionRelation
+	EmbeddingRelation      = arch.EmbeddingRelation
+	ImplementationRelation = arch.ImplementationRelation
+	AssociationRelation    = arch.AssociationRelation
+)
+
+const (
+	CallGraphStatic  = application.CallGraphStatic
+	CallGraphCha     = application.CallGraphCha
+	CallGraphRta     = application.CallGraphRta
+	CallGraphPointer = application.CallGraphPointer
+
+	FormatDot      = application.FormatDot
+	FormatMermaid  = application.FormatMermaid
+	FormatPlantUML = application.FormatPlantUML
+
+	FlowModeAll      = valueobject.FlowModeAll
+	FlowModeShortest = valueobject.FlowModeShortest
+	FlowModeReach    = valueobject.FlowModeReach
+)
+
+type Options struct {
+	// MainPkgPath is the main package directory of the module, e.g. ~/github/dddplayer/dp.
+	MainPkgPath string
+	// Domain is the package path analysed, e.g. github.com/dddplayer/dp/internal/domain.
+	Domain string
+	// CallGraph is the call graph algorithm, static when empty.
+	CallGraph CallGraph
+	// Layout is the content of a layout file mapping a non-standard structure to the
+	// hexagon layers, used by the strategic and tactic diagrams.
+	Layout []byte
+	// Detail shows all relations, Composition shows struct composition relations,
+	// both in the tactic and general diagrams.
+	Detail      bool
+	Composition bool
+	// Starts are the functions message flows are traced from, fully qualified and glob
+	// patterns allowed, e.g. github.com/dddplayer/dp/internal/interfaces/cmd/*.Run.
+	// The main func is used when empty. With FlowSearch.Routes they match the names of
+	// the http routes instead, e.g. GET /orders/*, every route is used when empty.
+	Starts []string
+	// End is the package or function message flows are traced to, Domain when empty.
+	End string
+	// FlowSearch bounds the search of message flow paths, every path is enumerated when zero.
+	FlowSearch FlowSearch
+	// ObjectRepository and RelationRepository receive the analysed code,
+	// in-memory ones are used when nil.
+	ObjectRepository   ObjectRepository
+	RelationRepository RelationRepository
+}
+
+type Result struct {
+	Diagram Diagram
+	Model   *Model
+}
+
+func Strategic(ctx context.Context, opts Options) (*Result, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+	return result(application.StrategicDiagram(ctx, opts.MainPkgPath, opts.Domain, opts.CallGraph, opts.Layout,
+		opts.ObjectRepository, opts.RelationRepository, nil))
+}
+
+func Tactic(ctx context.Context, opts Options) (*Result, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+	return result(application.TacticDiagram(ctx, opts.MainPkgPath, opts.Domain, opts.CallGraph, opts.Layout,
+		opts.ObjectRepository, opts.RelationRepository, nil, opts.Detail, opts.Composition))
+}
+
+func General(ctx context.Context, opts Options) (*Result, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+	return result(application.GeneralDiagram(ctx, opts.MainPkgPath, opts.Domain, opts.CallGraph,
+		opts.ObjectRepository, opts.RelationRepository, nil, opts.Detail, opts.Composition))
+}
+
+func MessageFlow(ctx context.Context, opts Options) (*Result, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+	return result(application.MessageFlowDiagram(ctx, opts.MainPkgPath, opts.Domain, opts.CallGraph,
+		opts.ObjectRepository, opts.RelationRepository, nil, opts.Starts, opts.End, opts.FlowSearch))
+}
+
+// Render writes a diagram as dot, mermaid or plantuml.
+func Render(d Diagram, format Format) (string, error) {
+	return application.Render(d, format)
+}
+
+func (o *Options) normalize() error {
+	if o.MainPkgPath == "" {
+		return errors.New("main package path is required")
+	}
+	if o.Domain == "" {
+		return errors.New("domain package path is required")
+	}
+	if o.CallGraph == "" {
+		o.CallGraph = CallGraphStatic
+	} else if _, err := application.ParseCallGraph(string(o.CallGraph)); err != nil {
+		return err
+	}
+	if o.FlowSearch.Mode == "" {
+		o.FlowSearch.Mode = FlowModeAll
+	}
+	if _, err := application.NewFlowSearch(string(o.FlowSearch.Mode),
+		o.FlowSearch.MaxDepth, o.FlowSearch.MaxPaths, o.FlowSearch.MaxSteps); err != nil {
+		return err
+	}
+	if o.ObjectRepository == nil {
+		o.ObjectRepository = persistence.NewRadixTree()
+	}
+	if o.RelationRepository == nil {
+		o.RelationRepository = &persistence.Relations{}
+	}
+	return nil
+}
+
+func result(d Diagram, m *Model, err error) (*Result, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Diagram: d, Model: m}, nil
+}
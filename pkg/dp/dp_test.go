@@ -0,0 +1,54 @@
+package dp
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestOptions_Normalize(t *testing.T) {
+	opts := Options{MainPkgPath: "~/github/dddplayer/dp", Domain: "github.com/dddplayer/dp/internal/domain"}
+	if err := opts.normalize(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opts.CallGraph != CallGraphStatic {
+		t.Errorf("Expected static call graph by default, but got %s", opts.CallGraph)
+	}
+	if opts.ObjectRepository == nil || opts.RelationRepository == nil {
+		t.Errorf("Expected in-memory repositories by default")
+	}
+	if opts.FlowSearch.Mode != FlowModeAll {
+		t.Errorf("Expected all message flow paths by default, but got %s", opts.FlowSearch.Mode)
+	}
+
+	for _, o := range []Options{
+		{Domain: "github.com/dddplayer/dp/internal/domain"},
+		{MainPkgPath: "~/github/dddplayer/dp"},
+		{MainPkgPath: "~/github/dddplayer/dp", Domain: "github.com/dddplayer/dp/internal/domain", CallGraph: "vta"},
+		{MainPkgPath: "~/github/dddplayer/dp", Domain: "github.com/dddplayer/dp/internal/domain",
+			FlowSearch: FlowSearch{Mode: "longest"}},
+		{MainPkgPath: "~/github/dddplayer/dp", Domain: "github.com/dddplayer/dp/internal/domain",
+			FlowSearch: FlowSearch{MaxDepth: -1}},
+	} {
+		if err := o.normalize(); err == nil {
+			t.Errorf("Expected options %+v to be rejected", o)
+		}
+	}
+}
+
+func TestCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	opts := Options{MainPkgPath: t.TempDir(), Domain: "example.com/app"}
+	for name, analyse := range map[string]func(context.Context, Options) (*Result, error){
+		"strategic":   Strategic,
+		"tactic":      Tactic,
+		"general":     General,
+		"messageflow": MessageFlow,
+	} {
+		if _, err := analyse(ctx, opts); !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected %s to stop with %v, but got %v", name, context.Canceled, err)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package dp_test
+
+import (
+	"context"
+	"errors"
+	"github.com/dddplayer/dp/pkg/dp"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type objectRepository struct {
+	objects map[string]dp.Object
+	idents  []dp.ObjIdentifier
+}
+
+func (r *objectRepository) Find(id dp.ObjIdentifier) dp.Object {
+	return r.objects[id.ID()]
+}
+
+func (r *objectRepository) GetObjects(ids []dp.ObjIdentifier) ([]dp.Object, error) {
+	var objs []dp.Object
+	for _, id := range ids {
+		obj, ok := r.objects[id.ID()]
+		if !ok {
+			return nil, errors.New("object not found")
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func (r *objectRepository) All() []dp.ObjIdentifier {
+	return r.idents
+}
+
+func (r *objectRepository) Insert(obj dp.Object) error {
+	if _, ok := r.objects[obj.Identifier().ID()]; !ok {
+		r.idents = append(r.idents, obj.Identifier())
+	}
+	r.objects[obj.Identifier().ID()] = obj
+	return nil
+}
+
+func (r *objectRepository) Update(obj dp.Object) error {
+	if _, ok := r.objects[obj.Identifier().ID()]; !ok {
+		return errors.New("object not found")
+	}
+	r.objects[obj.Identifier().ID()] = obj
+	return nil
+}
+
+func (r *objectRepository) Delete(id dp.ObjIdentifier) error {
+	delete(r.objects, id.ID())
+	for i, ident := range r.idents {
+		if ident.ID() == id.ID() {
+			r.idents = append(r.idents[:i], r.idents[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (r *objectRepository) Walk(walker func(obj dp.Object) error) {
+	for _, id := range r.idents {
+		if err := walker(r.objects[id.ID()]); err != nil {
+			return
+		}
+	}
+}
+
+type relationRepository struct {
+	relations []dp.Relation
+}
+
+func (r *relationRepository) Insert(rel dp.Relation) error {
+	r.relations = append(r.relations, rel)
+	return nil
+}
+
+func (r *relationRepository) Delete(match func(rel dp.Relation) bool) int {
+	var kept []dp.Relation
+	for _, rel := range r.relations {
+		if !match(rel) {
+			kept = append(kept, rel)
+		}
+	}
+	n := len(r.relations) - len(kept)
+	r.relations = kept
+	return n
+}
+
+func (r *relationRepository) Walk(walker func(rel dp.Relation) error) {
+	for _, rel := range r.relations {
+		if err := walker(rel); err != nil {
+			return
+		}
+	}
+}
+
+func TestGeneral_CustomRepositories(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"go.mod":   "module example.com/shop\n\ngo 1.21\n",
+		"main.go":  "package main\n\nfunc main() { Place() }\n",
+		"order.go": "package main\n\ntype Order struct{ Items []Item }\n\ntype Item struct{}\n\nfunc Place() *Order { return &Order{} }\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	objRepo := &objectRepository{objects: map[string]dp.Object{}}
+	relRepo := &relationRepository{}
+	res, err := dp.General(context.Background(), dp.Options{
+		MainPkgPath:        tempDir,
+		Domain:             "example.com/shop",
+		ObjectRepository:   objRepo,
+		RelationRepository: relRepo,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if res.Diagram == nil {
+		t.Fatal("Expected a diagram")
+	}
+
+	var names []string
+	for _, id := range objRepo.All() {
+		names = append(names, id.Name())
+	}
+	for _, want := range []string{"Order", "Item", "Place"} {
+		found := false
+		for _, name := range names {
+			found = found || name == want
+		}
+		if !found {
+			t.Errorf("Expected %s in the custom object repository, but got %s", want, strings.Join(names, ", "))
+		}
+	}
+	if len(relRepo.relations) == 0 {
+		t.Error("Expected relations in the custom relation repository")
+	}
+}